@@ -285,6 +285,71 @@ func BenchmarkPoolLatency(b *testing.B) {
 	b.ReportMetric(float64(p99.Microseconds()), "p99_μs")
 }
 
+// BenchmarkRPCStreaming compares CallStream's per-chunk throughput against
+// the request/response path (BenchmarkPool) for the same number of logical
+// items: b.N streaming calls each producing chunksPerCall chunks, vs. b.N
+// plain Call()s, run back to back in the same sub-benchmark so ns/op is
+// directly comparable between the two. Requires the worker script to
+// register "stream_predict" via @expose_stream.
+func BenchmarkRPCStreaming(b *testing.B) {
+	const chunksPerCall = 10
+
+	opts := pyproc.PoolOptions{
+		Config: pyproc.PoolConfig{
+			Workers:     4,
+			MaxInFlight: 10,
+		},
+		WorkerConfig: pyproc.WorkerConfig{
+			SocketPath:   "/tmp/bench-streaming.sock",
+			PythonExec:   "python3",
+			WorkerScript: "../examples/basic/worker.py",
+			StartTimeout: 5 * time.Second,
+		},
+	}
+
+	pool, err := pyproc.NewPool(opts, nil)
+	if err != nil {
+		b.Fatalf("failed to create pool: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := pool.Start(ctx); err != nil {
+		b.Fatalf("failed to start pool: %v", err)
+	}
+	defer pool.Shutdown(ctx)
+
+	// Wait for pool to be ready
+	time.Sleep(500 * time.Millisecond)
+
+	input := map[string]interface{}{"value": 42, "chunks": chunksPerCall}
+
+	b.Run("stream", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			msgCh, err := pool.CallStream(ctx, "stream_predict", input)
+			if err != nil {
+				b.Fatalf("CallStream failed: %v", err)
+			}
+			for msg := range msgCh {
+				if msg.Err != nil {
+					b.Fatalf("stream chunk failed: %v", msg.Err)
+				}
+			}
+		}
+		b.ReportMetric(float64(chunksPerCall), "chunks/op")
+	})
+
+	b.Run("request_response", func(b *testing.B) {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var output map[string]interface{}
+			if err := pool.Call(ctx, "predict", input, &output); err != nil {
+				b.Fatalf("call failed: %v", err)
+			}
+		}
+	})
+}
+
 // Helper functions
 
 func generateNumbers(n int) []int {
@@ -0,0 +1,99 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// exportPath, set via -bench.export=path.json (or .csv), tells TestMain to
+// write the accumulated ResultsExporter matrix after the benchmarks finish -
+// a machine-readable protocol x payload size x concurrency -> p50/p95/p99/
+// req/s matrix suitable for diffing across commits in CI.
+var exportPath = flag.String("bench.export", "", "write the RPC comparison results matrix to this path (.json or .csv) for CI regression tracking")
+
+// ExportedResult is one row of the results matrix: a single protocol's run
+// at a given payload size and concurrency level.
+type ExportedResult struct {
+	Protocol    string  `json:"protocol"`
+	PayloadSize string  `json:"payload_size"`
+	Concurrency int     `json:"concurrency"`
+	P50Us       float64 `json:"p50_us"`
+	P95Us       float64 `json:"p95_us"`
+	P99Us       float64 `json:"p99_us"`
+	ReqPerSec   float64 `json:"req_per_sec"`
+}
+
+// ResultsExporter accumulates ExportedResult rows across however many
+// benchmarks ran and writes them to disk as JSON or CSV, chosen by the
+// target path's extension.
+type ResultsExporter struct {
+	mu      sync.Mutex
+	results []ExportedResult
+}
+
+// defaultExporter is the matrix every benchmark in this package records
+// into; TestMain writes it to *exportPath once all benchmarks finish.
+var defaultExporter = &ResultsExporter{}
+
+// Record appends one row to the matrix. Safe for concurrent use, since
+// benchmark subtests may run under -test.parallel.
+func (e *ResultsExporter) Record(r ExportedResult) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.results = append(e.results, r)
+}
+
+// Write serializes the accumulated matrix to path as JSON or CSV, chosen by
+// its extension (".csv", otherwise JSON). A no-op if path is empty, so
+// benchmark runs that don't pass -bench.export are unaffected.
+func (e *ResultsExporter) Write(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	e.mu.Lock()
+	results := append([]ExportedResult(nil), e.results...)
+	e.mu.Unlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("bench: failed to create export file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return writeResultsCSV(f, results)
+	}
+	return json.NewEncoder(f).Encode(results)
+}
+
+func writeResultsCSV(f *os.File, results []ExportedResult) error {
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := w.Write([]string{"protocol", "payload_size", "concurrency", "p50_us", "p95_us", "p99_us", "req_per_sec"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Protocol,
+			r.PayloadSize,
+			strconv.Itoa(r.Concurrency),
+			strconv.FormatFloat(r.P50Us, 'f', -1, 64),
+			strconv.FormatFloat(r.P95Us, 'f', -1, 64),
+			strconv.FormatFloat(r.P99Us, 'f', -1, 64),
+			strconv.FormatFloat(r.ReqPerSec, 'f', -1, 64),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return w.Error()
+}
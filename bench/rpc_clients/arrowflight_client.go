@@ -0,0 +1,110 @@
+//go:build arrowflight
+
+package rpc_clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v14/arrow/flight"
+	"google.golang.org/grpc"
+)
+
+// ArrowFlightClient implements RPCClient over Apache Arrow Flight, dialing
+// udsPath as a "unix:" gRPC target (Flight's control plane is gRPC
+// underneath) and invoking a "call" DoAction carrying the same
+// method/input/ok/body/errorMessage shape GRPCClient's CallRequest/
+// CallResponse carry (see bench/python_servers/arrow_flight_server.py), so
+// this benchmark measures an equivalent round trip.
+type ArrowFlightClient struct {
+	client flight.Client
+}
+
+// NewArrowFlightClient creates a new Arrow Flight client.
+func NewArrowFlightClient() *ArrowFlightClient {
+	return &ArrowFlightClient{}
+}
+
+// Connect dials udsPath as a "unix:" gRPC target.
+func (c *ArrowFlightClient) Connect(udsPath string) error {
+	client, err := flight.NewClientWithMiddleware("unix:"+udsPath, nil, nil, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("failed to dial arrow flight server: %w", err)
+	}
+	c.client = client
+	return nil
+}
+
+// flightCallRequest/flightCallResponse mirror the JSON body
+// arrow_flight_server.py's do_action expects/returns for a "call" action.
+type flightCallRequest struct {
+	Method string          `json:"method"`
+	Input  json.RawMessage `json:"input"`
+}
+
+type flightCallResponse struct {
+	OK           bool            `json:"ok"`
+	Body         json.RawMessage `json:"body"`
+	ErrorMessage string          `json:"error_message"`
+}
+
+// Call invokes a "call" DoAction, marshaling args to JSON for the input
+// field the same way GRPCClient marshals CallRequest.Input.
+func (c *ArrowFlightClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	if c.client == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	body, err := json.Marshal(flightCallRequest{Method: method, Input: input})
+	if err != nil {
+		return fmt.Errorf("failed to marshal action body: %w", err)
+	}
+
+	stream, err := c.client.DoAction(ctx, &flight.Action{Type: "call", Body: body})
+	if err != nil {
+		return fmt.Errorf("arrow flight DoAction failed: %w", err)
+	}
+
+	result, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("arrow flight: server returned no result")
+		}
+		return fmt.Errorf("arrow flight: failed to read result: %w", err)
+	}
+
+	var resp flightCallResponse
+	if err := json.Unmarshal(result.GetBody(), &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	if !resp.OK {
+		return fmt.Errorf("arrow flight error: %s", resp.ErrorMessage)
+	}
+
+	if reply != nil && len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, reply); err != nil {
+			return fmt.Errorf("failed to unmarshal result body: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close terminates the connection.
+func (c *ArrowFlightClient) Close() error {
+	if c.client != nil {
+		return c.client.Close()
+	}
+	return nil
+}
+
+// Name returns the protocol identifier
+func (c *ArrowFlightClient) Name() string {
+	return "arrow-flight"
+}
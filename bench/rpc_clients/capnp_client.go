@@ -0,0 +1,112 @@
+//go:build capnp
+
+package rpc_clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"capnproto.org/go/capnp/v3/rpc"
+
+	"github.com/YuminosukeSato/pyproc/bench/rpc_clients/capnpapi"
+)
+
+// CapnProtoClient implements RPCClient over Cap'n Proto RPC, dialing udsPath
+// and speaking the PyProcService.call interface described in pyproc.capnp -
+// the same method/input/ok/body/errorMessage shape GRPCClient's CallRequest/
+// CallResponse carry, so this benchmark measures an equivalent call.
+//
+// Building with this tag requires capnpapi to have been generated first:
+//
+//	capnp compile -ogo bench/rpc_clients/pyproc.capnp
+//
+// capnpapi isn't vendored into this tree, so the default build excludes
+// this file - see capnp_client_stub.go.
+type CapnProtoClient struct {
+	conn      net.Conn
+	rpcConn   *rpc.Conn
+	client    capnpapi.PyProcService
+	requestID uint64
+}
+
+// NewCapnProtoClient creates a new Cap'n Proto client
+func NewCapnProtoClient() *CapnProtoClient {
+	return &CapnProtoClient{}
+}
+
+// Connect dials udsPath and bootstraps the PyProcService capability over it.
+func (c *CapnProtoClient) Connect(udsPath string) error {
+	conn, err := net.Dial("unix", udsPath)
+	if err != nil {
+		return fmt.Errorf("failed to dial capnp server: %w", err)
+	}
+
+	c.conn = conn
+	c.rpcConn = rpc.NewConn(rpc.NewStreamTransport(conn), nil)
+	c.client = capnpapi.PyProcService(c.rpcConn.Bootstrap(context.Background()))
+	return nil
+}
+
+// Call invokes PyProcService.call, marshaling args to JSON for the input
+// field the same way GRPCClient marshals CallRequest.Input.
+func (c *CapnProtoClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	if c.rpcConn == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	fut, release := c.client.Call(ctx, func(p capnpapi.PyProcService_call_Params) error {
+		p.SetId(atomic.AddUint64(&c.requestID, 1))
+		if err := p.SetMethod(method); err != nil {
+			return err
+		}
+		return p.SetInput(input)
+	})
+	defer release()
+
+	res, err := fut.Struct()
+	if err != nil {
+		return fmt.Errorf("capnp call failed: %w", err)
+	}
+	if !res.Ok() {
+		msg, _ := res.ErrorMessage()
+		return fmt.Errorf("capnp error: %s", msg)
+	}
+
+	if reply != nil {
+		body, err := res.Body()
+		if err != nil {
+			return fmt.Errorf("failed to read result body: %w", err)
+		}
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, reply); err != nil {
+				return fmt.Errorf("failed to unmarshal result: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close terminates the connection
+func (c *CapnProtoClient) Close() error {
+	if c.rpcConn != nil {
+		_ = c.rpcConn.Close()
+	}
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// Name returns the protocol identifier
+func (c *CapnProtoClient) Name() string {
+	return "capnproto"
+}
@@ -0,0 +1,42 @@
+//go:build !capnp
+
+package rpc_clients
+
+import (
+	"context"
+	"fmt"
+)
+
+// CapnProtoClient is a placeholder RPCClient for Cap'n Proto RPC, built when
+// the "capnp" tag isn't set. The real client (capnp_client.go) depends on
+// capnpapi, generated from pyproc.capnp via `capnp compile -ogo`, which
+// isn't vendored into every build - rebuild with -tags capnp after
+// generating it to get a working Cap'n Proto comparison point. Connect
+// fails clearly instead of silently measuring nothing, so a benchmark run
+// reports it as skipped rather than passing.
+type CapnProtoClient struct{}
+
+// NewCapnProtoClient creates a new Cap'n Proto client stub.
+func NewCapnProtoClient() *CapnProtoClient {
+	return &CapnProtoClient{}
+}
+
+// Connect always fails: see the CapnProtoClient doc comment.
+func (c *CapnProtoClient) Connect(udsPath string) error {
+	return fmt.Errorf("capnproto: not built into this binary (rebuild with -tags capnp after generating capnpapi)")
+}
+
+// Call is unreachable since Connect always fails.
+func (c *CapnProtoClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	return fmt.Errorf("capnproto: not implemented")
+}
+
+// Close is a no-op.
+func (c *CapnProtoClient) Close() error {
+	return nil
+}
+
+// Name returns the protocol identifier
+func (c *CapnProtoClient) Name() string {
+	return "capnproto"
+}
@@ -0,0 +1,86 @@
+package rpc_clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pyprocv1 "github.com/YuminosukeSato/pyproc/api/v1"
+)
+
+// GRPCClient implements RPCClient over gRPC, dialing a "unix:///path" target
+// with insecure credentials - the same PyProcService CallRequest/CallResponse
+// pair GRPCTransport (pkg/pyproc/transport_grpc.go) speaks in production, so
+// this benchmark measures the real wire protocol rather than a stand-in.
+type GRPCClient struct {
+	conn      *grpc.ClientConn
+	client    pyprocv1.PyProcServiceClient
+	requestID uint64
+}
+
+// NewGRPCClient creates a new gRPC client
+func NewGRPCClient() *GRPCClient {
+	return &GRPCClient{}
+}
+
+// Connect dials udsPath as a "unix://" gRPC target.
+func (c *GRPCClient) Connect(udsPath string) error {
+	conn, err := grpc.NewClient("unix://"+udsPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial gRPC server: %w", err)
+	}
+
+	c.conn = conn
+	c.client = pyprocv1.NewPyProcServiceClient(conn)
+	return nil
+}
+
+// Call invokes the PyProcService.Call RPC, marshaling args to JSON for
+// CallRequest.Input the same way pyproc's own codecs encode request bodies.
+func (c *GRPCClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	if c.client == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := c.client.Call(ctx, &pyprocv1.CallRequest{
+		Id:     atomic.AddUint64(&c.requestID, 1),
+		Method: method,
+		Input:  input,
+	})
+	if err != nil {
+		return fmt.Errorf("gRPC call failed: %w", err)
+	}
+	if !resp.Ok {
+		return fmt.Errorf("gRPC error: %s", resp.ErrorMessage)
+	}
+
+	if reply != nil && len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, reply); err != nil {
+			return fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close terminates the connection
+func (c *GRPCClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// Name returns the protocol identifier
+func (c *GRPCClient) Name() string {
+	return "grpc"
+}
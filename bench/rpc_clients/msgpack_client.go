@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -12,12 +13,23 @@ import (
 	"github.com/vmihailenco/msgpack/v5"
 )
 
-// MsgpackRPCClient implements MessagePack-RPC protocol over Unix Domain Socket
+// MsgpackRPCClient implements MessagePack-RPC protocol over Unix Domain
+// Socket. A background readLoop demultiplexes responses by msgid into a
+// map[uint32]chan *MsgpackResponse, so Call may be invoked concurrently from
+// multiple goroutines instead of serializing the whole round trip under a
+// single mutex.
 type MsgpackRPCClient struct {
 	conn      net.Conn
 	udsPath   string
 	requestID uint32
-	mu        sync.Mutex
+
+	writeMu sync.Mutex // serializes writes to conn; reads only happen in readLoop
+
+	pendingMu sync.Mutex
+	pending   map[uint32]chan *MsgpackResponse
+
+	readErr  atomic.Value // error, set once readLoop exits
+	closedCh chan struct{}
 }
 
 // MsgpackRequest represents a MessagePack-RPC request
@@ -29,6 +41,14 @@ type MsgpackRequest struct {
 	Params interface{} // Parameters
 }
 
+// MsgpackNotification represents a MessagePack-RPC notification.
+// Format: [type, method, params] - no msgid, no reply expected.
+type MsgpackNotification struct {
+	Type   uint8       // 2 for notification
+	Method string      // Method name
+	Params interface{} // Parameters
+}
+
 // MsgpackResponse represents a MessagePack-RPC response
 // Format: [type, msgid, error, result]
 type MsgpackResponse struct {
@@ -40,7 +60,10 @@ type MsgpackResponse struct {
 
 // NewMsgpackRPCClient creates a new MessagePack-RPC client
 func NewMsgpackRPCClient() *MsgpackRPCClient {
-	return &MsgpackRPCClient{}
+	return &MsgpackRPCClient{
+		pending:  make(map[uint32]chan *MsgpackResponse),
+		closedCh: make(chan struct{}),
+	}
 }
 
 // Connect establishes connection to MessagePack-RPC server via UDS
@@ -52,100 +75,211 @@ func (c *MsgpackRPCClient) Connect(udsPath string) error {
 
 	c.conn = conn
 	c.udsPath = udsPath
+	go c.readLoop()
 	return nil
 }
 
-// Call invokes a MessagePack-RPC method
+// readLoop owns all reads from conn: it reads one length-prefixed response
+// at a time, decodes its msgid, and routes it to the waiter Call registered
+// in pending. It runs until conn errors or is closed, at which point every
+// still-waiting Call is unblocked with the read error.
+func (c *MsgpackRPCClient) readLoop() {
+	lenBuf := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(c.conn, lenBuf); err != nil {
+			c.failPending(fmt.Errorf("failed to read response length: %w", err))
+			return
+		}
+		respLen := binary.BigEndian.Uint32(lenBuf)
+
+		respData := make([]byte, respLen)
+		if _, err := io.ReadFull(c.conn, respData); err != nil {
+			c.failPending(fmt.Errorf("failed to read response: %w", err))
+			return
+		}
+
+		resp, err := decodeMsgpackResponse(respData)
+		if err != nil {
+			c.failPending(err)
+			return
+		}
+
+		c.pendingMu.Lock()
+		ch, ok := c.pending[resp.MsgID]
+		if ok {
+			delete(c.pending, resp.MsgID)
+		}
+		c.pendingMu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// failPending records err and unblocks every Call still waiting on a
+// response - the connection is unusable for the rest of pending's callers
+// once a read fails, since readLoop is the only goroutine that will ever
+// deliver their response.
+func (c *MsgpackRPCClient) failPending(err error) {
+	c.readErr.Store(err)
+	close(c.closedCh)
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for msgID, ch := range c.pending {
+		close(ch)
+		delete(c.pending, msgID)
+	}
+}
+
+func decodeMsgpackResponse(data []byte) (*MsgpackResponse, error) {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	var raw []interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(raw) != 4 {
+		return nil, fmt.Errorf("invalid response format")
+	}
+
+	respType, ok := raw[0].(uint8)
+	if !ok || respType != 1 {
+		return nil, fmt.Errorf("invalid response type")
+	}
+	msgID, ok := raw[1].(uint32)
+	if !ok {
+		return nil, fmt.Errorf("invalid response message id")
+	}
+
+	return &MsgpackResponse{
+		Type:   1,
+		MsgID:  msgID,
+		Error:  raw[2],
+		Result: raw[3],
+	}, nil
+}
+
+// Call invokes a MessagePack-RPC method. Safe to call concurrently from
+// multiple goroutines: each call gets its own msgid and waits on its own
+// response channel, so concurrent calls no longer block each other
+// head-of-line on a shared read.
 func (c *MsgpackRPCClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
 	if c.conn == nil {
 		return fmt.Errorf("not connected")
 	}
 
-	// Generate unique request ID
 	msgID := atomic.AddUint32(&c.requestID, 1)
 
-	// Create MessagePack-RPC request array
-	request := []interface{}{
-		uint8(0), // Request type
-		msgID,    // Message ID
-		method,   // Method name
-		args,     // Parameters
-	}
+	respCh := make(chan *MsgpackResponse, 1)
+	c.pendingMu.Lock()
+	c.pending[msgID] = respCh
+	c.pendingMu.Unlock()
 
-	// Encode request
-	var buf bytes.Buffer
-	encoder := msgpack.NewEncoder(&buf)
-	if err := encoder.Encode(request); err != nil {
-		return fmt.Errorf("failed to encode request: %w", err)
+	if err := c.send(0, msgID, method, args); err != nil {
+		c.pendingMu.Lock()
+		delete(c.pending, msgID)
+		c.pendingMu.Unlock()
+		return err
 	}
 
-	// Send request with length prefix (4 bytes)
-	reqData := buf.Bytes()
-	lenBuf := make([]byte, 4)
-	binary.BigEndian.PutUint32(lenBuf, uint32(len(reqData)))
-
-	c.mu.Lock()
-	if _, err := c.conn.Write(lenBuf); err != nil {
-		c.mu.Unlock()
-		return fmt.Errorf("failed to send length prefix: %w", err)
-	}
-	if _, err := c.conn.Write(reqData); err != nil {
-		c.mu.Unlock()
-		return fmt.Errorf("failed to send request: %w", err)
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			if err, _ := c.readErr.Load().(error); err != nil {
+				return err
+			}
+			return fmt.Errorf("connection closed before response arrived")
+		}
+		return applyMsgpackResponse(resp, reply)
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, msgID)
+		c.pendingMu.Unlock()
+		return ctx.Err()
+	case <-c.closedCh:
+		if err, _ := c.readErr.Load().(error); err != nil {
+			return err
+		}
+		return fmt.Errorf("connection closed before response arrived")
 	}
+}
 
-	// Read response length
-	if _, err := c.conn.Read(lenBuf); err != nil {
-		c.mu.Unlock()
-		return fmt.Errorf("failed to read response length: %w", err)
+// Notify sends a MessagePack-RPC type-2 notification array ([2, method,
+// params]) and returns as soon as it's written - there is no msgid and no
+// reply to wait for, matching the fire-and-forget half of the
+// MessagePack-RPC spec.
+func (c *MsgpackRPCClient) Notify(ctx context.Context, method string, args interface{}) error {
+	if c.conn == nil {
+		return fmt.Errorf("not connected")
 	}
-	respLen := binary.BigEndian.Uint32(lenBuf)
 
-	// Read response data
-	respData := make([]byte, respLen)
-	if _, err := c.conn.Read(respData); err != nil {
-		c.mu.Unlock()
-		return fmt.Errorf("failed to read response: %w", err)
+	notification := []interface{}{
+		uint8(2), // Notification type
+		method,
+		args,
 	}
-	c.mu.Unlock()
 
-	// Decode response
-	decoder := msgpack.NewDecoder(bytes.NewReader(respData))
-	var response []interface{}
-	if err := decoder.Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	if err := encoder.Encode(notification); err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
 	}
 
-	// Validate response format
-	if len(response) != 4 {
-		return fmt.Errorf("invalid response format")
+	return c.writeFramed(buf.Bytes())
+}
+
+// send encodes and writes a MessagePack-RPC request array.
+func (c *MsgpackRPCClient) send(msgType uint8, msgID uint32, method string, args interface{}) error {
+	request := []interface{}{
+		msgType,
+		msgID,
+		method,
+		args,
 	}
 
-	// Check message type (should be 1 for response)
-	if respType, ok := response[0].(uint8); !ok || respType != 1 {
-		return fmt.Errorf("invalid response type")
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	if err := encoder.Encode(request); err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
 	}
 
-	// Check message ID matches
-	if respID, ok := response[1].(uint32); !ok || respID != msgID {
-		return fmt.Errorf("message ID mismatch")
+	return c.writeFramed(buf.Bytes())
+}
+
+// writeFramed writes data with its 4-byte big-endian length prefix, holding
+// writeMu for the duration so concurrent Call/Notify writers can't
+// interleave their length prefix and payload.
+func (c *MsgpackRPCClient) writeFramed(data []byte) error {
+	lenBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lenBuf, uint32(len(data)))
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err := c.conn.Write(lenBuf); err != nil {
+		return fmt.Errorf("failed to send length prefix: %w", err)
 	}
+	if _, err := c.conn.Write(data); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	return nil
+}
 
-	// Check for error
-	if response[2] != nil {
-		return fmt.Errorf("MessagePack-RPC error: %v", response[2])
+// applyMsgpackResponse checks resp for an RPC-level error and, if none,
+// copies its result into reply.
+func applyMsgpackResponse(resp *MsgpackResponse, reply interface{}) error {
+	if resp.Error != nil {
+		return fmt.Errorf("MessagePack-RPC error: %v", resp.Error)
 	}
 
-	// Extract result
-	if reply != nil && response[3] != nil {
-		// Convert response[3] to the expected reply type
+	if reply != nil && resp.Result != nil {
+		// Convert resp.Result to the expected reply type
 		// This is simplified for benchmark purposes
 		if m, ok := reply.(*map[string]interface{}); ok {
-			if result, ok := response[3].(map[string]interface{}); ok {
+			if result, ok := resp.Result.(map[string]interface{}); ok {
 				*m = result
 			} else {
 				*m = map[string]interface{}{
-					"result": response[3],
+					"result": resp.Result,
 				}
 			}
 		}
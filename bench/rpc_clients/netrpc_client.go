@@ -0,0 +1,58 @@
+package rpc_clients
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/rpc"
+)
+
+// NetRPCClient implements RPCClient over the standard library's net/rpc with
+// its default gob codec - the simplest baseline a Go-only comparison can
+// offer, with none of the framing/codec machinery the other clients hand-roll.
+type NetRPCClient struct {
+	client *rpc.Client
+}
+
+// NewNetRPCClient creates a new net/rpc client
+func NewNetRPCClient() *NetRPCClient {
+	return &NetRPCClient{}
+}
+
+// Connect dials udsPath and wraps it in a gob-codec net/rpc client.
+func (c *NetRPCClient) Connect(udsPath string) error {
+	conn, err := net.Dial("unix", udsPath)
+	if err != nil {
+		return fmt.Errorf("failed to connect to net/rpc server: %w", err)
+	}
+
+	c.client = rpc.NewClient(conn)
+	return nil
+}
+
+// Call invokes method via net/rpc. net/rpc has no context support, so ctx
+// cancellation can't interrupt a call already in flight - benchmarks don't
+// rely on that, but callers needing it should prefer another client.
+func (c *NetRPCClient) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	if c.client == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	if err := c.client.Call(method, args, reply); err != nil {
+		return fmt.Errorf("net/rpc call failed: %w", err)
+	}
+	return nil
+}
+
+// Close terminates the connection
+func (c *NetRPCClient) Close() error {
+	if c.client != nil {
+		return c.client.Close()
+	}
+	return nil
+}
+
+// Name returns the protocol identifier
+func (c *NetRPCClient) Name() string {
+	return "net-rpc"
+}
@@ -3,12 +3,13 @@ package rpc_clients
 import (
 	"bytes"
 	"context"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"sync"
+
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc/xmlrpc"
 )
 
 // XMLRPCClient implements XML-RPC protocol over Unix Domain Socket
@@ -43,8 +44,13 @@ func (c *XMLRPCClient) Call(ctx context.Context, method string, args interface{}
 		return fmt.Errorf("not connected")
 	}
 
-	// Create XML-RPC request
-	request, err := c.encodeRequest(method, args)
+	// Create XML-RPC request. args is passed as the single positional param,
+	// matching the pyproc XML-RPC gateway's PoolDispatcher convention.
+	var params []interface{}
+	if args != nil {
+		params = []interface{}{args}
+	}
+	request, err := xmlrpc.EncodeCall(method, params)
 	if err != nil {
 		return fmt.Errorf("failed to encode request: %w", err)
 	}
@@ -75,88 +81,23 @@ func (c *XMLRPCClient) Call(ctx context.Context, method string, args interface{}
 	return c.decodeResponse(body, reply)
 }
 
-// encodeRequest encodes method call to XML-RPC format
-func (c *XMLRPCClient) encodeRequest(method string, args interface{}) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// Write XML header
-	buf.WriteString(`<?xml version="1.0"?>`)
-	buf.WriteString(`<methodCall>`)
-	buf.WriteString(`<methodName>` + method + `</methodName>`)
-	buf.WriteString(`<params>`)
-
-	// Encode parameters
-	if args != nil {
-		buf.WriteString(`<param>`)
-		if err := c.encodeValue(&buf, args); err != nil {
-			return nil, err
-		}
-		buf.WriteString(`</param>`)
-	}
-
-	buf.WriteString(`</params>`)
-	buf.WriteString(`</methodCall>`)
-
-	return buf.Bytes(), nil
-}
-
-// encodeValue encodes a value to XML-RPC format
-func (c *XMLRPCClient) encodeValue(buf *bytes.Buffer, v interface{}) error {
-	buf.WriteString(`<value>`)
-
-	switch val := v.(type) {
-	case int:
-		buf.WriteString(fmt.Sprintf(`<int>%d</int>`, val))
-	case string:
-		buf.WriteString(`<string>`)
-		xml.EscapeText(buf, []byte(val))
-		buf.WriteString(`</string>`)
-	case map[string]interface{}:
-		buf.WriteString(`<struct>`)
-		for k, v := range val {
-			buf.WriteString(`<member>`)
-			buf.WriteString(`<name>` + k + `</name>`)
-			if err := c.encodeValue(buf, v); err != nil {
-				return err
-			}
-			buf.WriteString(`</member>`)
-		}
-		buf.WriteString(`</struct>`)
-	case []interface{}:
-		buf.WriteString(`<array><data>`)
-		for _, item := range val {
-			if err := c.encodeValue(buf, item); err != nil {
-				return err
-			}
-		}
-		buf.WriteString(`</data></array>`)
-	default:
-		// Simplified encoding for benchmark purposes
-		buf.WriteString(fmt.Sprintf(`<string>%v</string>`, val))
-	}
-
-	buf.WriteString(`</value>`)
-	return nil
-}
-
-// decodeResponse decodes XML-RPC response
+// decodeResponse decodes an XML-RPC methodResponse body into reply, which
+// must be a pointer. A <fault> response is returned as a *xmlrpc.Fault error.
 func (c *XMLRPCClient) decodeResponse(data []byte, reply interface{}) error {
-	// Simplified XML parsing for benchmark purposes
-	// In production, use proper XML-RPC library
+	result, err := xmlrpc.DecodeResponse(data)
+	if err != nil {
+		return err
+	}
 
-	// Check for fault
-	if bytes.Contains(data, []byte("<fault>")) {
-		return fmt.Errorf("XML-RPC fault in response")
+	if reply == nil {
+		return nil
 	}
 
-	// Extract result value (simplified)
-	// In real implementation, properly parse XML structure
-	if reply != nil {
-		// For benchmark purposes, we'll just set a simple result
-		if m, ok := reply.(*map[string]interface{}); ok {
-			*m = map[string]interface{}{
-				"result": "processed",
-			}
+	if m, ok := reply.(*map[string]interface{}); ok {
+		if resultMap, ok := result.(map[string]interface{}); ok {
+			*m = resultMap
+		} else {
+			*m = map[string]interface{}{"result": result}
 		}
 	}
 
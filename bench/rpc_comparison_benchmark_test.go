@@ -35,6 +35,10 @@ func TestMain(m *testing.M) {
 	// Cleanup
 	stopPythonServers()
 
+	if err := defaultExporter.Write(*exportPath); err != nil {
+		fmt.Printf("failed to write bench export: %v\n", err)
+	}
+
 	os.Exit(code)
 }
 
@@ -55,6 +59,8 @@ func startPythonServers() error {
 		{"jsonrpc", "jsonrpc_server.py", "/tmp/bench-jsonrpc.sock"},
 		{"xmlrpc", "xmlrpc_server.py", "/tmp/bench-xmlrpc.sock"},
 		{"msgpack", "msgpack_server.py", "/tmp/bench-msgpack.sock"},
+		{"grpc", "grpc_server.py", "/tmp/bench-grpc.sock"},
+		{"arrow-flight", "arrow_flight_server.py", "/tmp/bench-arrowflight.sock"},
 	}
 
 	// Start each server
@@ -123,6 +129,10 @@ func stopPythonServers() {
 		"/tmp/bench-xmlrpc.sock",
 		"/tmp/bench-msgpack.sock",
 		"/tmp/bench-pyproc.sock",
+		"/tmp/bench-grpc.sock",
+		"/tmp/bench-netrpc.sock",
+		"/tmp/bench-capnp.sock",
+		"/tmp/bench-arrowflight.sock",
 	}
 
 	for _, socket := range sockets {
@@ -168,6 +178,26 @@ func BenchmarkRPCProtocols(b *testing.B) {
 			client: rpc_clients.NewMsgpackRPCClient(),
 			socket: "/tmp/bench-msgpack.sock",
 		},
+		{
+			name:   "grpc",
+			client: rpc_clients.NewGRPCClient(),
+			socket: "/tmp/bench-grpc.sock",
+		},
+		{
+			name:   "net-rpc",
+			client: rpc_clients.NewNetRPCClient(),
+			socket: "/tmp/bench-netrpc.sock",
+		},
+		{
+			name:   "capnproto",
+			client: rpc_clients.NewCapnProtoClient(),
+			socket: "/tmp/bench-capnp.sock",
+		},
+		{
+			name:   "arrow-flight",
+			client: rpc_clients.NewArrowFlightClient(),
+			socket: "/tmp/bench-arrowflight.sock",
+		},
 	}
 
 	// Test with different payload sizes
@@ -238,6 +268,21 @@ func BenchmarkRPCLatency(b *testing.B) {
 			client: rpc_clients.NewMsgpackRPCClient(),
 			socket: "/tmp/bench-msgpack.sock",
 		},
+		{
+			name:   "grpc",
+			client: rpc_clients.NewGRPCClient(),
+			socket: "/tmp/bench-grpc.sock",
+		},
+		{
+			name:   "net-rpc",
+			client: rpc_clients.NewNetRPCClient(),
+			socket: "/tmp/bench-netrpc.sock",
+		},
+		{
+			name:   "arrow-flight",
+			client: rpc_clients.NewArrowFlightClient(),
+			socket: "/tmp/bench-arrowflight.sock",
+		},
 	}
 
 	payload := rpc_clients.SmallPayload()
@@ -280,11 +325,23 @@ func BenchmarkRPCLatency(b *testing.B) {
 			p50 := latencies[len(latencies)*50/100]
 			p95 := latencies[len(latencies)*95/100]
 			p99 := latencies[len(latencies)*99/100]
+			reqPerSec := float64(b.N) / b.Elapsed().Seconds()
 
 			// Report metrics
 			b.ReportMetric(float64(p50.Microseconds()), "p50_μs")
 			b.ReportMetric(float64(p95.Microseconds()), "p95_μs")
 			b.ReportMetric(float64(p99.Microseconds()), "p99_μs")
+			b.ReportMetric(reqPerSec, "req/s")
+
+			defaultExporter.Record(ExportedResult{
+				Protocol:    clientConfig.name,
+				PayloadSize: payload.Size,
+				Concurrency: 1,
+				P50Us:       float64(p50.Microseconds()),
+				P95Us:       float64(p95.Microseconds()),
+				P99Us:       float64(p99.Microseconds()),
+				ReqPerSec:   reqPerSec,
+			})
 		})
 	}
 }
@@ -15,6 +15,30 @@ import (
 //go:embed templates/*
 var templates embed.FS
 
+// presetTemplates maps each --template preset to the extra files it emits
+// on top of (and, per runInit, in place of) the base scaffold: tmplPath is
+// the embedded template, relPath is where it lands relative to the project
+// directory.
+var presetTemplates = map[string]map[string]string{
+	"grpc": {
+		"templates/grpc/server.go.tmpl":           filepath.Join("cmd", "app", "main.go"),
+		"templates/grpc/service.proto.tmpl":       filepath.Join("api", "service.proto"),
+		"templates/grpc/worker.py.tmpl":           filepath.Join("worker", "python", "worker.py"),
+		"templates/grpc/docker-compose.yml.tmpl":  "docker-compose.yml",
+	},
+	"kafka": {
+		"templates/kafka/consumer.go.tmpl":        filepath.Join("cmd", "app", "main.go"),
+		"templates/kafka/worker.py.tmpl":          filepath.Join("worker", "python", "worker.py"),
+		"templates/kafka/docker-compose.yml.tmpl": "docker-compose.yml",
+	},
+	"openapi": {
+		"templates/openapi/server.go.tmpl":          filepath.Join("cmd", "app", "main.go"),
+		"templates/openapi/openapi.yaml.tmpl":       filepath.Join("api", "openapi.yaml"),
+		"templates/openapi/worker.py.tmpl":          filepath.Join("worker", "python", "worker.py"),
+		"templates/openapi/docker-compose.yml.tmpl": "docker-compose.yml",
+	},
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "pyproc",
 	Short: "PyProc - Call Python from Go without CGO",
@@ -46,6 +70,7 @@ func init() {
 	initCmd.Flags().String("go-module", "", "Go module name (e.g., github.com/user/project)")
 	initCmd.Flags().Bool("with-docker", false, "Include Docker Compose configuration")
 	initCmd.Flags().Bool("with-k8s", false, "Include Kubernetes manifests")
+	initCmd.Flags().String("template", "", "Project preset: grpc, kafka, or openapi (default: minimal hello-world)")
 
 	scaffoldCmd.Flags().String("name", "worker", "Name of the worker")
 	scaffoldCmd.Flags().String("output", ".", "Output directory")
@@ -67,6 +92,13 @@ func runInit(cmd *cobra.Command, args []string) error {
 	goModule, _ := cmd.Flags().GetString("go-module")
 	withDocker, _ := cmd.Flags().GetBool("with-docker")
 	withK8s, _ := cmd.Flags().GetBool("with-k8s")
+	preset, _ := cmd.Flags().GetString("template")
+
+	if preset != "" {
+		if _, ok := presetTemplates[preset]; !ok {
+			return fmt.Errorf("unknown template preset: %s (use 'grpc', 'kafka', or 'openapi')", preset)
+		}
+	}
 
 	if goModule == "" {
 		goModule = fmt.Sprintf("github.com/example/%s", projectName)
@@ -124,13 +156,43 @@ func runInit(cmd *cobra.Command, args []string) error {
 		files["templates/k8s-service.yaml.tmpl"] = filepath.Join(k8sDir, "service.yaml")
 	}
 
+	if preset != "" {
+		// Preset templates replace the base scaffold's app entrypoint and
+		// worker - a gRPC project's cmd/app/main.go is the gRPC server, not
+		// the plain hello-world loop - so drop the base templates targeting
+		// the same output paths before adding the preset's own.
+		delete(files, "templates/main.go.tmpl")
+		delete(files, "templates/worker.py.tmpl")
+		delete(files, "templates/docker-compose.yml.tmpl")
+
+		// Every preset ships its own docker-compose.yml (with the broker/
+		// service it needs), so it needs the Dockerfiles that compose
+		// file builds regardless of --with-docker.
+		if !withDocker {
+			files["templates/Dockerfile.go.tmpl"] = filepath.Join(projectName, "Dockerfile.go")
+			files["templates/Dockerfile.python.tmpl"] = filepath.Join(projectName, "Dockerfile.python")
+		}
+
+		for tmplPath, relPath := range presetTemplates[preset] {
+			outPath := filepath.Join(projectName, relPath)
+			if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", outPath, err)
+			}
+			files[tmplPath] = outPath
+		}
+	}
+
 	for tmplPath, outPath := range files {
 		if err := generateFromTemplate(tmplPath, outPath, data); err != nil {
 			return fmt.Errorf("failed to generate %s: %w", outPath, err)
 		}
 	}
 
-	fmt.Printf("âœ… Created PyProc project: %s\n", projectName)
+	if preset != "" {
+		fmt.Printf("âœ… Created PyProc project: %s (template: %s)\n", projectName, preset)
+	} else {
+		fmt.Printf("âœ… Created PyProc project: %s\n", projectName)
+	}
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  cd %s\n", projectName)
 	fmt.Printf("  go mod tidy\n")
@@ -0,0 +1,98 @@
+package framing
+
+import "sync"
+
+// BufferPool is a pooled byte-buffer allocator for framing's read/write hot
+// path, mirroring grpc-go's mem.BufferPool. It exists so ReadMessage/
+// ReadFrame/WriteFrame can reuse buffers across calls instead of making a
+// fresh one every time, which matters once payloads reach the hundreds of
+// KB to MB range.
+type BufferPool interface {
+	// Get returns a buffer whose length is exactly length. Its capacity may
+	// be larger, borrowed from whichever size bucket the implementation
+	// keeps length in.
+	Get(length int) *[]byte
+	// Put returns buf to the pool for reuse. Callers must not touch buf (or
+	// any slice derived from it) again afterwards.
+	Put(buf *[]byte)
+}
+
+// bufferPoolBucketSizes are the power-of-two bucket boundaries
+// tieredBufferPool allocates. A request larger than the biggest bucket gets
+// a one-off buffer that Put declines to pool (so a single oversized payload
+// can't permanently inflate every bucket's steady-state memory).
+var bufferPoolBucketSizes = []int{256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304}
+
+// tieredBufferPool is the default BufferPool: one sync.Pool per bucket in
+// bufferPoolBucketSizes, so Get(length) always hands out a buffer sized to
+// length's bucket rather than exactly length, keeping each bucket's pooled
+// buffers fungible.
+type tieredBufferPool struct {
+	buckets []sync.Pool
+}
+
+// NewBufferPool creates a tiered, bucketed BufferPool - the implementation
+// NewFramer and NewFramerWithMaxSize plumb in by default.
+func NewBufferPool() BufferPool {
+	p := &tieredBufferPool{buckets: make([]sync.Pool, len(bufferPoolBucketSizes))}
+	for i, size := range bufferPoolBucketSizes {
+		size := size
+		p.buckets[i].New = func() interface{} {
+			buf := make([]byte, size)
+			return &buf
+		}
+	}
+	return p
+}
+
+// bucketIndex returns the index of the smallest bucket that fits size, or -1
+// if size exceeds every bucket.
+func bucketIndex(size int) int {
+	for i, bucketSize := range bufferPoolBucketSizes {
+		if size <= bucketSize {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *tieredBufferPool) Get(length int) *[]byte {
+	idx := bucketIndex(length)
+	if idx < 0 {
+		buf := make([]byte, length)
+		return &buf
+	}
+	buf := p.buckets[idx].Get().(*[]byte)
+	*buf = (*buf)[:length]
+	return buf
+}
+
+func (p *tieredBufferPool) Put(buf *[]byte) {
+	if buf == nil {
+		return
+	}
+	idx := bucketIndex(cap(*buf))
+	if idx < 0 || cap(*buf) != bufferPoolBucketSizes[idx] {
+		// Not one of our bucket sizes - an oversized one-off Get, or a
+		// caller-constructed slice. Let the GC reclaim it instead of
+		// polluting a bucket with the wrong capacity.
+		return
+	}
+	*buf = (*buf)[:bufferPoolBucketSizes[idx]]
+	p.buckets[idx].Put(buf)
+}
+
+// NopBufferPool is a BufferPool that always allocates fresh and never
+// reuses a buffer. It exists so benchmarks can compare pooled vs. unpooled
+// allocation under the same Framer code path, and for callers who'd rather
+// opt out of pooling than reason about its buffer lifetimes.
+type NopBufferPool struct{}
+
+// Get returns a freshly allocated buffer of exactly length bytes.
+func (NopBufferPool) Get(length int) *[]byte {
+	buf := make([]byte, length)
+	return &buf
+}
+
+// Put is a no-op: NopBufferPool never reuses buffers.
+func (NopBufferPool) Put(*[]byte) {}
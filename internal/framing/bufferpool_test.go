@@ -0,0 +1,119 @@
+package framing
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestTieredBufferPoolGetReturnsExactLength(t *testing.T) {
+	p := NewBufferPool()
+
+	for _, length := range []int{0, 1, 256, 300, 1048576, 5000000} {
+		buf := p.Get(length)
+		if len(*buf) != length {
+			t.Errorf("Get(%d) returned length %d", length, len(*buf))
+		}
+	}
+}
+
+func TestTieredBufferPoolPutReuseWithinBucket(t *testing.T) {
+	p := NewBufferPool()
+
+	first := p.Get(100)
+	(*first)[0] = 0x42
+	addr := &(*first)[0]
+	p.Put(first)
+
+	second := p.Get(100)
+	if &(*second)[0] != addr {
+		t.Error("expected Get after Put to reuse the same backing array within a bucket")
+	}
+}
+
+func TestTieredBufferPoolOversizedRequestNotPooled(t *testing.T) {
+	p := NewBufferPool()
+
+	huge := p.Get(bufferPoolBucketSizes[len(bufferPoolBucketSizes)-1] + 1)
+	if len(*huge) != bufferPoolBucketSizes[len(bufferPoolBucketSizes)-1]+1 {
+		t.Errorf("expected an oversized Get to still return exactly the requested length")
+	}
+	// Put should be a silent no-op rather than panic or corrupt a bucket.
+	p.Put(huge)
+}
+
+func TestNopBufferPoolNeverReuses(t *testing.T) {
+	p := NopBufferPool{}
+
+	first := p.Get(64)
+	p.Put(first)
+	second := p.Get(64)
+
+	if &(*first)[0] == &(*second)[0] {
+		t.Error("NopBufferPool should never hand back the same backing array")
+	}
+}
+
+func TestFramerReadMessageRoundTripsThroughPool(t *testing.T) {
+	var buf bytes.Buffer
+	framer := NewFramer(&buf)
+
+	message := bytes.Repeat([]byte("x"), 5000)
+	if err := framer.WriteMessage(message); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+
+	got, err := framer.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if !bytes.Equal(got, message) {
+		t.Error("ReadMessage did not round-trip the written message")
+	}
+	framer.ReleaseMessage(got)
+}
+
+// benchmarkFramerReadMessage drives size bytes through a single WriteMessage/
+// ReadMessage round trip per iteration against framer, releasing each
+// message immediately as Pool.Call does after Unmarshal. Shared by the
+// pooled and unpooled benchmarks below so the only variable between them is
+// the BufferPool passed to NewFramerWithPool.
+func benchmarkFramerReadMessage(b *testing.B, pool BufferPool, size int) {
+	message := bytes.Repeat([]byte("x"), size)
+	var buf bytes.Buffer
+	framer := NewFramerWithPool(&buf, DefaultMaxFrameSize, pool)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := framer.WriteMessage(message); err != nil {
+			b.Fatalf("WriteMessage failed: %v", err)
+		}
+		got, err := framer.ReadMessage()
+		if err != nil {
+			b.Fatalf("ReadMessage failed: %v", err)
+		}
+		framer.ReleaseMessage(got)
+	}
+}
+
+// BenchmarkFramerReadMessagePooled and BenchmarkFramerReadMessageUnpooled
+// compare the tiered BufferPool (the default every Framer gets) against
+// NopBufferPool (fresh allocation every call, i.e. framing's behavior before
+// BufferPool existed) on the same ReadMessage hot path, at four sizes that
+// each land exactly on one of the tiered pool's buckets.
+func BenchmarkFramerReadMessagePooled(b *testing.B) {
+	for _, size := range []int{256, 4096, 65536, 1048576} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			benchmarkFramerReadMessage(b, NewBufferPool(), size)
+		})
+	}
+}
+
+func BenchmarkFramerReadMessageUnpooled(b *testing.B) {
+	for _, size := range []int{256, 4096, 65536, 1048576} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			benchmarkFramerReadMessage(b, NopBufferPool{}, size)
+		})
+	}
+}
@@ -10,38 +10,123 @@ import (
 
 // Frame header constants
 const (
-	// Frame header size: 2 (magic) + 4 (length) + 8 (request ID) + 4 (CRC32C) = 18 bytes
-	FrameHeaderSize = 18
+	// Frame header size: 2 (magic) + 4 (length) + 8 (request ID) + 4 (CRC32C) + 1 (codec) + 1 (compression) + 1 (type) = 21 bytes
+	FrameHeaderSize = 21
 
 	// Magic bytes to identify valid frames
 	MagicByte1 = 0x50 // 'P'
 	MagicByte2 = 0x59 // 'Y'
 )
 
+// Frame types distinguish a normal request/response frame from an
+// out-of-band control frame carried over the same multiplexed connection.
+// A receiver that doesn't recognize a type should treat anything other than
+// FrameTypeData as a frame it can't act on rather than guess.
+const (
+	// FrameTypeData is an ordinary request or response, decoded with CodecID
+	// and CompressionID as usual. The zero value, so every frame built before
+	// FrameType existed is indistinguishable from one built after.
+	FrameTypeData byte = 0
+	// FrameTypeCancel signals that the request named by Header.RequestID
+	// should be aborted; Payload carries the cancellation reason as a plain
+	// UTF-8 string rather than a codec-encoded body, since it's control
+	// metadata, not a Request/Response the codec knows how to decode.
+	FrameTypeCancel byte = 1
+)
+
+// Codec IDs negotiated in the frame header, letting mixed deployments run
+// JSON (for debugging) and msgpack/protobuf (for hot paths) on the same pool.
+const (
+	CodecIDJSON     byte = 0
+	CodecIDMsgpack  byte = 1
+	CodecIDProtobuf byte = 2
+	// CodecIDFileOp tags a frame carrying a pyproc/fs file-operation request
+	// or response instead of a protocol.Message envelope - a receiver routes
+	// it to a registered fs.Mux instead of a Codec. See pkg/pyproc/fs.
+	CodecIDFileOp byte = 0xF0
+)
+
+// Compression IDs negotiated in the frame header, telling the receiver which
+// registered Compressor (see pkg/pyproc/compression.go) decompresses Payload
+// before it reaches the codec. CompressionIDNone leaves Payload as the codec
+// produced it.
+const (
+	CompressionIDNone   byte = 0
+	CompressionIDGzip   byte = 1
+	CompressionIDSnappy byte = 2
+	CompressionIDLZ4    byte = 3
+	CompressionIDZstd   byte = 4
+)
+
 // FrameHeader represents the enhanced frame header
 type FrameHeader struct {
-	Magic     [2]byte // Magic bytes for frame validation
-	Length    uint32  // Total frame length (including header)
-	RequestID uint64  // Request ID for multiplexing
-	CRC32C    uint32  // CRC32C checksum of the payload
+	Magic         [2]byte // Magic bytes for frame validation
+	Length        uint32  // Total frame length (including header)
+	RequestID     uint64  // Request ID for multiplexing
+	CRC32C        uint32  // CRC32C checksum of the payload
+	CodecID       byte    // Codec used to encode Payload (see CodecID* constants)
+	CompressionID byte    // Compression applied to Payload after encoding (see CompressionID* constants)
+	Type          byte    // FrameTypeData or FrameTypeCancel
 }
 
 // Frame represents a complete frame with header and payload
 type Frame struct {
 	Header  FrameHeader
 	Payload []byte
+
+	// pooled is the full header+payload buffer Payload was sliced from, set
+	// only when ReadFrame built the frame out of a BufferPool - nil for
+	// frames built via NewFrame/NewFrameWithCodec/etc. Release it through
+	// Framer.ReleaseFrame, never by pooling Payload directly: Payload's
+	// shorter length/cap wouldn't match one of BufferPool's size buckets.
+	pooled []byte
 }
 
 var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
 
-// NewFrame creates a new frame with the given request ID and payload
+// NewFrame creates a new frame with the given request ID and payload,
+// encoded with CodecIDJSON and no compression.
 func NewFrame(requestID uint64, payload []byte) *Frame {
+	return NewFrameWithCodec(requestID, payload, CodecIDJSON)
+}
+
+// NewFrameWithCodec creates a new frame tagged with the codec used to encode
+// payload, and no compression.
+func NewFrameWithCodec(requestID uint64, payload []byte, codecID byte) *Frame {
+	return NewFrameWithCodecAndCompression(requestID, payload, codecID, CompressionIDNone)
+}
+
+// NewFrameWithCodecAndCompression creates a new frame tagged with both the
+// codec used to encode payload and the compression (if any) already applied
+// to it - the receiver decompresses before handing payload to the codec.
+func NewFrameWithCodecAndCompression(requestID uint64, payload []byte, codecID, compressionID byte) *Frame {
+	return &Frame{
+		Header: FrameHeader{
+			Magic:         [2]byte{MagicByte1, MagicByte2},
+			Length:        uint32(FrameHeaderSize + len(payload)),
+			RequestID:     requestID,
+			CRC32C:        crc32.Checksum(payload, crc32cTable),
+			CodecID:       codecID,
+			CompressionID: compressionID,
+			Type:          FrameTypeData,
+		},
+		Payload: payload,
+	}
+}
+
+// NewCancelFrame creates a FrameTypeCancel control frame telling the peer to
+// abort the request named by requestID. reason is carried verbatim as the
+// payload rather than codec-encoded, since a receiver must be able to act on
+// a cancellation even for a codec it doesn't support.
+func NewCancelFrame(requestID uint64, reason string) *Frame {
+	payload := []byte(reason)
 	return &Frame{
 		Header: FrameHeader{
 			Magic:     [2]byte{MagicByte1, MagicByte2},
 			Length:    uint32(FrameHeaderSize + len(payload)),
 			RequestID: requestID,
 			CRC32C:    crc32.Checksum(payload, crc32cTable),
+			Type:      FrameTypeCancel,
 		},
 		Payload: payload,
 	}
@@ -50,7 +135,14 @@ func NewFrame(requestID uint64, payload []byte) *Frame {
 // Marshal serializes the frame to bytes
 func (f *Frame) Marshal() []byte {
 	buf := make([]byte, f.Header.Length)
+	f.marshalInto(buf)
+	return buf
+}
 
+// marshalInto writes the frame into buf, which must already be exactly
+// Header.Length bytes - letting a caller that got buf from a BufferPool
+// avoid the allocation Marshal's own make would otherwise cost.
+func (f *Frame) marshalInto(buf []byte) {
 	// Write magic bytes
 	buf[0] = f.Header.Magic[0]
 	buf[1] = f.Header.Magic[1]
@@ -64,12 +156,19 @@ func (f *Frame) Marshal() []byte {
 	// Write CRC32C (4 bytes, big-endian)
 	binary.BigEndian.PutUint32(buf[14:18], f.Header.CRC32C)
 
+	// Write codec ID (1 byte)
+	buf[18] = f.Header.CodecID
+
+	// Write compression ID (1 byte)
+	buf[19] = f.Header.CompressionID
+
+	// Write frame type (1 byte)
+	buf[20] = f.Header.Type
+
 	// Copy payload (starting after the header)
 	if len(f.Payload) > 0 {
 		copy(buf[FrameHeaderSize:], f.Payload)
 	}
-
-	return buf
 }
 
 // UnmarshalFrame deserializes a frame from bytes
@@ -85,10 +184,13 @@ func UnmarshalFrame(data []byte) (*Frame, error) {
 
 	// Parse header
 	header := FrameHeader{
-		Magic:     [2]byte{data[0], data[1]},
-		Length:    binary.BigEndian.Uint32(data[2:6]),
-		RequestID: binary.BigEndian.Uint64(data[6:14]),
-		CRC32C:    binary.BigEndian.Uint32(data[14:18]),
+		Magic:         [2]byte{data[0], data[1]},
+		Length:        binary.BigEndian.Uint32(data[2:6]),
+		RequestID:     binary.BigEndian.Uint64(data[6:14]),
+		CRC32C:        binary.BigEndian.Uint32(data[14:18]),
+		CodecID:       data[18],
+		CompressionID: data[19],
+		Type:          data[20],
 	}
 
 	// Validate length
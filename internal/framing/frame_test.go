@@ -112,6 +112,65 @@ func TestFrame(t *testing.T) {
 		}
 	})
 
+	t.Run("Codec Negotiation", func(t *testing.T) {
+		frame := NewFrameWithCodec(55, []byte("protobuf payload"), CodecIDProtobuf)
+
+		data := frame.Marshal()
+		decodedFrame, err := UnmarshalFrame(data)
+		if err != nil {
+			t.Fatalf("UnmarshalFrame failed: %v", err)
+		}
+
+		if decodedFrame.Header.CodecID != CodecIDProtobuf {
+			t.Errorf("CodecID mismatch: got %d, want %d", decodedFrame.Header.CodecID, CodecIDProtobuf)
+		}
+	})
+
+	t.Run("Compression Negotiation", func(t *testing.T) {
+		frame := NewFrameWithCodecAndCompression(56, []byte("gzip payload"), CodecIDJSON, CompressionIDGzip)
+
+		data := frame.Marshal()
+		decodedFrame, err := UnmarshalFrame(data)
+		if err != nil {
+			t.Fatalf("UnmarshalFrame failed: %v", err)
+		}
+
+		if decodedFrame.Header.CompressionID != CompressionIDGzip {
+			t.Errorf("CompressionID mismatch: got %d, want %d", decodedFrame.Header.CompressionID, CompressionIDGzip)
+		}
+
+		uncompressed := NewFrame(57, []byte("plain payload"))
+		if uncompressed.Header.CompressionID != CompressionIDNone {
+			t.Errorf("NewFrame should default CompressionID to CompressionIDNone, got %d", uncompressed.Header.CompressionID)
+		}
+	})
+
+	t.Run("Cancel Frame", func(t *testing.T) {
+		frame := NewCancelFrame(58, "context deadline exceeded")
+
+		data := frame.Marshal()
+		decodedFrame, err := UnmarshalFrame(data)
+		if err != nil {
+			t.Fatalf("UnmarshalFrame failed: %v", err)
+		}
+
+		if decodedFrame.Header.Type != FrameTypeCancel {
+			t.Errorf("Type mismatch: got %d, want %d", decodedFrame.Header.Type, FrameTypeCancel)
+		}
+		if string(decodedFrame.Payload) != "context deadline exceeded" {
+			t.Errorf("Payload mismatch: got %q", decodedFrame.Payload)
+		}
+
+		data2 := NewFrame(59, []byte("plain")).Marshal()
+		decoded2, err := UnmarshalFrame(data2)
+		if err != nil {
+			t.Fatalf("UnmarshalFrame failed: %v", err)
+		}
+		if decoded2.Header.Type != FrameTypeData {
+			t.Errorf("NewFrame should default Type to FrameTypeData, got %d", decoded2.Header.Type)
+		}
+	})
+
 	t.Run("Empty Payload", func(t *testing.T) {
 		frame := NewFrame(999, []byte{})
 
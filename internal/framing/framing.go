@@ -13,12 +13,20 @@ const (
 	DefaultMaxFrameSize = 10 * 1024 * 1024
 )
 
+// defaultPool is shared by every Framer created without an explicit
+// BufferPool, so buffers stay pooled across connections instead of each
+// Framer keeping its own (mostly-empty) set of sync.Pools.
+var defaultPool = NewBufferPool()
+
 // Framer handles framing of messages over a stream
 type Framer struct {
 	rw           io.ReadWriter
 	maxFrameSize int
 	// Enhanced mode enables request ID and CRC32C
 	enhancedMode bool
+	// pool backs ReadMessage/ReadFrame/WriteFrame's buffers. Never nil -
+	// constructors default it to defaultPool.
+	pool BufferPool
 }
 
 // NewFramer creates a new framer with default max frame size
@@ -27,6 +35,7 @@ func NewFramer(rw io.ReadWriter) *Framer {
 		rw:           rw,
 		maxFrameSize: DefaultMaxFrameSize,
 		enhancedMode: false,
+		pool:         defaultPool,
 	}
 }
 
@@ -36,6 +45,19 @@ func NewFramerWithMaxSize(rw io.ReadWriter, maxSize int) *Framer {
 		rw:           rw,
 		maxFrameSize: maxSize,
 		enhancedMode: false,
+		pool:         defaultPool,
+	}
+}
+
+// NewFramerWithPool creates a framer that gets its read/write buffers from
+// pool instead of the shared defaultPool - e.g. NopBufferPool for a
+// benchmark comparing pooled vs. unpooled allocation.
+func NewFramerWithPool(rw io.ReadWriter, maxSize int, pool BufferPool) *Framer {
+	return &Framer{
+		rw:           rw,
+		maxFrameSize: maxSize,
+		enhancedMode: false,
+		pool:         pool,
 	}
 }
 
@@ -45,6 +67,20 @@ func NewEnhancedFramer(rw io.ReadWriter) *Framer {
 		rw:           rw,
 		maxFrameSize: DefaultMaxFrameSize,
 		enhancedMode: true,
+		pool:         defaultPool,
+	}
+}
+
+// NewEnhancedFramerWithPool creates an enhanced framer (request ID and
+// CRC32C) that gets its read/write buffers from pool instead of the shared
+// defaultPool - e.g. NopBufferPool for a benchmark comparing pooled vs.
+// unpooled allocation.
+func NewEnhancedFramerWithPool(rw io.ReadWriter, maxSize int, pool BufferPool) *Framer {
+	return &Framer{
+		rw:           rw,
+		maxFrameSize: maxSize,
+		enhancedMode: true,
+		pool:         pool,
 	}
 }
 
@@ -82,18 +118,24 @@ func (f *Framer) WriteFrame(frame *Frame) error {
 		return fmt.Errorf("payload size %d exceeds max frame size %d", len(frame.Payload), f.maxFrameSize)
 	}
 
-	// Marshal the entire frame
-	data := frame.Marshal()
+	// Marshal the entire frame into a pooled buffer instead of frame.Marshal's
+	// own make, releasing it back once the write completes.
+	bufPtr := f.pool.Get(int(frame.Header.Length))
+	frame.marshalInto(*bufPtr)
 
 	// Write the complete frame
-	if _, err := f.rw.Write(data); err != nil {
+	_, err := f.rw.Write(*bufPtr)
+	f.pool.Put(bufPtr)
+	if err != nil {
 		return fmt.Errorf("failed to write frame: %w", err)
 	}
 
 	return nil
 }
 
-// ReadMessage reads a framed message
+// ReadMessage reads a framed message. The returned slice is borrowed from
+// f's BufferPool - call ReleaseMessage on it once the caller is done
+// decoding, so it can be reused by a later Get instead of left for the GC.
 func (f *Framer) ReadMessage() ([]byte, error) {
 	// Read length header (4 bytes)
 	lengthBuf := make([]byte, 4)
@@ -111,14 +153,26 @@ func (f *Framer) ReadMessage() ([]byte, error) {
 	}
 
 	// Read message data
-	data := make([]byte, length)
+	data := *f.pool.Get(int(length))
 	if _, err := io.ReadFull(f.rw, data); err != nil {
+		f.ReleaseMessage(data)
 		return nil, fmt.Errorf("failed to read frame data: %w", err)
 	}
 
 	return data, nil
 }
 
+// ReleaseMessage returns a buffer previously returned by ReadMessage (or a
+// Payload read via ReadFrame) to f's BufferPool. Ownership must be handed
+// back eagerly - e.g. right after a codec finishes unmarshaling it - rather
+// than held past the call that consumes it, or the pool can't reuse it.
+func (f *Framer) ReleaseMessage(data []byte) {
+	if data == nil {
+		return
+	}
+	f.pool.Put(&data)
+}
+
 // ReadFrame reads an enhanced frame with request ID and CRC32C
 func (f *Framer) ReadFrame() (*Frame, error) {
 	if !f.enhancedMode {
@@ -134,8 +188,8 @@ func (f *Framer) ReadFrame() (*Frame, error) {
 	}
 
 	// Peek at magic bytes first
-	magicBuf := make([]byte, 2)
-	if _, err := io.ReadFull(f.rw, magicBuf); err != nil {
+	var magicBuf [2]byte
+	if _, err := io.ReadFull(f.rw, magicBuf[:]); err != nil {
 		if err == io.EOF {
 			return nil, io.EOF
 		}
@@ -148,34 +202,53 @@ func (f *Framer) ReadFrame() (*Frame, error) {
 	}
 
 	// Read the rest of the header
-	headerBuf := make([]byte, FrameHeaderSize-2) // -2 for magic bytes already read
-	if _, err := io.ReadFull(f.rw, headerBuf); err != nil {
+	var headerBuf [FrameHeaderSize - 2]byte // -2 for magic bytes already read
+	if _, err := io.ReadFull(f.rw, headerBuf[:]); err != nil {
 		return nil, fmt.Errorf("failed to read frame header: %w", err)
 	}
 
-	// Parse header fields
+	// Parse length up front so the rest of the frame can be read straight
+	// into a single pooled buffer instead of a separate payload buffer
+	// that's then copied into place.
 	length := binary.BigEndian.Uint32(headerBuf[0:4])
 	if int(length) > f.maxFrameSize+FrameHeaderSize {
 		return nil, fmt.Errorf("frame size %d exceeds max frame size %d", length, f.maxFrameSize)
 	}
+	if int(length) < FrameHeaderSize {
+		return nil, fmt.Errorf("frame too short: %d bytes", length)
+	}
 
-	// Read payload
-	payloadSize := int(length) - FrameHeaderSize
-	payload := make([]byte, payloadSize)
-	if payloadSize > 0 {
-		if _, err := io.ReadFull(f.rw, payload); err != nil {
+	bufPtr := f.pool.Get(int(length))
+	completeData := *bufPtr
+	copy(completeData[0:2], magicBuf[:])
+	copy(completeData[2:FrameHeaderSize], headerBuf[:])
+	if payloadSize := int(length) - FrameHeaderSize; payloadSize > 0 {
+		if _, err := io.ReadFull(f.rw, completeData[FrameHeaderSize:]); err != nil {
+			f.pool.Put(bufPtr)
 			return nil, fmt.Errorf("failed to read frame payload: %w", err)
 		}
 	}
 
-	// Reconstruct complete frame data for unmarshaling
-	completeData := make([]byte, length)
-	copy(completeData[0:2], magicBuf)
-	copy(completeData[2:FrameHeaderSize], headerBuf)
-	if payloadSize > 0 {
-		copy(completeData[FrameHeaderSize:], payload)
+	// Unmarshal and validate
+	frame, err := UnmarshalFrame(completeData)
+	if err != nil {
+		f.pool.Put(bufPtr)
+		return nil, err
 	}
+	frame.pooled = completeData
+	return frame, nil
+}
 
-	// Unmarshal and validate
-	return UnmarshalFrame(completeData)
+// ReleaseFrame returns a frame previously returned by ReadFrame to f's
+// BufferPool, once the caller is done with its Payload. A no-op for frames
+// that weren't built from a pooled buffer (e.g. the simple, non-enhanced
+// framing mode's ReadMessage-backed frames).
+func (f *Framer) ReleaseFrame(frame *Frame) {
+	if frame == nil || frame.pooled == nil {
+		return
+	}
+	buf := frame.pooled
+	f.pool.Put(&buf)
+	frame.pooled = nil
+	frame.Payload = nil
 }
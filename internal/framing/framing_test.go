@@ -198,6 +198,42 @@ func TestFramer_PartialRead(t *testing.T) {
 	}
 }
 
+func TestFramer_ReadFrameRoundTripsAndReleaseReusesBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	pool := NewBufferPool()
+	writer := NewEnhancedFramerWithPool(&buf, DefaultMaxFrameSize, pool)
+
+	frame := NewFrame(42, []byte("hello, frame"))
+	if err := writer.WriteFrame(frame); err != nil {
+		t.Fatalf("WriteFrame: %v", err)
+	}
+
+	reader := NewEnhancedFramerWithPool(&buf, DefaultMaxFrameSize, pool)
+	got, err := reader.ReadFrame()
+	if err != nil {
+		t.Fatalf("ReadFrame: %v", err)
+	}
+	if got.Header.RequestID != frame.Header.RequestID {
+		t.Errorf("RequestID mismatch: got %d, want %d", got.Header.RequestID, frame.Header.RequestID)
+	}
+	if string(got.Payload) != "hello, frame" {
+		t.Errorf("Payload mismatch: got %q", got.Payload)
+	}
+
+	addr := &got.Payload[0]
+	fullLen := len(got.pooled)
+	reader.ReleaseFrame(got)
+	if got.Payload != nil {
+		t.Error("expected ReleaseFrame to clear Payload")
+	}
+
+	// A same-size Get afterwards should reuse the buffer ReleaseFrame put back.
+	reused := pool.Get(fullLen)
+	if &(*reused)[FrameHeaderSize] != addr {
+		t.Error("expected ReleaseFrame to return its buffer to the pool for reuse")
+	}
+}
+
 // partialReader simulates reading data in small chunks
 type partialReader struct {
 	data      []byte
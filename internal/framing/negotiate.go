@@ -0,0 +1,154 @@
+package framing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ProtocolVersion is the framing protocol version this build of pyproc
+// speaks, exchanged during Negotiate/NegotiateServer so a client and worker
+// that drift out of sync close the connection instead of misinterpreting
+// each other's frames.
+const ProtocolVersion = "pyproc/1"
+
+// ErrUnsupportedVersion is returned by Negotiate/NegotiateServer when the
+// peer's version string doesn't match ours. There's no meaningful fallback
+// once the two sides can't agree on framing, so callers should close the
+// connection rather than attempt to continue.
+var ErrUnsupportedVersion = errors.New("framing: unsupported protocol version")
+
+// NegotiationRequest is the first message a client sends on a new
+// connection, before any Request/Response traffic - modeled on 9P's version
+// exchange. It proposes the version, the largest frame the client is
+// willing to read, and the codecs (by name - "json", "msgpack", "protobuf")
+// it can decode, most preferred first.
+type NegotiationRequest struct {
+	Version      string   `json:"version"`
+	MaxFrameSize int      `json:"max_frame_size"`
+	Codecs       []string `json:"codecs"`
+}
+
+// NegotiationResponse is the worker's reply. MaxFrameSize is the agreed
+// frame size limit - min(request's proposal, the worker's own ceiling) -
+// and Codec is the worker's pick among NegotiationRequest.Codecs, or "" if
+// it supports none of them.
+type NegotiationResponse struct {
+	Version      string `json:"version"`
+	MaxFrameSize int    `json:"max_frame_size"`
+	Codec        string `json:"codec"`
+}
+
+// Negotiate runs the client side of the pre-session handshake over conn: it
+// writes req on a bootstrap Framer capped at DefaultMaxFrameSize (small
+// enough that any worker, old or new, can read it), then reads the
+// worker's NegotiationResponse. On success it returns a Framer whose
+// maxFrameSize is the agreed value - learned from the worker, not
+// hardcoded - ready for ordinary framed traffic over the same conn.
+func Negotiate(conn io.ReadWriter, req NegotiationRequest) (*Framer, *NegotiationResponse, error) {
+	bootstrap := NewFramerWithMaxSize(conn, DefaultMaxFrameSize)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("framing: marshal negotiation request: %w", err)
+	}
+	if err := bootstrap.WriteMessage(data); err != nil {
+		return nil, nil, fmt.Errorf("framing: send negotiation request: %w", err)
+	}
+
+	respData, err := bootstrap.ReadMessage()
+	if err != nil {
+		return nil, nil, fmt.Errorf("framing: read negotiation response: %w", err)
+	}
+	defer bootstrap.ReleaseMessage(respData)
+
+	var resp NegotiationResponse
+	if err := json.Unmarshal(respData, &resp); err != nil {
+		return nil, nil, fmt.Errorf("framing: unmarshal negotiation response: %w", err)
+	}
+
+	if resp.Version != req.Version {
+		return nil, nil, fmt.Errorf("%w: client %s, worker %s", ErrUnsupportedVersion, req.Version, resp.Version)
+	}
+
+	maxSize := req.MaxFrameSize
+	if resp.MaxFrameSize < maxSize {
+		maxSize = resp.MaxFrameSize
+	}
+
+	return NewFramerWithMaxSize(conn, maxSize), &resp, nil
+}
+
+// NegotiateServer runs the worker side of the handshake: it reads a
+// NegotiationRequest, agrees on a version/msize/codec, and replies.
+// serverMaxFrameSize is this side's own ceiling, independent of whatever
+// the client proposed. preferredCodecs is matched against req.Codecs in
+// order, so the server's own priority wins ties.
+//
+// The response is always sent, even when the client's version doesn't
+// match ProtocolVersion, echoing this side's real version so the client can
+// detect the mismatch itself (Negotiate does, returning
+// ErrUnsupportedVersion) - NegotiateServer returns the same error after
+// writing, so both ends know to close conn instead of proceeding.
+func NegotiateServer(conn io.ReadWriter, serverMaxFrameSize int, preferredCodecs []string) (*Framer, *NegotiationRequest, error) {
+	bootstrap := NewFramerWithMaxSize(conn, DefaultMaxFrameSize)
+
+	reqData, err := bootstrap.ReadMessage()
+	if err != nil {
+		return nil, nil, fmt.Errorf("framing: read negotiation request: %w", err)
+	}
+	defer bootstrap.ReleaseMessage(reqData)
+
+	var req NegotiationRequest
+	if err := json.Unmarshal(reqData, &req); err != nil {
+		return nil, nil, fmt.Errorf("framing: unmarshal negotiation request: %w", err)
+	}
+
+	versionMismatch := req.Version != ProtocolVersion
+
+	maxSize := req.MaxFrameSize
+	if serverMaxFrameSize < maxSize {
+		maxSize = serverMaxFrameSize
+	}
+
+	var codec string
+	if !versionMismatch {
+		codec = negotiateCodec(req.Codecs, preferredCodecs)
+	}
+
+	resp := NegotiationResponse{
+		Version:      ProtocolVersion,
+		MaxFrameSize: maxSize,
+		Codec:        codec,
+	}
+	respData, err := json.Marshal(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("framing: marshal negotiation response: %w", err)
+	}
+	if err := bootstrap.WriteMessage(respData); err != nil {
+		return nil, nil, fmt.Errorf("framing: send negotiation response: %w", err)
+	}
+
+	if versionMismatch {
+		return nil, &req, fmt.Errorf("%w: client %s, server %s", ErrUnsupportedVersion, req.Version, ProtocolVersion)
+	}
+
+	return NewFramerWithMaxSize(conn, maxSize), &req, nil
+}
+
+// negotiateCodec returns the first of preferred that also appears in
+// offered, or "" if the two share nothing - the caller decides whether an
+// empty Codec is fatal.
+func negotiateCodec(offered, preferred []string) string {
+	offeredSet := make(map[string]struct{}, len(offered))
+	for _, c := range offered {
+		offeredSet[c] = struct{}{}
+	}
+	for _, c := range preferred {
+		if _, ok := offeredSet[c]; ok {
+			return c
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package framing
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNegotiateAgreesOnMinMsizeAndPreferredCodec(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		_, req, err := NegotiateServer(server, 4096, []string{"msgpack", "json"})
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		if req.Version != ProtocolVersion {
+			serverDone <- errors.New("server saw wrong version")
+			return
+		}
+		serverDone <- nil
+	}()
+
+	framer, resp, err := Negotiate(client, NegotiationRequest{
+		Version:      ProtocolVersion,
+		MaxFrameSize: 8192,
+		Codecs:       []string{"json", "msgpack"},
+	})
+	if err != nil {
+		t.Fatalf("Negotiate: %v", err)
+	}
+	if resp.MaxFrameSize != 4096 {
+		t.Errorf("expected agreed max frame size 4096 (the server's lower ceiling), got %d", resp.MaxFrameSize)
+	}
+	if resp.Codec != "msgpack" {
+		t.Errorf("expected server's preferred codec msgpack, got %q", resp.Codec)
+	}
+	if framer.maxFrameSize != 4096 {
+		t.Errorf("expected client Framer to learn maxFrameSize 4096, got %d", framer.maxFrameSize)
+	}
+
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Fatalf("NegotiateServer: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for NegotiateServer")
+	}
+}
+
+func TestNegotiateRejectsVersionMismatch(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		_, _, err := NegotiateServer(server, DefaultMaxFrameSize, []string{"json"})
+		serverDone <- err
+	}()
+
+	_, _, err := Negotiate(client, NegotiationRequest{
+		Version:      "pyproc/99",
+		MaxFrameSize: DefaultMaxFrameSize,
+		Codecs:       []string{"json"},
+	})
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("expected ErrUnsupportedVersion, got %v", err)
+	}
+
+	select {
+	case serverErr := <-serverDone:
+		if !errors.Is(serverErr, ErrUnsupportedVersion) {
+			t.Fatalf("expected server to also report ErrUnsupportedVersion, got %v", serverErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for NegotiateServer")
+	}
+}
+
+func TestNegotiateCodecFallsBackToEmptyWhenDisjoint(t *testing.T) {
+	if got := negotiateCodec([]string{"json"}, []string{"protobuf", "msgpack"}); got != "" {
+		t.Errorf("expected no common codec, got %q", got)
+	}
+	if got := negotiateCodec([]string{"json", "msgpack"}, []string{"protobuf", "msgpack", "json"}); got != "msgpack" {
+		t.Errorf("expected msgpack (server's first match), got %q", got)
+	}
+}
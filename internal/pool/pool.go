@@ -0,0 +1,250 @@
+// Package pool provides a generic, resizable pool of net.Conn for
+// transports that want several interchangeable underlying connections to
+// one address, as opposed to a single persistent connection per worker.
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned by Get and Put once the pool has been Closed.
+var ErrClosed = errors.New("pool: closed")
+
+// Factory creates one new underlying connection.
+type Factory func() (net.Conn, error)
+
+// Pool is a generic, concurrency-safe pool of net.Conn.
+type Pool interface {
+	// Get returns a connection from the pool, creating one via Factory if
+	// none is idle and the pool is below MaxCap. If the pool is already at
+	// MaxCap with nothing idle, Get blocks until a connection is Put back
+	// or ctx is done.
+	Get(ctx context.Context) (net.Conn, error)
+
+	// Put returns conn to the pool for reuse, or closes it if the pool is
+	// full or already closed. Callers normally never call Put directly:
+	// the net.Conn returned by Get is wrapped so its own Close() calls Put.
+	Put(conn net.Conn) error
+
+	// Len reports the number of idle connections currently held.
+	Len() int
+
+	// Close closes the pool and every connection it currently holds idle.
+	// Connections still checked out are closed as they're Put back.
+	Close() error
+}
+
+// Options configures a channel-based Pool.
+type Options struct {
+	// InitialCap is the number of connections opened eagerly by New.
+	InitialCap int
+	// MaxCap is the maximum number of connections the pool will hold at
+	// once, idle or checked out. Must be > 0.
+	MaxCap int
+	// Factory creates a new connection. Required.
+	Factory Factory
+	// OnCheckout, if set, is run against a connection about to be handed
+	// out by Get; returning false discards it (closing it, and creating or
+	// waiting for a replacement) instead of handing back a connection that
+	// failed a liveness probe.
+	OnCheckout func(net.Conn) bool
+}
+
+// New builds a channel-based Pool from opts, eagerly opening InitialCap
+// connections via Factory.
+func New(opts Options) (Pool, error) {
+	if opts.Factory == nil {
+		return nil, errors.New("pool: Factory is required")
+	}
+	if opts.MaxCap <= 0 {
+		return nil, errors.New("pool: MaxCap must be > 0")
+	}
+	if opts.InitialCap < 0 || opts.InitialCap > opts.MaxCap {
+		return nil, errors.New("pool: InitialCap must be between 0 and MaxCap")
+	}
+
+	p := &channelPool{
+		conns:      make(chan net.Conn, opts.MaxCap),
+		factory:    opts.Factory,
+		onCheckout: opts.OnCheckout,
+		maxCap:     opts.MaxCap,
+	}
+
+	for i := 0; i < opts.InitialCap; i++ {
+		c, err := p.factory()
+		if err != nil {
+			_ = p.Close()
+			return nil, fmt.Errorf("pool: failed to open initial connection %d: %w", i, err)
+		}
+		p.open.Add(1)
+		p.conns <- c
+	}
+
+	return p, nil
+}
+
+// channelPool is a Pool backed by a buffered channel of idle connections.
+// The channel is never closed, even by Close: closing it would race any
+// concurrent Put trying to send on it. Close instead flips the closed flag
+// (checked by both Get and Put under mu) and drains+closes whatever is
+// currently idle.
+type channelPool struct {
+	mu      sync.Mutex
+	closed  bool
+	conns   chan net.Conn
+	factory Factory
+
+	onCheckout func(net.Conn) bool
+	maxCap     int
+	open       atomic.Int32 // connections created and not yet closed, idle or checked out
+}
+
+func (p *channelPool) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+func (p *channelPool) Get(ctx context.Context) (net.Conn, error) {
+	for {
+		if p.isClosed() {
+			return nil, ErrClosed
+		}
+
+		select {
+		case c := <-p.conns:
+			if p.onCheckout != nil && !p.onCheckout(c) {
+				_ = c.Close()
+				p.open.Add(-1)
+				continue
+			}
+			return p.wrap(c), nil
+		default:
+		}
+
+		if int(p.open.Load()) < p.maxCap {
+			c, err := p.factory()
+			if err != nil {
+				return nil, fmt.Errorf("pool: failed to create connection: %w", err)
+			}
+			p.open.Add(1)
+			return p.wrap(c), nil
+		}
+
+		select {
+		case c := <-p.conns:
+			if p.onCheckout != nil && !p.onCheckout(c) {
+				_ = c.Close()
+				p.open.Add(-1)
+				continue
+			}
+			return p.wrap(c), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (p *channelPool) Put(c net.Conn) error {
+	if c == nil {
+		return errors.New("pool: cannot put a nil connection")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// c didn't come from Get, so it isn't counted in open yet: adopt it only
+	// if that keeps idle plus checked-out connections within MaxCap.
+	if p.closed {
+		return errJoin(ErrClosed, c.Close())
+	}
+	if int(p.open.Load()) >= p.maxCap {
+		return c.Close()
+	}
+	p.open.Add(1)
+	p.conns <- c
+	return nil
+}
+
+// put returns a connection handed out by Get (already counted in open) to
+// the idle channel, or closes it if the pool is closed or full.
+func (p *channelPool) put(c net.Conn) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		p.open.Add(-1)
+		return errJoin(ErrClosed, c.Close())
+	}
+
+	select {
+	case p.conns <- c:
+		return nil
+	default:
+		// Pool is at MaxCap idle connections already; discard this one.
+		p.open.Add(-1)
+		return c.Close()
+	}
+}
+
+func (p *channelPool) Len() int {
+	return len(p.conns)
+}
+
+func (p *channelPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	var firstErr error
+	for {
+		select {
+		case c := <-p.conns:
+			if err := c.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			p.open.Add(-1)
+		default:
+			return firstErr
+		}
+	}
+}
+
+// wrap returns c wrapped so that calling Close() on it returns c to the pool
+// (via Put) instead of closing the underlying connection directly.
+func (p *channelPool) wrap(c net.Conn) net.Conn {
+	return &pooledConn{Conn: c, pool: p}
+}
+
+// pooledConn is the net.Conn handed out by Get. Its Close() is safe to call
+// more than once; only the first call returns the connection to the pool.
+type pooledConn struct {
+	net.Conn
+	pool   *channelPool
+	closed atomic.Bool
+}
+
+func (c *pooledConn) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	return c.pool.put(c.Conn)
+}
+
+// errJoin returns err if closeErr is nil, otherwise a combined error; used
+// only for the Put-on-a-closed-pool case where both are informative.
+func errJoin(err, closeErr error) error {
+	if closeErr == nil {
+		return err
+	}
+	return fmt.Errorf("%w (and failed to close discarded connection: %v)", err, closeErr)
+}
@@ -0,0 +1,218 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeConn is a minimal net.Conn that just tracks whether Close was called.
+type fakeConn struct {
+	net.Conn
+	closed atomic.Bool
+}
+
+func (c *fakeConn) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func newFakeFactory() (Factory, *atomic.Int32) {
+	var created atomic.Int32
+	factory := func() (net.Conn, error) {
+		created.Add(1)
+		return &fakeConn{}, nil
+	}
+	return factory, &created
+}
+
+func TestNewRejectsBadOptions(t *testing.T) {
+	factory, _ := newFakeFactory()
+
+	if _, err := New(Options{MaxCap: 1}); err == nil {
+		t.Error("expected error for missing Factory")
+	}
+	if _, err := New(Options{Factory: factory}); err == nil {
+		t.Error("expected error for MaxCap <= 0")
+	}
+	if _, err := New(Options{Factory: factory, MaxCap: 1, InitialCap: 2}); err == nil {
+		t.Error("expected error for InitialCap > MaxCap")
+	}
+}
+
+func TestPoolPrefillsInitialCap(t *testing.T) {
+	factory, created := newFakeFactory()
+	p, err := New(Options{Factory: factory, InitialCap: 2, MaxCap: 5})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Close()
+
+	if created.Load() != 2 {
+		t.Errorf("expected 2 connections prefilled, got %d", created.Load())
+	}
+	if p.Len() != 2 {
+		t.Errorf("expected Len() == 2, got %d", p.Len())
+	}
+}
+
+func TestPoolGetReusesReturnedConn(t *testing.T) {
+	factory, created := newFakeFactory()
+	p, err := New(Options{Factory: factory, MaxCap: 1})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Close()
+
+	conn, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close (return to pool) failed: %v", err)
+	}
+
+	if _, err := p.Get(context.Background()); err != nil {
+		t.Fatalf("second Get failed: %v", err)
+	}
+	if created.Load() != 1 {
+		t.Errorf("expected the connection to be reused, got %d created", created.Load())
+	}
+}
+
+func TestPoolGetBlocksAtMaxCapUntilPut(t *testing.T) {
+	factory, _ := newFakeFactory()
+	p, err := New(Options{Factory: factory, MaxCap: 1})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Close()
+
+	conn, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded while pool is exhausted, got %v", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	got, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get after release should succeed: %v", err)
+	}
+	_ = got.Close()
+}
+
+func TestPoolPutDiscardsWhenFull(t *testing.T) {
+	factory, created := newFakeFactory()
+	p, err := New(Options{Factory: factory, MaxCap: 1})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Close()
+
+	conn, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	// Put a second, pool-unaware connection directly: the pool is already
+	// at MaxCap (1 checked out), so this must be discarded rather than
+	// queued.
+	extra := &fakeConn{}
+	if err := p.Put(extra); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if !extra.closed.Load() {
+		t.Error("expected the extra connection to be closed, not pooled")
+	}
+
+	_ = conn.Close()
+	if created.Load() != 1 {
+		t.Errorf("expected only 1 connection ever created, got %d", created.Load())
+	}
+}
+
+func TestPoolOnCheckoutRejectsDeadConn(t *testing.T) {
+	factory, created := newFakeFactory()
+	p, err := New(Options{
+		Factory: factory,
+		MaxCap:  2,
+		OnCheckout: func(c net.Conn) bool {
+			return !c.(*fakeConn).closed.Load()
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer p.Close()
+
+	conn, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	// Simulate the connection dying while idle, then return it.
+	conn.(*pooledConn).Conn.(*fakeConn).closed.Store(true)
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := p.Get(context.Background()); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if created.Load() != 2 {
+		t.Errorf("expected the dead connection to be discarded and a new one created, got %d", created.Load())
+	}
+}
+
+func TestPoolCloseClosesIdleConnsAndRejectsFurtherUse(t *testing.T) {
+	factory, _ := newFakeFactory()
+	p, err := New(Options{Factory: factory, InitialCap: 2, MaxCap: 2})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := p.Get(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed from Get after Close, got %v", err)
+	}
+	if err := p.Put(&fakeConn{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed from Put after Close, got %v", err)
+	}
+}
+
+func TestPoolPutAfterCloseDoesNotPanic(t *testing.T) {
+	factory, _ := newFakeFactory()
+	p, err := New(Options{Factory: factory, MaxCap: 1})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	conn, err := p.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// A Put racing a concurrent Close must see ErrClosed, not panic on a
+	// closed channel.
+	if err := conn.Close(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed returning a checked-out conn after Close, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package protocol
+
+import "fmt"
+
+// StatusCode classifies a worker-side failure into a well-known error class,
+// mirroring google.rpc.Code so existing gRPC tooling (retry policies, status
+// codes) applies without translation. See proto/pyproc.proto for the wire
+// definition shared with the protobuf codec.
+type StatusCode int32
+
+const (
+	StatusOK                 StatusCode = 0
+	StatusCancelled          StatusCode = 1
+	StatusUnknown            StatusCode = 2
+	StatusInvalidArgument    StatusCode = 3
+	StatusDeadlineExceeded   StatusCode = 4
+	StatusNotFound           StatusCode = 5
+	StatusAlreadyExists      StatusCode = 6
+	StatusPermissionDenied   StatusCode = 7
+	StatusResourceExhausted  StatusCode = 8
+	StatusFailedPrecondition StatusCode = 9
+	StatusInternal           StatusCode = 13
+	StatusUnavailable        StatusCode = 14
+)
+
+// StatusError is an error carrying a StatusCode, used so transports can
+// propagate a worker exception's class instead of flattening it to a string.
+type StatusError struct {
+	Code    StatusCode
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// String returns the canonical name of the status code.
+func (c StatusCode) String() string {
+	switch c {
+	case StatusOK:
+		return "OK"
+	case StatusCancelled:
+		return "CANCELLED"
+	case StatusUnknown:
+		return "UNKNOWN"
+	case StatusInvalidArgument:
+		return "INVALID_ARGUMENT"
+	case StatusDeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case StatusNotFound:
+		return "NOT_FOUND"
+	case StatusAlreadyExists:
+		return "ALREADY_EXISTS"
+	case StatusPermissionDenied:
+		return "PERMISSION_DENIED"
+	case StatusResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case StatusFailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case StatusInternal:
+		return "INTERNAL"
+	case StatusUnavailable:
+		return "UNAVAILABLE"
+	default:
+		return fmt.Sprintf("STATUS(%d)", int32(c))
+	}
+}
+
+// NewStatusErrorResponse creates an error response carrying a typed status
+// code, for use in place of NewErrorResponse when the failure class matters.
+func NewStatusErrorResponse(id uint64, code StatusCode, message string) *Response {
+	return &Response{
+		ID:        id,
+		OK:        false,
+		ErrorMsg:  message,
+		ErrorCode: code,
+	}
+}
+
+// StatusErr returns the typed StatusError if the response is an error, falling
+// back to Response.Error's plain error when no code was reported.
+func (r *Response) StatusErr() error {
+	if r.OK {
+		return nil
+	}
+	if r.ErrorCode == StatusOK {
+		return r.Error()
+	}
+	return &StatusError{Code: r.ErrorCode, Message: r.ErrorMsg}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 )
 
 // MessageType defines the type of message being sent
@@ -18,27 +19,71 @@ const (
 	MessageTypeResponse MessageType = "response"
 	// MessageTypeCancellation is a cancellation control message
 	MessageTypeCancellation MessageType = "cancellation"
+	// MessageTypeStreamData carries one chunk of a streaming call's
+	// response (see Pool.CallStream). Its payload is a Response whose Body
+	// is the chunk; ID matches the originating Request.
+	MessageTypeStreamData MessageType = "stream_data"
+	// MessageTypeStreamEnd marks the normal end of a streaming call's
+	// response - no more MessageTypeStreamData frames will follow for ID.
+	MessageTypeStreamEnd MessageType = "stream_end"
+	// MessageTypeStreamError ends a streaming call's response the way
+	// MessageTypeStreamEnd does, but reports a failure instead of a clean
+	// finish; its payload is a Response with OK false.
+	MessageTypeStreamError MessageType = "stream_error"
+	// MessageTypeNotification is a fire-and-forget call: its payload is a
+	// Request, same as MessageTypeRequest, but the worker sends no
+	// MessageTypeResponse back and the sender never waits for one (see
+	// Pool.Notify). Matches MessagePack-RPC's type-2 notification.
+	MessageTypeNotification MessageType = "notification"
 )
 
 // Message is the envelope for all messages between Go and Python
 type Message struct {
-	Type    MessageType     `json:"type"`
-	Payload json.RawMessage `json:"payload"`
+	Type    MessageType     `json:"type" msgpack:"type"`
+	Payload json.RawMessage `json:"payload" msgpack:"payload"`
 }
 
 // Request represents a request from Go to Python
 type Request struct {
-	ID     uint64          `json:"id"`
-	Method string          `json:"method"`
-	Body   json.RawMessage `json:"body"`
+	ID     uint64          `json:"id" msgpack:"id"`
+	Method string          `json:"method" msgpack:"method"`
+	Body   json.RawMessage `json:"body" msgpack:"body"`
+
+	// Idempotent marks a request as safe to resend with a fresh ID after a
+	// transport reconnect (see MultiplexedTransport). Requests that mutate
+	// state the worker can't safely re-apply should leave this false, the
+	// zero value, so a reconnect fails them instead of risking a replay.
+	Idempotent bool `json:"idempotent,omitempty" msgpack:"idempotent,omitempty"`
+
+	// Attachments are file descriptors sent alongside the request as an
+	// SCM_RIGHTS ancillary message, not part of the framed payload itself -
+	// a transport that supports it (MultiplexedTransport) sends them out of
+	// band and the worker receives them via recvmsg. Used to hand over a
+	// large buffer (e.g. from NewSharedBuffer) for the worker to mmap
+	// instead of shipping it through Body. Never marshaled.
+	Attachments []*os.File `json:"-" msgpack:"-"`
+
+	// Trace carries the calling span's context as a W3C traceparent header
+	// value ("00-{trace-id}-{span-id}-{flags}"), so the worker can restore it
+	// and nest its own spans underneath instead of starting a disconnected
+	// trace. Empty when the caller's context carried no span (see
+	// pyproc.TraceContextFromContext).
+	Trace string `json:"trace,omitempty" msgpack:"trace,omitempty"`
+	// TraceState carries the matching W3C tracestate header value, if any -
+	// vendor-specific trace state that travels alongside Trace unmodified.
+	TraceState string `json:"tracestate,omitempty" msgpack:"tracestate,omitempty"`
 }
 
 // Response represents a response from Python to Go
 type Response struct {
-	ID       uint64          `json:"id"`
-	OK       bool            `json:"ok"`
-	Body     json.RawMessage `json:"body,omitempty"`
-	ErrorMsg string          `json:"error,omitempty"`
+	ID       uint64          `json:"id" msgpack:"id"`
+	OK       bool            `json:"ok" msgpack:"ok"`
+	Body     json.RawMessage `json:"body,omitempty" msgpack:"body,omitempty"`
+	ErrorMsg string          `json:"error,omitempty" msgpack:"error,omitempty"`
+	// ErrorCode classifies ErrorMsg using the StatusCode enum so callers can
+	// branch on the failure class instead of pattern-matching the message.
+	// Zero value (StatusOK) means "no code reported", not success - check OK.
+	ErrorCode StatusCode `json:"error_code,omitempty" msgpack:"error_code,omitempty"`
 }
 
 // CancellationRequest represents a cancellation signal for a specific request
@@ -0,0 +1,193 @@
+package pyproc
+
+import (
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy computes the delay before the next restart attempt.
+// retries is the number of attempts already made (0 on the first retry).
+type BackoffStrategy interface {
+	NextDelay(retries int) time.Duration
+}
+
+// BackoffStrategyType selects a BackoffStrategy implementation via config.
+type BackoffStrategyType string
+
+const (
+	// BackoffConstant always waits RestartConfig.InitialBackoff.
+	BackoffConstant BackoffStrategyType = "constant"
+	// BackoffExponential waits InitialBackoff * Multiplier^retries, capped at MaxBackoff.
+	BackoffExponential BackoffStrategyType = "exponential"
+	// BackoffExponentialJitter applies full jitter on top of BackoffExponential:
+	// a random duration between 0 and the exponential delay.
+	BackoffExponentialJitter BackoffStrategyType = "exponential-jitter"
+	// BackoffDecorrelatedJitter follows the AWS "decorrelated jitter" recipe,
+	// which spreads out restarts better than full jitter when many workers
+	// crash at once (e.g. a shared Python dependency import failure).
+	BackoffDecorrelatedJitter BackoffStrategyType = "decorrelated-jitter"
+	// BackoffConnection follows the connection-backoff recipe grpc-go uses
+	// for redials: baseDelay * multiplier^retries capped at maxDelay, then
+	// scaled by a uniform jitter factor instead of full jitter - see
+	// ConnectionBackoff. UDSTransport uses this as its default (see
+	// TransportConfig.Backoff) since it's tuned for exactly this case: a
+	// socket dial failing because a Python worker is mid-restart.
+	BackoffConnection BackoffStrategyType = "connection"
+)
+
+// NewBackoffStrategy builds the BackoffStrategy selected by strategyType using
+// the bounds from cfg. Unknown strategyType values fall back to BackoffExponential.
+// BackoffConnection ignores cfg and uses NewConnectionBackoff's defaults.
+func NewBackoffStrategy(strategyType BackoffStrategyType, cfg RestartConfig) BackoffStrategy {
+	initial := cfg.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	max := cfg.MaxBackoff
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	multiplier := cfg.Multiplier
+	if multiplier <= 1 {
+		multiplier = 2.0
+	}
+
+	switch strategyType {
+	case BackoffConstant:
+		return &ConstantBackoff{Delay: initial}
+	case BackoffExponentialJitter:
+		return &ExponentialBackoff{Initial: initial, Max: max, Multiplier: multiplier, FullJitter: true}
+	case BackoffDecorrelatedJitter:
+		return &DecorrelatedJitterBackoff{Initial: initial, Max: max}
+	case BackoffConnection:
+		return NewConnectionBackoff()
+	case BackoffExponential, "":
+		return &ExponentialBackoff{Initial: initial, Max: max, Multiplier: multiplier}
+	default:
+		return &ExponentialBackoff{Initial: initial, Max: max, Multiplier: multiplier}
+	}
+}
+
+// ConstantBackoff always returns the same delay.
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+func (b *ConstantBackoff) NextDelay(retries int) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff grows the delay geometrically, optionally applying full
+// jitter (a uniform random delay between 0 and the computed ceiling).
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	FullJitter bool
+}
+
+func (b *ExponentialBackoff) NextDelay(retries int) time.Duration {
+	delay := float64(b.Initial)
+	for i := 0; i < retries; i++ {
+		delay *= b.Multiplier
+		if delay >= float64(b.Max) {
+			delay = float64(b.Max)
+			break
+		}
+	}
+	ceiling := time.Duration(delay)
+	if ceiling > b.Max {
+		ceiling = b.Max
+	}
+
+	if !b.FullJitter {
+		return ceiling
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// DecorrelatedJitterBackoff implements the "decorrelated jitter" algorithm:
+// sleep = min(max, random_between(initial, prev*3)), with prev seeded to
+// initial. This spreads out concurrent restarts better than full jitter
+// because each delay is correlated with (but not equal to) the last one,
+// avoiding the thundering herd of every worker retrying at the same instant.
+type DecorrelatedJitterBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+
+	prev time.Duration
+}
+
+func (b *DecorrelatedJitterBackoff) NextDelay(retries int) time.Duration {
+	prev := b.prev
+	if prev <= 0 {
+		prev = b.Initial
+	}
+
+	upper := prev * 3
+	if upper <= b.Initial {
+		upper = b.Initial + 1
+	}
+
+	span := int64(upper) - int64(b.Initial)
+	next := b.Initial
+	if span > 0 {
+		next += time.Duration(rand.Int63n(span))
+	}
+	if next > b.Max {
+		next = b.Max
+	}
+
+	b.prev = next
+	return next
+}
+
+// ConnectionBackoff computes delay = min(BaseDelay * Multiplier^retries,
+// MaxDelay), then scales it by a uniform random factor in
+// [1-Jitter, 1+Jitter]. Unlike ExponentialBackoff's full jitter (0 to the
+// ceiling), this keeps every delay close to the geometric curve - the shape
+// UDSTransport's retry loop wants when backing off a redial against a
+// worker that's mid-restart (see TransportConfig.Backoff).
+type ConnectionBackoff struct {
+	BaseDelay  time.Duration
+	Multiplier float64
+	MaxDelay   time.Duration
+	Jitter     float64
+}
+
+// NewConnectionBackoff returns a ConnectionBackoff with UDSTransport's
+// defaults: 1s base delay, 1.6x multiplier, 120s cap, 20% jitter.
+func NewConnectionBackoff() *ConnectionBackoff {
+	return &ConnectionBackoff{
+		BaseDelay:  time.Second,
+		Multiplier: 1.6,
+		MaxDelay:   120 * time.Second,
+		Jitter:     0.2,
+	}
+}
+
+func (b *ConnectionBackoff) NextDelay(retries int) time.Duration {
+	delay := float64(b.BaseDelay)
+	for i := 0; i < retries; i++ {
+		delay *= b.Multiplier
+		if delay >= float64(b.MaxDelay) {
+			delay = float64(b.MaxDelay)
+			break
+		}
+	}
+
+	if b.Jitter > 0 {
+		delay *= 1 - b.Jitter + 2*b.Jitter*rand.Float64()
+	}
+
+	if ceiling := float64(b.MaxDelay); delay > ceiling {
+		delay = ceiling
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,116 @@
+package pyproc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	b := &ConstantBackoff{Delay: 500 * time.Millisecond}
+
+	for i := 0; i < 3; i++ {
+		if got := b.NextDelay(i); got != 500*time.Millisecond {
+			t.Errorf("NextDelay(%d) = %v, want 500ms", i, got)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := &ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := b.NextDelay(i); got != w {
+			t.Errorf("NextDelay(%d) = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestExponentialJitterBackoffBounded(t *testing.T) {
+	b := &ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, FullJitter: true}
+
+	for i := 0; i < 10; i++ {
+		got := b.NextDelay(i)
+		if got < 0 || got > time.Second {
+			t.Errorf("NextDelay(%d) = %v out of bounds [0, 1s]", i, got)
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoffBounded(t *testing.T) {
+	b := &DecorrelatedJitterBackoff{Initial: 100 * time.Millisecond, Max: 2 * time.Second}
+
+	prev := b.Initial
+	for i := 0; i < 20; i++ {
+		got := b.NextDelay(i)
+		if got < b.Initial || got > b.Max {
+			t.Errorf("NextDelay(%d) = %v out of bounds [%v, %v]", i, got, b.Initial, b.Max)
+		}
+		prev = got
+	}
+	_ = prev
+}
+
+func TestConnectionBackoffGrowsAndCaps(t *testing.T) {
+	b := &ConnectionBackoff{BaseDelay: 100 * time.Millisecond, Multiplier: 2, MaxDelay: time.Second}
+
+	prev := time.Duration(0)
+	for i := 0; i < 10; i++ {
+		got := b.NextDelay(i)
+		if got < prev {
+			t.Errorf("NextDelay(%d) = %v, expected it not to shrink from %v (no jitter configured)", i, got, prev)
+		}
+		if got > time.Second {
+			t.Errorf("NextDelay(%d) = %v, want <= MaxDelay 1s", i, got)
+		}
+		prev = got
+	}
+}
+
+func TestConnectionBackoffJitterBounded(t *testing.T) {
+	b := NewConnectionBackoff()
+	b.BaseDelay = 100 * time.Millisecond
+	b.MaxDelay = time.Second
+
+	for i := 0; i < 20; i++ {
+		got := b.NextDelay(3)
+		if got <= 0 || got > b.MaxDelay {
+			t.Errorf("NextDelay(3) = %v out of bounds (0, %v]", got, b.MaxDelay)
+		}
+	}
+}
+
+func TestNewConnectionBackoffDefaults(t *testing.T) {
+	b := NewConnectionBackoff()
+	if b.BaseDelay != time.Second || b.Multiplier != 1.6 || b.MaxDelay != 120*time.Second || b.Jitter != 0.2 {
+		t.Errorf("unexpected defaults: %+v", b)
+	}
+}
+
+func TestNewBackoffStrategy(t *testing.T) {
+	cfg := RestartConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	tests := []struct {
+		strategyType BackoffStrategyType
+		wantType     interface{}
+	}{
+		{BackoffConstant, &ConstantBackoff{}},
+		{BackoffExponential, &ExponentialBackoff{}},
+		{BackoffExponentialJitter, &ExponentialBackoff{}},
+		{BackoffDecorrelatedJitter, &DecorrelatedJitterBackoff{}},
+		{BackoffConnection, &ConnectionBackoff{}},
+		{"", &ExponentialBackoff{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategyType), func(t *testing.T) {
+			strategy := NewBackoffStrategy(tt.strategyType, cfg)
+			if strategy == nil {
+				t.Fatal("NewBackoffStrategy returned nil")
+			}
+			if strategy.NextDelay(0) <= 0 {
+				t.Error("expected a positive first delay")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+//go:build arrow
+
+package pyproc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+)
+
+// ArrowCodec implements Codec using Arrow IPC for columnar batches. Worth the
+// extra dependency (hence the "arrow" build tag, same gating as
+// codec_json_goccy.go) for methods like compute_stats or process_batch that
+// exchange large numeric columns, where MessagePack/JSON's per-cell boxing
+// dominates encode/decode time on both the Go and pyarrow side.
+//
+// Marshal expects v to be an arrow.Record built against a schema already
+// agreed with the Python worker for that method; Unmarshal expects v to be
+// *arrow.Record. ArrowCodec does not itself carry or validate a schema - the
+// caller is responsible for using the same schema per method on both ends,
+// the way ProtobufCodec relies on both ends sharing the generated .proto.
+type ArrowCodec struct {
+	alloc memory.Allocator
+}
+
+// NewArrowCodec returns an ArrowCodec backed by a GoAllocator.
+func NewArrowCodec() *ArrowCodec {
+	return &ArrowCodec{alloc: memory.NewGoAllocator()}
+}
+
+// Marshal serializes v, which must be an arrow.Record, to Arrow IPC stream bytes.
+func (c *ArrowCodec) Marshal(v interface{}) ([]byte, error) {
+	rec, ok := v.(arrow.Record)
+	if !ok {
+		return nil, fmt.Errorf("arrow codec: expected arrow.Record, got %T", v)
+	}
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(rec.Schema()), ipc.WithAllocator(c.alloc))
+	if err := w.Write(rec); err != nil {
+		return nil, fmt.Errorf("arrow marshal: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("arrow marshal: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes an Arrow IPC stream into v, which must be *arrow.Record.
+// The returned record is retained; callers must Release it.
+func (c *ArrowCodec) Unmarshal(data []byte, v interface{}) error {
+	dst, ok := v.(*arrow.Record)
+	if !ok {
+		return fmt.Errorf("arrow codec: expected *arrow.Record, got %T", v)
+	}
+
+	r, err := ipc.NewReader(bytes.NewReader(data), ipc.WithAllocator(c.alloc))
+	if err != nil {
+		return fmt.Errorf("arrow unmarshal: %w", err)
+	}
+	defer r.Release()
+
+	if !r.Next() {
+		return fmt.Errorf("arrow unmarshal: payload contains no record batch")
+	}
+	rec := r.Record()
+	rec.Retain()
+	*dst = rec
+	return nil
+}
+
+// Name returns the name of the codec.
+func (c *ArrowCodec) Name() string {
+	return "arrow"
+}
+
+func init() {
+	RegisterCodecFactory("arrow", func() Codec { return NewArrowCodec() })
+}
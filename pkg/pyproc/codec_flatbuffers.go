@@ -0,0 +1,141 @@
+//go:build flatbuffers
+
+package pyproc
+
+import (
+	"fmt"
+	"sync"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// FlatBuffersSchema adapts one generated flatbuffers table type to
+// FlatBuffersCodec: Build writes v into b using the generated Builder calls
+// (flatc's usual output), and ViewOf wraps raw decoded bytes with the
+// generated zero-copy accessor type so a caller can read fields straight out
+// of data without allocating a Go struct. FlatBuffersCodec only handles
+// dispatch and builder/buffer reuse; flatc generates everything schema-specific.
+type FlatBuffersSchema interface {
+	// Name identifies the schema, e.g. the flatbuffers table's name. It's
+	// the value passed to RegisterFlatBuffersSchema/NewFlatBuffersCodec.
+	Name() string
+	Build(b *flatbuffers.Builder, v interface{}) error
+	ViewOf(data []byte) (View, error)
+}
+
+var (
+	flatBuffersSchemasMu sync.RWMutex
+	flatBuffersSchemas   = map[string]FlatBuffersSchema{}
+)
+
+// RegisterFlatBuffersSchema registers schema under schema.Name(), replacing
+// any schema previously registered under that name. Call it from an init()
+// alongside the flatc-generated package for that schema, before
+// NewFlatBuffersCodec or RegisterFlatBuffersCodec look it up by name.
+func RegisterFlatBuffersSchema(schema FlatBuffersSchema) {
+	flatBuffersSchemasMu.Lock()
+	defer flatBuffersSchemasMu.Unlock()
+	flatBuffersSchemas[schema.Name()] = schema
+}
+
+// GetFlatBuffersSchema looks up a schema previously passed to
+// RegisterFlatBuffersSchema.
+func GetFlatBuffersSchema(name string) (FlatBuffersSchema, bool) {
+	flatBuffersSchemasMu.RLock()
+	defer flatBuffersSchemasMu.RUnlock()
+	schema, ok := flatBuffersSchemas[name]
+	return schema, ok
+}
+
+// RegisterFlatBuffersCodec registers a CodecFactory for schemaName's already
+// -registered FlatBuffersSchema under the name "flatbuffers:<schemaName>",
+// the same way ArrowCodec registers itself under "arrow" - making it
+// selectable via TransportConfig.Options["codec"] or NewCodec. Call it after
+// RegisterFlatBuffersSchema; it panics if schemaName isn't registered yet,
+// since that's a program wiring error rather than something a caller
+// recovers from at request time.
+func RegisterFlatBuffersCodec(schemaName string) {
+	RegisterCodecFactory("flatbuffers:"+schemaName, func() Codec {
+		codec, err := NewFlatBuffersCodec(schemaName)
+		if err != nil {
+			panic(err)
+		}
+		return codec
+	})
+}
+
+// FlatBuffersCodec implements Codec and ZeroCopyCodec for one
+// FlatBuffersSchema, pooling *flatbuffers.Builder instances since they're
+// expensive to allocate and safe to Reset between uses.
+type FlatBuffersCodec struct {
+	schema   FlatBuffersSchema
+	builders sync.Pool
+}
+
+// NewFlatBuffersCodec returns a FlatBuffersCodec bound to the
+// FlatBuffersSchema registered as schemaName.
+func NewFlatBuffersCodec(schemaName string) (*FlatBuffersCodec, error) {
+	schema, ok := GetFlatBuffersSchema(schemaName)
+	if !ok {
+		return nil, fmt.Errorf("flatbuffers codec: no schema registered as %q, call RegisterFlatBuffersSchema first", schemaName)
+	}
+	return &FlatBuffersCodec{
+		schema:   schema,
+		builders: sync.Pool{New: func() interface{} { return flatbuffers.NewBuilder(1024) }},
+	}, nil
+}
+
+// Marshal encodes v via the bound schema. Prefer MarshalTo on the hot path
+// to reuse a buffer instead of allocating a fresh one per call.
+func (c *FlatBuffersCodec) Marshal(v interface{}) ([]byte, error) {
+	return c.MarshalTo(nil, v)
+}
+
+// MarshalTo encodes v via the bound schema into buf, reusing its backing
+// array when it has enough capacity, and returns the slice actually written.
+func (c *FlatBuffersCodec) MarshalTo(buf []byte, v interface{}) ([]byte, error) {
+	b := c.builders.Get().(*flatbuffers.Builder)
+	b.Reset()
+	defer c.builders.Put(b)
+
+	if err := c.schema.Build(b, v); err != nil {
+		return nil, fmt.Errorf("flatbuffers marshal: %w", err)
+	}
+
+	encoded := b.FinishedBytes()
+	if cap(buf) < len(encoded) {
+		buf = make([]byte, len(encoded))
+	} else {
+		buf = buf[:len(encoded)]
+	}
+	copy(buf, encoded)
+	return buf, nil
+}
+
+// Unmarshal decodes data into v by building a View via the bound schema and
+// copying its fields onto v. The View's concrete type must implement a
+// CopyTo(v interface{}) error method, otherwise Unmarshal returns an error;
+// callers that want the zero-copy benefit should call UnmarshalView directly
+// instead.
+func (c *FlatBuffersCodec) Unmarshal(data []byte, v interface{}) error {
+	view, err := c.schema.ViewOf(data)
+	if err != nil {
+		return fmt.Errorf("flatbuffers unmarshal: %w", err)
+	}
+	copier, ok := view.(interface{ CopyTo(v interface{}) error })
+	if !ok {
+		return fmt.Errorf("flatbuffers unmarshal: %q's View does not implement CopyTo, use UnmarshalView instead", c.schema.Name())
+	}
+	return copier.CopyTo(v)
+}
+
+// UnmarshalView decodes data into zero-copy accessors via the bound schema,
+// without allocating a destination Go struct.
+func (c *FlatBuffersCodec) UnmarshalView(data []byte) (View, error) {
+	return c.schema.ViewOf(data)
+}
+
+// Name returns the name of the codec, qualified by the bound schema.
+func (c *FlatBuffersCodec) Name() string {
+	return "flatbuffers:" + c.schema.Name()
+}
@@ -1,6 +1,8 @@
 package pyproc
 
 import (
+	"io"
+
 	"github.com/vmihailenco/msgpack/v5"
 )
 
@@ -21,3 +23,23 @@ func (c *MessagePackCodec) Unmarshal(data []byte, v interface{}) error {
 func (c *MessagePackCodec) Name() string {
 	return "msgpack"
 }
+
+// NewEncoder returns a streaming MessagePack encoder that writes directly to w.
+// Untyped maps decode to map[string]interface{} (never map[interface{}]interface{}),
+// matching schema-driven decoding into Go structs with `msgpack:"..."` tags.
+func (c *MessagePackCodec) NewEncoder(w io.Writer) Encoder {
+	return msgpack.NewEncoder(w)
+}
+
+// NewDecoder returns a streaming MessagePack decoder that reads directly from r,
+// so large payloads (tensors, blobs) can be decoded without an intermediate
+// []byte copy of the whole frame. The msgpack str/bin wire types are preserved
+// as Go string/[]byte respectively, so binary payloads never get coerced to
+// strings the way an ambiguous "raw" type would.
+func (c *MessagePackCodec) NewDecoder(r io.Reader) Decoder {
+	dec := msgpack.NewDecoder(r)
+	dec.SetMapDecoder(func(d *msgpack.Decoder) (interface{}, error) {
+		return d.DecodeMap()
+	})
+	return dec
+}
@@ -0,0 +1,124 @@
+//go:build protobuf
+
+package pyproc
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pyprocv1 "github.com/YuminosukeSato/pyproc/api/v1"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// ProtobufCodec implements Codec using the Envelope/EnvelopeReply messages
+// generated from proto/pyproc.proto. Marshal/Unmarshal expect to receive a
+// *protocol.Request/*protocol.Response (or the raw pyprocv1 types) and
+// re-encode them as the protobuf envelope so that deployments can mix JSON
+// (for debugging) and protobuf (for hot paths) on the same pool.
+//
+// Gated behind the "protobuf" build tag, same as ArrowCodec/FlatBuffersCodec:
+// the generated api/v1 package it depends on isn't vendored into every
+// build. Run `protoc` against proto/pyproc.proto to generate it before
+// building with -tags protobuf.
+type ProtobufCodec struct{}
+
+// Marshal serializes v to protobuf bytes. v must be a proto.Message, or a
+// *protocol.Request/*protocol.Response which is first converted to the
+// matching pyprocv1 envelope type.
+func (c *ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, err := toProtoMessage(v)
+	if err != nil {
+		return nil, fmt.Errorf("protobuf marshal: %w", err)
+	}
+	return proto.Marshal(msg)
+}
+
+// Unmarshal deserializes protobuf bytes into v. v may be a
+// *protocol.Request/*protocol.Response, which is populated from the decoded
+// envelope, or a raw pyprocv1/proto.Message decoded in place.
+func (c *ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	switch dst := v.(type) {
+	case *protocol.Request:
+		var env pyprocv1.Envelope
+		if err := proto.Unmarshal(data, &env); err != nil {
+			return fmt.Errorf("protobuf unmarshal: %w", err)
+		}
+		requestFromEnvelope(&env, dst)
+		return nil
+	case *protocol.Response:
+		var reply pyprocv1.EnvelopeReply
+		if err := proto.Unmarshal(data, &reply); err != nil {
+			return fmt.Errorf("protobuf unmarshal: %w", err)
+		}
+		responseFromEnvelopeReply(&reply, dst)
+		return nil
+	default:
+		msg, err := toProtoMessage(v)
+		if err != nil {
+			return fmt.Errorf("protobuf unmarshal: %w", err)
+		}
+		return proto.Unmarshal(data, msg)
+	}
+}
+
+// Name returns the name of the codec
+func (c *ProtobufCodec) Name() string {
+	return "protobuf"
+}
+
+// toProtoMessage adapts the protocol envelope types to their generated
+// protobuf counterparts so ProtobufCodec can sit behind the same Codec
+// interface as the JSON/MessagePack codecs.
+func toProtoMessage(v interface{}) (proto.Message, error) {
+	switch msg := v.(type) {
+	case *protocol.Request:
+		return &pyprocv1.Envelope{
+			RequestId: msg.ID,
+			Method:    msg.Method,
+			Payload:   []byte(msg.Body),
+		}, nil
+	case *protocol.Response:
+		reply := &pyprocv1.EnvelopeReply{
+			RequestId: msg.ID,
+			Payload:   []byte(msg.Body),
+		}
+		if !msg.OK {
+			reply.Status = &pyprocv1.Status{
+				Code:    int32(msg.ErrorCode),
+				Message: msg.ErrorMsg,
+			}
+		}
+		return reply, nil
+	case proto.Message:
+		return msg, nil
+	default:
+		return nil, fmt.Errorf("value of type %T does not implement proto.Message", v)
+	}
+}
+
+// requestFromEnvelope copies a decoded Envelope's fields onto req, the
+// inverse of toProtoMessage's *protocol.Request case.
+func requestFromEnvelope(env *pyprocv1.Envelope, req *protocol.Request) {
+	req.ID = env.RequestId
+	req.Method = env.Method
+	req.Body = env.Payload
+}
+
+// responseFromEnvelopeReply copies a decoded EnvelopeReply's fields onto
+// resp, the inverse of toProtoMessage's *protocol.Response case.
+func responseFromEnvelopeReply(reply *pyprocv1.EnvelopeReply, resp *protocol.Response) {
+	resp.ID = reply.RequestId
+	resp.Body = reply.Payload
+	if reply.Status == nil {
+		resp.OK = true
+		return
+	}
+	resp.OK = false
+	resp.ErrorMsg = reply.Status.Message
+	resp.ErrorCode = protocol.StatusCode(reply.Status.Code)
+}
+
+func init() {
+	RegisterCodecFactory(string(CodecProtobuf), func() Codec { return &ProtobufCodec{} })
+}
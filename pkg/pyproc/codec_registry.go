@@ -0,0 +1,44 @@
+package pyproc
+
+import "sync"
+
+// CodecFactory constructs a new Codec instance. Registered factories let
+// TransportConfig.Options["codec"] (and NewCodec) select codecs beyond the
+// three built-ins (CodecJSON/CodecMessagePack/CodecProtobuf) without this
+// package needing to import whatever package implements them - mirrors
+// RegisterCompressor/GetCompressor. See ArrowCodec for an example consumer.
+type CodecFactory func() Codec
+
+var (
+	codecFactoriesMu sync.RWMutex
+	codecFactories   = map[string]CodecFactory{}
+)
+
+// RegisterCodecFactory registers factory under name, replacing any factory
+// previously registered under that name. Call it from an init() so the
+// codec becomes selectable via TransportConfig.Options["codec"] = name (or
+// NewCodec(CodecType(name))) without transport.go needing a build-time
+// dependency on it - e.g. ArrowCodec registers "arrow" this way behind the
+// "arrow" build tag.
+func RegisterCodecFactory(name string, factory CodecFactory) {
+	codecFactoriesMu.Lock()
+	defer codecFactoriesMu.Unlock()
+	codecFactories[name] = factory
+}
+
+// GetCodecFactory looks up a factory previously passed to RegisterCodecFactory.
+func GetCodecFactory(name string) (CodecFactory, bool) {
+	codecFactoriesMu.RLock()
+	defer codecFactoriesMu.RUnlock()
+	f, ok := codecFactories[name]
+	return f, ok
+}
+
+func init() {
+	RegisterCodecFactory(string(CodecJSON), func() Codec { return &JSONCodec{} })
+	RegisterCodecFactory(string(CodecMessagePack), func() Codec { return &MessagePackCodec{} })
+	// CodecProtobuf registers itself from codec_protobuf.go, gated behind
+	// the "protobuf" build tag - the generated api/v1 package it depends on
+	// isn't vendored into every build, the same reason ArrowCodec and
+	// FlatBuffersCodec register behind their own tags.
+}
@@ -0,0 +1,67 @@
+package pyproc
+
+import "testing"
+
+func TestNewCodecUsesBuiltinFactories(t *testing.T) {
+	tests := []struct {
+		codecType CodecType
+		wantName  string
+	}{
+		{"", "json-stdlib"},
+		{CodecJSON, "json-stdlib"},
+		{CodecMessagePack, "msgpack"},
+		// CodecProtobuf isn't registered in this build - it self-registers
+		// from codec_protobuf.go behind the "protobuf" build tag.
+	}
+
+	for _, tt := range tests {
+		codec, err := NewCodec(tt.codecType)
+		if err != nil {
+			t.Errorf("NewCodec(%q): unexpected error %v", tt.codecType, err)
+			continue
+		}
+		if codec.Name() != tt.wantName {
+			t.Errorf("NewCodec(%q).Name() = %q, want %q", tt.codecType, codec.Name(), tt.wantName)
+		}
+	}
+}
+
+func TestNewCodecUnknownNameNotFound(t *testing.T) {
+	if _, err := NewCodec("does-not-exist"); err == nil {
+		t.Error("expected NewCodec to fail for an unregistered codec type")
+	}
+}
+
+func TestRegisterCodecFactoryOverwritesByName(t *testing.T) {
+	name := "test-codec-override"
+	RegisterCodecFactory(name, func() Codec { return &fakeNamedCodec{name: name, tag: 'a'} })
+	RegisterCodecFactory(name, func() Codec { return &fakeNamedCodec{name: name, tag: 'b'} })
+
+	factory, ok := GetCodecFactory(name)
+	if !ok {
+		t.Fatal("expected codec factory to be registered")
+	}
+	codec := factory().(*fakeNamedCodec)
+	if codec.tag != 'b' {
+		t.Error("expected the second registration to win")
+	}
+
+	got, err := NewCodec(CodecType(name))
+	if err != nil {
+		t.Fatalf("NewCodec: unexpected error %v", err)
+	}
+	if got.(*fakeNamedCodec).tag != 'b' {
+		t.Error("expected NewCodec to resolve through the registry")
+	}
+}
+
+// fakeNamedCodec is a no-op Codec used only to test the registry itself,
+// independent of any real encoding.
+type fakeNamedCodec struct {
+	name string
+	tag  byte
+}
+
+func (f *fakeNamedCodec) Marshal(v interface{}) ([]byte, error)      { return nil, nil }
+func (f *fakeNamedCodec) Unmarshal(data []byte, v interface{}) error { return nil }
+func (f *fakeNamedCodec) Name() string                               { return f.name }
@@ -1,8 +1,11 @@
 package pyproc
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
 func TestGetJSONCodecType(t *testing.T) {
@@ -144,6 +147,74 @@ func TestMessagePackCodec(t *testing.T) {
 	}
 }
 
+func TestMessagePackCodecStreaming(t *testing.T) {
+	codec := &MessagePackCodec{}
+
+	type payload struct {
+		Name string `msgpack:"name"`
+		Blob []byte `msgpack:"blob"`
+	}
+
+	want := payload{Name: "tensor", Blob: []byte{0xde, 0xad, 0xbe, 0xef}}
+
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf).Encode(&want); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	var got payload
+	if err := codec.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if got.Name != want.Name || !reflect.DeepEqual(got.Blob, want.Blob) {
+		t.Errorf("streaming round-trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestProtobufCodecRoundTrip(t *testing.T) {
+	codec := &ProtobufCodec{}
+
+	t.Run("request", func(t *testing.T) {
+		want, err := protocol.NewRequest(7, "predict", map[string]int{"count": 42})
+		if err != nil {
+			t.Fatalf("NewRequest failed: %v", err)
+		}
+
+		data, err := codec.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		var got protocol.Request
+		if err := codec.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if got.ID != want.ID || got.Method != want.Method || !bytes.Equal(got.Body, want.Body) {
+			t.Errorf("round-trip = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		want := protocol.NewStatusErrorResponse(7, protocol.StatusNotFound, "model not found")
+
+		data, err := codec.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		var got protocol.Response
+		if err := codec.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if got.ID != want.ID || got.OK != want.OK || got.ErrorMsg != want.ErrorMsg || got.ErrorCode != want.ErrorCode {
+			t.Errorf("round-trip = %+v, want %+v", got, want)
+		}
+	})
+}
+
 func TestNewCodec(t *testing.T) {
 	// Get the actual JSON codec name at runtime
 	jsonCodecName := (&JSONCodec{}).Name()
@@ -173,7 +244,11 @@ func TestNewCodec(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "Protobuf (not implemented)",
+			// CodecProtobuf isn't registered in this build - it
+			// self-registers from codec_protobuf.go behind the "protobuf"
+			// build tag, so selecting it here without that tag fails the
+			// same way an unknown codec name would.
+			name:      "Protobuf (without the protobuf build tag)",
 			codecType: CodecProtobuf,
 			wantName:  "",
 			wantErr:   true,
@@ -0,0 +1,28 @@
+package pyproc
+
+// View exposes zero-copy accessors into a payload decoded by a
+// ZeroCopyCodec's UnmarshalView, instead of populating a destination Go
+// struct. Callers type-assert the result to whatever accessor type the
+// concrete codec/schema returns (e.g. a generated flatbuffers table); the
+// View is only valid while the []byte passed to UnmarshalView isn't reused.
+type View interface {
+	// SchemaName identifies which schema decoded this View, so a caller
+	// juggling more than one can dispatch the type assertion correctly.
+	SchemaName() string
+}
+
+// ZeroCopyCodec is implemented by codecs that can encode into a caller-owned
+// buffer and decode into a typed View without allocating destination Go
+// structs - the ML-inference hot path where JSON/MessagePack's per-field
+// allocation dominates round-trip latency (see BenchmarkRPCLatency).
+type ZeroCopyCodec interface {
+	Codec
+
+	// MarshalTo writes v's encoding into buf, reusing its backing array
+	// when it has enough capacity, and returns the slice actually written.
+	MarshalTo(buf []byte, v interface{}) ([]byte, error)
+
+	// UnmarshalView returns zero-copy accessors over data instead of
+	// populating a destination struct. data must outlive the returned View.
+	UnmarshalView(data []byte) (View, error)
+}
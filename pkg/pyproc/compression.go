@@ -0,0 +1,101 @@
+package pyproc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Compressor transparently compresses/decompresses RPC payloads after the
+// configured Codec has encoded them, trading CPU for bandwidth on large
+// requests and responses (e.g. LargePayload's 1MB JSON body). It mirrors
+// grpc-go's encoding.Compressor so the registration pattern is familiar:
+// register an implementation once, then select it by name per transport via
+// TransportConfig.Options["compression"].
+type Compressor interface {
+	// Name identifies the compressor, e.g. "gzip". It's the value looked up
+	// in TransportConfig.Options["compression"] and the one tagged in the
+	// enhanced Frame header's CompressionID (see framing.CompressionID*).
+	Name() string
+	// Compress returns a WriteCloser that compresses into w. Callers must
+	// Close it to flush the final block before reading w's bytes.
+	Compress(w io.Writer) io.WriteCloser
+	// Decompress returns a Reader yielding r's decompressed bytes.
+	Decompress(r io.Reader) (io.Reader, error)
+}
+
+var (
+	compressorsMu sync.RWMutex
+	compressors   = map[string]Compressor{}
+)
+
+// RegisterCompressor registers c under c.Name(), replacing any compressor
+// previously registered under that name. Mirrors grpc-go's
+// encoding.RegisterCompressor - call it from an init() so c becomes
+// available to TransportConfig.Options["compression"] without the transport
+// package needing to import whatever package implements c.
+func RegisterCompressor(c Compressor) {
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	compressors[c.Name()] = c
+}
+
+// GetCompressor looks up a compressor previously passed to RegisterCompressor.
+func GetCompressor(name string) (Compressor, bool) {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	c, ok := compressors[name]
+	return c, ok
+}
+
+func init() {
+	RegisterCompressor(gzipCompressor{})
+}
+
+// gzipCompressor is the built-in "gzip" Compressor, backed by the stdlib so
+// it's available with no new dependency. The other algorithms framing has a
+// CompressionID for - zstd, snappy, lz4 - are registered the same way from
+// compression_zstd.go/compression_snappy.go/compression_lz4.go, each behind
+// its own build tag so selecting "compression: zstd" doesn't force every
+// build to vendor klauspost/compress, golang/snappy, and pierrec/lz4 for
+// algorithms it won't use.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string { return "gzip" }
+
+func (gzipCompressor) Compress(w io.Writer) io.WriteCloser {
+	return gzip.NewWriter(w)
+}
+
+func (gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+// compressBytes compresses data with c in one shot.
+func compressBytes(c Compressor, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := c.Compress(&buf)
+	if _, err := zw.Write(data); err != nil {
+		_ = zw.Close()
+		return nil, fmt.Errorf("compress with %s: %w", c.Name(), err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress with %s: %w", c.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressBytes decompresses data with c in one shot.
+func decompressBytes(c Compressor, data []byte) ([]byte, error) {
+	zr, err := c.Decompress(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress with %s: %w", c.Name(), err)
+	}
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress with %s: %w", c.Name(), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,28 @@
+//go:build lz4
+
+package pyproc
+
+import (
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// lz4Compressor is the "lz4" Compressor, gated behind the "lz4" build tag
+// so the default build doesn't vendor pierrec/lz4 just for deployments that
+// never select it via TransportConfig.Options["compression"].
+type lz4Compressor struct{}
+
+func (lz4Compressor) Name() string { return "lz4" }
+
+func (lz4Compressor) Compress(w io.Writer) io.WriteCloser {
+	return lz4.NewWriter(w)
+}
+
+func (lz4Compressor) Decompress(r io.Reader) (io.Reader, error) {
+	return lz4.NewReader(r), nil
+}
+
+func init() {
+	RegisterCompressor(lz4Compressor{})
+}
@@ -0,0 +1,28 @@
+//go:build snappy
+
+package pyproc
+
+import (
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// snappyCompressor is the "snappy" Compressor, gated behind the "snappy"
+// build tag so the default build doesn't vendor golang/snappy just for
+// deployments that never select it via TransportConfig.Options["compression"].
+type snappyCompressor struct{}
+
+func (snappyCompressor) Name() string { return "snappy" }
+
+func (snappyCompressor) Compress(w io.Writer) io.WriteCloser {
+	return snappy.NewBufferedWriter(w)
+}
+
+func (snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
+
+func init() {
+	RegisterCompressor(snappyCompressor{})
+}
@@ -0,0 +1,69 @@
+package pyproc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestGzipCompressorRoundTrips(t *testing.T) {
+	c, ok := GetCompressor("gzip")
+	if !ok {
+		t.Fatal("expected \"gzip\" to be registered by default")
+	}
+	if c.Name() != "gzip" {
+		t.Errorf("Name() = %q, want gzip", c.Name())
+	}
+
+	original := bytes.Repeat([]byte("pyproc compression test payload "), 256)
+
+	compressed, err := compressBytes(c, original)
+	if err != nil {
+		t.Fatalf("compressBytes failed: %v", err)
+	}
+	if len(compressed) >= len(original) {
+		t.Errorf("compressed length %d not smaller than original %d for repetitive input", len(compressed), len(original))
+	}
+
+	decompressed, err := decompressBytes(c, compressed)
+	if err != nil {
+		t.Fatalf("decompressBytes failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, original) {
+		t.Error("decompressed bytes don't match the original payload")
+	}
+}
+
+func TestGetCompressorUnknownNameNotFound(t *testing.T) {
+	if _, ok := GetCompressor("does-not-exist"); ok {
+		t.Error("expected lookup of an unregistered compressor to fail")
+	}
+}
+
+func TestRegisterCompressorOverwritesByName(t *testing.T) {
+	name := "test-compression-override"
+	first := fakeCompressor{name: name, tag: 'a'}
+	second := fakeCompressor{name: name, tag: 'b'}
+
+	RegisterCompressor(first)
+	RegisterCompressor(second)
+
+	got, ok := GetCompressor(name)
+	if !ok {
+		t.Fatal("expected compressor to be registered")
+	}
+	if got.(fakeCompressor).tag != 'b' {
+		t.Error("expected the second registration to win")
+	}
+}
+
+// fakeCompressor is a no-op Compressor used only to test the registry
+// itself, independent of any real compression algorithm.
+type fakeCompressor struct {
+	name string
+	tag  byte
+}
+
+func (f fakeCompressor) Name() string                             { return f.name }
+func (f fakeCompressor) Compress(w io.Writer) io.WriteCloser       { return nil }
+func (f fakeCompressor) Decompress(r io.Reader) (io.Reader, error) { return nil, nil }
@@ -0,0 +1,39 @@
+//go:build zstd
+
+package pyproc
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdCompressor is the "zstd" Compressor, gated behind the "zstd" build
+// tag so the default build doesn't vendor klauspost/compress just for
+// deployments that never select it via TransportConfig.Options["compression"].
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string { return "zstd" }
+
+func (zstdCompressor) Compress(w io.Writer) io.WriteCloser {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		// Only NewWriter's own option validation can fail here, and
+		// zstdCompressor passes none, so this is unreachable in practice.
+		panic(fmt.Sprintf("pyproc: zstd.NewWriter: %v", err))
+	}
+	return zw
+}
+
+func (zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("zstd: %w", err)
+	}
+	return zr.IOReadCloser(), nil
+}
+
+func init() {
+	RegisterCompressor(zstdCompressor{})
+}
@@ -15,6 +15,20 @@ type Config struct {
 	Protocol ProtocolConfig `mapstructure:"protocol"`
 	Logging  LoggingConfig  `mapstructure:"logging"`
 	Metrics  MetricsConfig  `mapstructure:"metrics"`
+	Auth     AuthConfig     `mapstructure:"auth"`
+}
+
+// AuthConfig defines the SASL-style authentication settings for the UDS.
+// Mechanisms are offered to the negotiation in the order listed here.
+type AuthConfig struct {
+	// Mechanisms lists the enabled mechanism names, e.g.
+	// ["SCRAM-SHA-256", "PEERCRED", "HMAC-SHA256"]. Defaults to ["ANONYMOUS"].
+	Mechanisms []string `mapstructure:"mechanisms"`
+	// Secret is the shared secret used by HMAC-SHA256 and SCRAM-SHA-256.
+	Secret string `mapstructure:"secret"`
+	// AllowedUIDs/AllowedGIDs configure the PEERCRED mechanism's allowlist.
+	AllowedUIDs []uint32 `mapstructure:"allowed_uids"`
+	AllowedGIDs []uint32 `mapstructure:"allowed_gids"`
 }
 
 // PoolConfig defines worker pool settings
@@ -24,6 +38,33 @@ type PoolConfig struct {
 	StartTimeout   time.Duration `mapstructure:"start_timeout"`
 	HealthInterval time.Duration `mapstructure:"health_interval"`
 	Restart        RestartConfig `mapstructure:"restart"`
+	// CallbackConcurrency bounds how many Go handler invocations triggered by
+	// a worker's pyproc.call_go() may run at once, across all workers. 0
+	// means the default of 10; it is not a per-worker limit.
+	CallbackConcurrency int `mapstructure:"callback_concurrency"`
+	// RateLimit configures token-bucket admission control for
+	// PoolWithTransport.Call/TryCall (see ratelimit.go). The zero value
+	// disables rate limiting entirely.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	// CircuitBreaker, if non-nil, is applied to every transport
+	// PoolWithTransport.Start creates (see transport_circuitbreaker.go). A
+	// nil value (the default) leaves transports unwrapped.
+	CircuitBreaker *CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// LameDuckTimeout bounds how long Shutdown waits for each worker's
+	// in-flight calls to finish after it stops routing new ones there,
+	// before closing its connection and escalating to SIGTERM/SIGKILL. 0
+	// defaults to 5 seconds. Also copied into each worker's
+	// WorkerConfig.LameDuckTimeout.
+	LameDuckTimeout time.Duration `mapstructure:"lame_duck_timeout"`
+	// MaxRestartsPerWindow and RestartWindow bound how many times a single
+	// worker may be restarted within RestartWindow before its Supervisor
+	// marks it degraded and gives up restarting it (see Supervisor,
+	// HealthStatus.DegradedWorkers). This is a crash-loop breaker distinct
+	// from Restart.MaxAttempts, which only counts consecutive failed-to-start
+	// attempts and resets on the first successful restart. MaxRestartsPerWindow
+	// <= 0 disables the breaker. RestartWindow <= 0 defaults to 1 minute.
+	MaxRestartsPerWindow int           `mapstructure:"max_restarts_per_window"`
+	RestartWindow        time.Duration `mapstructure:"restart_window"`
 }
 
 // RestartConfig defines restart policy
@@ -32,6 +73,11 @@ type RestartConfig struct {
 	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
 	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
 	Multiplier     float64       `mapstructure:"multiplier"`
+	// Strategy selects the BackoffStrategy used between restart attempts.
+	// One of "constant", "exponential", "exponential-jitter" (LoadConfig's
+	// default), "decorrelated-jitter", or "connection"; an empty Strategy
+	// selects "exponential". See BackoffStrategyType.
+	Strategy string `mapstructure:"strategy"`
 }
 
 // PythonConfig defines Python runtime settings
@@ -60,6 +106,12 @@ type LoggingConfig struct {
 	Level        string `mapstructure:"level"`
 	Format       string `mapstructure:"format"`
 	TraceEnabled bool   `mapstructure:"trace_enabled"`
+
+	// Hooks are additional structured-event sinks built alongside the
+	// default stdout handler - syslog, journald, a rotating file, or a
+	// Kafka/NATS producer - so operators can ship per-request telemetry
+	// off-box. See LogEvent and HookConfig.
+	Hooks []HookConfig `mapstructure:"hooks"`
 }
 
 // MetricsConfig defines metrics collection settings
@@ -71,12 +123,25 @@ type MetricsConfig struct {
 
 // LoadConfig loads configuration from file and environment
 func LoadConfig(configPath string) (*Config, error) {
+	v := newConfigViper(configPath)
+
+	if err := v.ReadInConfig(); err != nil {
+		// It's ok if config file doesn't exist, we have defaults
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	return buildConfig(v)
+}
+
+// newConfigViper builds the viper instance shared by LoadConfig and
+// LoadConfigWatched, so both apply the same defaults/env/file search path.
+func newConfigViper(configPath string) *viper.Viper {
 	v := viper.New()
 
-	// Set defaults
 	setDefaults(v)
 
-	// Set config file
 	if configPath != "" {
 		v.SetConfigFile(configPath)
 	} else {
@@ -87,25 +152,23 @@ func LoadConfig(configPath string) (*Config, error) {
 		v.AddConfigPath("/etc/pyproc")
 	}
 
-	// Read environment variables
 	v.SetEnvPrefix("PYPROC")
 	v.AutomaticEnv()
 
-	// Read config file
-	if err := v.ReadInConfig(); err != nil {
-		// It's ok if config file doesn't exist, we have defaults
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("failed to read config: %w", err)
-		}
-	}
+	return v
+}
 
-	// Unmarshal config
+// buildConfig unmarshals v into a fresh Config and applies unit conversions.
+// It always starts from v's raw values, never from a previously-built Config,
+// so calling it repeatedly (as LoadConfigWatched does on every file change)
+// cannot double-apply the second/millisecond multipliers below.
+func buildConfig(v *viper.Viper) (*Config, error) {
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// Convert duration fields (viper reads them as seconds)
+	// Convert duration fields (viper reads them as seconds/milliseconds)
 	cfg.Pool.StartTimeout *= time.Second
 	cfg.Pool.HealthInterval *= time.Second
 	cfg.Pool.Restart.InitialBackoff *= time.Millisecond
@@ -126,6 +189,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("pool.restart.initial_backoff", 1000)
 	v.SetDefault("pool.restart.max_backoff", 30000)
 	v.SetDefault("pool.restart.multiplier", 2.0)
+	v.SetDefault("pool.restart.strategy", string(BackoffExponentialJitter))
 
 	// Python defaults
 	v.SetDefault("python.executable", "python3")
@@ -153,4 +217,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("metrics.enabled", true)
 	v.SetDefault("metrics.endpoint", ":9090")
 	v.SetDefault("metrics.path", "/metrics")
+
+	// Auth defaults: no handshake beyond filesystem socket permissions
+	v.SetDefault("auth.mechanisms", []string{"ANONYMOUS"})
 }
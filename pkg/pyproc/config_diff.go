@@ -0,0 +1,52 @@
+package pyproc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// diffConfig compares old and new Config field-by-field (recursing into
+// nested structs) and returns one ConfigChange per leaf field whose value
+// differs. Field names use dotted struct-field notation, e.g. "Pool.Workers".
+func diffConfig(old, new *Config) []ConfigChange {
+	var changes []ConfigChange
+	diffStruct("", reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), &changes)
+	return changes
+}
+
+func diffStruct(prefix string, oldV, newV reflect.Value, changes *[]ConfigChange) {
+	t := oldV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		oldField := oldV.Field(i)
+		newField := newV.Field(i)
+
+		if oldField.Kind() == reflect.Struct {
+			diffStruct(name, oldField, newField, changes)
+			continue
+		}
+
+		oldVal := oldField.Interface()
+		newVal := newField.Interface()
+		if !reflect.DeepEqual(oldVal, newVal) {
+			*changes = append(*changes, ConfigChange{Field: name, Old: oldVal, New: newVal})
+		}
+	}
+}
+
+// String renders a ConfigChange for logging.
+func (c ConfigChange) String() string {
+	if c.Err != nil {
+		return fmt.Sprintf("%s: rejected (%v)", c.Field, c.Err)
+	}
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
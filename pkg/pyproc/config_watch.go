@@ -0,0 +1,73 @@
+package pyproc
+
+import (
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// unsafeConfigFields cannot be changed at runtime without restarting the
+// process (e.g. a socket directory change would orphan already-listening
+// sockets). Changes to these fields are reported as an error on the
+// ConfigChange channel instead of being applied.
+var unsafeConfigFields = map[string]bool{
+	"Socket.Dir":          true,
+	"Socket.Prefix":       true,
+	"Python.Executable":   true,
+	"Python.WorkerScript": true,
+}
+
+// ConfigChange describes a single field that differs between the previous
+// and newly-reloaded Config. Field uses Go struct-field dotted notation
+// (e.g. "Pool.Workers") rather than the mapstructure tag name.
+type ConfigChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+	// Err is set instead of being applied when Field is in unsafeConfigFields.
+	Err error
+}
+
+// LoadConfigWatched loads configuration like LoadConfig, then keeps watching
+// the backing file for changes. Each detected change is diffed field-by-field
+// against the previously loaded Config and emitted on the returned channel.
+// The channel is never closed and the watch runs for the life of the
+// process: there is no Stop method, matching viper's own WatchConfig having
+// no unwatch primitive.
+func LoadConfigWatched(configPath string) (*Config, <-chan ConfigChange, error) {
+	v := newConfigViper(configPath)
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	cfg, err := buildConfig(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := make(chan ConfigChange, 16)
+	current := cfg
+
+	v.OnConfigChange(func(e fsnotify.Event) {
+		next, err := buildConfig(v)
+		if err != nil {
+			changes <- ConfigChange{Field: "<reload>", Err: fmt.Errorf("failed to reload config: %w", err)}
+			return
+		}
+
+		for _, change := range diffConfig(current, next) {
+			if unsafeConfigFields[change.Field] {
+				change.Err = fmt.Errorf("field %s cannot be changed without a restart", change.Field)
+			}
+			changes <- change
+		}
+		current = next
+	})
+	v.WatchConfig()
+
+	return cfg, changes, nil
+}
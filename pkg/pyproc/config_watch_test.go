@@ -0,0 +1,111 @@
+package pyproc
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiffConfig(t *testing.T) {
+	old, err := buildConfig(newConfigViper(""))
+	if err != nil {
+		t.Fatalf("buildConfig failed: %v", err)
+	}
+	newCfg, err := buildConfig(newConfigViper(""))
+	if err != nil {
+		t.Fatalf("buildConfig failed: %v", err)
+	}
+
+	newCfg.Pool.Workers = old.Pool.Workers + 4
+	newCfg.Logging.Level = "debug"
+
+	changes := diffConfig(old, newCfg)
+
+	got := map[string]bool{}
+	for _, c := range changes {
+		got[c.Field] = true
+	}
+
+	if !got["Pool.Workers"] {
+		t.Error("expected Pool.Workers to be reported as changed")
+	}
+	if !got["Logging.Level"] {
+		t.Error("expected Logging.Level to be reported as changed")
+	}
+}
+
+func TestDiffConfigNoChanges(t *testing.T) {
+	old, err := buildConfig(newConfigViper(""))
+	if err != nil {
+		t.Fatalf("buildConfig failed: %v", err)
+	}
+	newCfg, err := buildConfig(newConfigViper(""))
+	if err != nil {
+		t.Fatalf("buildConfig failed: %v", err)
+	}
+
+	if changes := diffConfig(old, newCfg); len(changes) != 0 {
+		t.Errorf("expected no changes between two default configs, got %v", changes)
+	}
+}
+
+func TestLoadConfigWatchedDetectsReload(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/config.yaml"
+	if err := os.WriteFile(path, []byte("pool:\n  workers: 2\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, changes, err := LoadConfigWatched(path)
+	if err != nil {
+		t.Fatalf("LoadConfigWatched failed: %v", err)
+	}
+	if cfg.Pool.Workers != 2 {
+		t.Fatalf("expected 2 workers, got %d", cfg.Pool.Workers)
+	}
+
+	if err := os.WriteFile(path, []byte("pool:\n  workers: 5\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+
+	select {
+	case change := <-changes:
+		if change.Field != "Pool.Workers" {
+			t.Errorf("expected Pool.Workers change, got %s", change.Field)
+		}
+		if change.New != 5 {
+			t.Errorf("expected new value 5, got %v", change.New)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change notification")
+	}
+}
+
+func TestLoadConfigWatchedFlagsUnsafeField(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/config.yaml"
+	if err := os.WriteFile(path, []byte("socket:\n  dir: /tmp\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	_, changes, err := LoadConfigWatched(path)
+	if err != nil {
+		t.Fatalf("LoadConfigWatched failed: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("socket:\n  dir: /var/run/pyproc\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+
+	select {
+	case change := <-changes:
+		if change.Field != "Socket.Dir" {
+			t.Errorf("expected Socket.Dir change, got %s", change.Field)
+		}
+		if change.Err == nil {
+			t.Error("expected Socket.Dir change to be flagged as unsafe")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change notification")
+	}
+}
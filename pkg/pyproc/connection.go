@@ -5,32 +5,87 @@ import (
 	"fmt"
 	"net"
 	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
 )
 
-const defaultSleepDuration = 100 * time.Millisecond
+// connectBackoff paces retries inside ConnectToWorker: a crashing or slow-
+// starting worker (e.g. one importing heavy libraries like torch or
+// transformers) shouldn't be hammered with a dial every tick. Pool restarts
+// (see superviseRestart) use the BackoffStrategy built from
+// RestartConfig.Strategy instead; Strategy "connection" selects this same
+// ConnectionBackoff shape, with NewConnectionBackoff's defaults rather than
+// these.
+var connectBackoff = &ConnectionBackoff{
+	BaseDelay:  100 * time.Millisecond,
+	Multiplier: 1.6,
+	MaxDelay:   30 * time.Second,
+	Jitter:     0.2,
+}
+
+// ConnectToWorker connects to a worker via Unix domain socket, retrying with
+// exponential backoff and jitter until it succeeds or timeout elapses. strategy
+// optionally overrides the retry pacing (connectBackoff otherwise) - callers
+// that already restart workers with their own BackoffStrategy can reuse it
+// here so dialing backs off the same way restarts do.
+func ConnectToWorker(socketPath string, timeout time.Duration, strategy ...BackoffStrategy) (net.Conn, error) {
+	backoff := BackoffStrategy(connectBackoff)
+	if len(strategy) > 0 && strategy[0] != nil {
+		backoff = strategy[0]
+	}
 
-// ConnectToWorker connects to a worker via Unix domain socket
-func ConnectToWorker(socketPath string, timeout time.Duration) (net.Conn, error) {
 	// Set connection timeout
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	for {
+	for attempt := 0; ; attempt++ {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("failed to connect to worker at %s after %v", socketPath, timeout)
+			return nil, fmt.Errorf("failed to connect to worker at %s after %d attempts (%v): %w", socketPath, attempt, timeout, ctx.Err())
 		default:
 			conn, err := net.Dial("unix", socketPath)
 			if err == nil {
 				return conn, nil
 			}
-			if err := sleepWithCtx(ctx, defaultSleepDuration); err != nil {
-				return nil, fmt.Errorf("failed to connect to worker at %s after %v", socketPath, timeout)
+			if sleepErr := sleepWithCtx(ctx, backoff.NextDelay(attempt)); sleepErr != nil {
+				return nil, fmt.Errorf("failed to connect to worker at %s after %d attempts (%v): %w", socketPath, attempt+1, timeout, err)
 			}
 		}
 	}
 }
 
+// NegotiatedCodecs lists the codec names ConnectToWorkerNegotiated offers a
+// worker, most preferred first.
+var NegotiatedCodecs = []string{string(CodecJSON), string(CodecMessagePack), string(CodecProtobuf)}
+
+// ConnectToWorkerNegotiated is ConnectToWorker followed by the framing
+// version/msize/codec handshake (see framing.Negotiate): once connected, it
+// exchanges a framing.NegotiationRequest proposing maxFrameSize and
+// NegotiatedCodecs, and returns the conn alongside the worker's
+// framing.NegotiationResponse so the caller can build its Framer/codec
+// around what was actually agreed instead of assuming hardcoded constants.
+// A worker that doesn't speak framing.ProtocolVersion closes the handshake
+// with framing.ErrUnsupportedVersion; the connection is closed before
+// returning in that case.
+func ConnectToWorkerNegotiated(socketPath string, timeout time.Duration, maxFrameSize int, strategy ...BackoffStrategy) (net.Conn, *framing.NegotiationResponse, error) {
+	conn, err := ConnectToWorker(socketPath, timeout, strategy...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, resp, err := framing.Negotiate(conn, framing.NegotiationRequest{
+		Version:      framing.ProtocolVersion,
+		MaxFrameSize: maxFrameSize,
+		Codecs:       NegotiatedCodecs,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	return conn, resp, nil
+}
+
 func sleepWithCtx(ctx context.Context, d time.Duration) error {
 	// Wait a bit before retrying
 	timer := time.NewTimer(d)
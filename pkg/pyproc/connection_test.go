@@ -0,0 +1,169 @@
+package pyproc
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+)
+
+func TestConnectToWorkerRetriesThenSucceeds(t *testing.T) {
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "worker.sock")
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	// Remove the socket file so the first dial attempts fail, then recreate
+	// it after a couple of retries - simulating a worker that's still
+	// importing heavy libraries when the caller starts dialing.
+	if err := os.Remove(socketPath); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	strategy := &countingBackoff{}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ln2, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return
+		}
+		defer ln2.Close()
+		conn, err := ln2.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	conn, err := ConnectToWorker(socketPath, time.Second, strategy)
+	if err != nil {
+		t.Fatalf("ConnectToWorker: unexpected error %v", err)
+	}
+	defer conn.Close()
+
+	if strategy.calls.Load() == 0 {
+		t.Error("expected the supplied BackoffStrategy to pace at least one retry")
+	}
+}
+
+func TestConnectToWorkerSurfacesAttemptCount(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "missing.sock")
+
+	_, err := ConnectToWorker(socketPath, 30*time.Millisecond, &countingBackoff{})
+	if err == nil {
+		t.Fatal("expected ConnectToWorker to fail against a socket that never appears")
+	}
+	if !strings.Contains(err.Error(), "attempts") {
+		t.Errorf("expected error to surface the attempt count, got %q", err)
+	}
+}
+
+func TestConnectToWorkerDefaultsWhenNoStrategyGiven(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "missing.sock")
+
+	start := time.Now()
+	_, err := ConnectToWorker(socketPath, 50*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected ConnectToWorker to fail against a socket that never appears")
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected ConnectToWorker to honor the timeout, returned after %v", elapsed)
+	}
+}
+
+func TestConnectToWorkerHonorsContextViaTimeout(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "missing.sock")
+	slow := &ConnectionBackoff{BaseDelay: time.Hour, Multiplier: 1, MaxDelay: time.Hour}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := ConnectToWorker(socketPath, 20*time.Millisecond, slow)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error once the timeout elapses")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ConnectToWorker did not return once its context deadline passed")
+	}
+}
+
+func TestConnectToWorkerNegotiatedAgreesWithWorker(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "worker.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		framing.NegotiateServer(conn, 4096, []string{"msgpack", "json"})
+	}()
+
+	conn, resp, err := ConnectToWorkerNegotiated(socketPath, time.Second, 8192)
+	if err != nil {
+		t.Fatalf("ConnectToWorkerNegotiated: %v", err)
+	}
+	defer conn.Close()
+
+	if resp.Version != framing.ProtocolVersion {
+		t.Errorf("expected version %s, got %s", framing.ProtocolVersion, resp.Version)
+	}
+	if resp.MaxFrameSize != 4096 {
+		t.Errorf("expected agreed max frame size 4096, got %d", resp.MaxFrameSize)
+	}
+	if resp.Codec != "msgpack" {
+		t.Errorf("expected worker's preferred codec msgpack, got %q", resp.Codec)
+	}
+}
+
+func TestConnectToWorkerNegotiatedRejectsVersionMismatch(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "worker.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Simulate an incompatible worker build: read the request but echo
+		// back a version framing.ProtocolVersion doesn't match, rather than
+		// the real NegotiateServer's always-agreeing version.
+		bootstrap := framing.NewFramerWithMaxSize(conn, framing.DefaultMaxFrameSize)
+		if _, err := bootstrap.ReadMessage(); err != nil {
+			return
+		}
+		respData, _ := json.Marshal(framing.NegotiationResponse{
+			Version:      "pyproc/0",
+			MaxFrameSize: framing.DefaultMaxFrameSize,
+			Codec:        "json",
+		})
+		bootstrap.WriteMessage(respData)
+	}()
+
+	_, _, err = ConnectToWorkerNegotiated(socketPath, time.Second, 8192)
+	if !errors.Is(err, framing.ErrUnsupportedVersion) {
+		t.Fatalf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package pyproc
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrConnPoolClosed is returned by ConnPool.Get once the pool has been closed.
+var ErrConnPoolClosed = errors.New("connection pool closed")
+
+// ConnPool is a small fixed-capacity pool of net.Conn to a single address,
+// sitting between a Transport and the raw connection so concurrent Calls
+// don't all serialize on one socket's read/write path. Get returns an idle
+// connection or lazily dials a fresh one (up to MaxCapacity); Put returns a
+// healthy connection for reuse or, for a broken one, closes it and frees
+// its slot so the next Get redials instead of handing out a dead conn.
+type ConnPool struct {
+	dial        func() (net.Conn, error)
+	maxCapacity int32
+
+	idle    chan net.Conn
+	numOpen atomic.Int32
+
+	closeOnce sync.Once
+	mu        sync.Mutex // guards closed against a concurrent Put
+	closed    bool
+}
+
+// NewConnPool creates a pool that dials via dial, pre-warmed with
+// initialSize connections and never holding more than maxCapacity open at
+// once. maxCapacity <= 0 is treated as 1; initialSize is clamped to
+// [0, maxCapacity]. If dialing one of the initial connections fails, any
+// already opened are closed and the error is returned.
+func NewConnPool(dial func() (net.Conn, error), initialSize, maxCapacity int) (*ConnPool, error) {
+	if maxCapacity <= 0 {
+		maxCapacity = 1
+	}
+	if initialSize > maxCapacity {
+		initialSize = maxCapacity
+	}
+	if initialSize < 0 {
+		initialSize = 0
+	}
+
+	p := &ConnPool{
+		dial:        dial,
+		maxCapacity: int32(maxCapacity),
+		idle:        make(chan net.Conn, maxCapacity),
+	}
+
+	for i := 0; i < initialSize; i++ {
+		conn, err := dial()
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+		p.numOpen.Add(1)
+		p.idle <- conn
+	}
+
+	return p, nil
+}
+
+// Get returns an idle connection if one is available, dials a fresh one if
+// the pool hasn't reached maxCapacity, and otherwise blocks until a
+// connection is Put back.
+func (p *ConnPool) Get() (net.Conn, error) {
+	select {
+	case conn, ok := <-p.idle:
+		if !ok {
+			return nil, ErrConnPoolClosed
+		}
+		return conn, nil
+	default:
+	}
+
+	for {
+		cur := p.numOpen.Load()
+		if cur >= p.maxCapacity {
+			break
+		}
+		if p.numOpen.CompareAndSwap(cur, cur+1) {
+			conn, err := p.dial()
+			if err != nil {
+				p.numOpen.Add(-1)
+				return nil, err
+			}
+			return conn, nil
+		}
+	}
+
+	conn, ok := <-p.idle
+	if !ok {
+		return nil, ErrConnPoolClosed
+	}
+	return conn, nil
+}
+
+// Put returns conn to the pool for reuse. A broken conn (the caller hit a
+// read/write error on it) is closed and its slot freed instead, so the
+// next Get lazily redials rather than handing out a dead connection.
+func (p *ConnPool) Put(conn net.Conn, broken bool) {
+	if broken {
+		_ = conn.Close()
+		p.numOpen.Add(-1)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		// Close ran first: idle is closed, so close conn rather than leak it.
+		_ = conn.Close()
+		p.numOpen.Add(-1)
+		return
+	}
+
+	select {
+	case p.idle <- conn:
+	default:
+		// The channel is sized to maxCapacity and numOpen never exceeds
+		// it, so this isn't expected to fire - don't leak the connection
+		// if it does.
+		_ = conn.Close()
+		p.numOpen.Add(-1)
+	}
+}
+
+// Close closes every idle connection and marks the pool unusable; a Get
+// already blocked, or called afterwards, returns ErrConnPoolClosed.
+// Connections currently checked out are not closed here - the transport
+// is responsible for not calling Get again after Close.
+func (p *ConnPool) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		p.mu.Lock()
+		p.closed = true
+		close(p.idle)
+		p.mu.Unlock()
+		for conn := range p.idle {
+			if cerr := conn.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
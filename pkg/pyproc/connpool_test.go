@@ -0,0 +1,183 @@
+package pyproc
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingDialer returns a dial func that hands out one end of a
+// net.Pipe per call (discarding the other end, since these tests only
+// exercise pool bookkeeping, not actual I/O) and a counter of how many
+// times it was called.
+func newCountingDialer() (func() (net.Conn, error), *atomic.Int32) {
+	var calls atomic.Int32
+	dial := func() (net.Conn, error) {
+		calls.Add(1)
+		client, server := net.Pipe()
+		go func() { _ = server.Close() }()
+		return client, nil
+	}
+	return dial, &calls
+}
+
+func TestNewConnPoolPreWarms(t *testing.T) {
+	dial, calls := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 3, 5)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("dial called %d times, want 3", got)
+	}
+}
+
+func TestConnPoolGetReusesPutConn(t *testing.T) {
+	dial, calls := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 1, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	pool.Put(conn, false)
+
+	conn2, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if conn2 != conn {
+		t.Error("Get() after Put() dialed a new connection instead of reusing the idle one")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("dial called %d times, want 1", got)
+	}
+}
+
+func TestConnPoolGetDialsUpToMaxCapacity(t *testing.T) {
+	dial, calls := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 0, 2)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	conn1, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	conn2, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if conn1 == conn2 {
+		t.Error("two concurrent Get() calls returned the same connection")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("dial called %d times, want 2", got)
+	}
+}
+
+func TestConnPoolGetBlocksAtCapacityUntilPut(t *testing.T) {
+	dial, _ := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 1, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got := make(chan net.Conn, 1)
+	go func() {
+		c, err := pool.Get()
+		if err != nil {
+			return
+		}
+		got <- c
+	}()
+
+	select {
+	case <-got:
+		t.Fatal("Get() returned before a connection was Put back")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pool.Put(conn, false)
+
+	select {
+	case c := <-got:
+		if c != conn {
+			t.Error("blocked Get() did not receive the connection that was Put back")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocked Get() never unblocked after Put()")
+	}
+}
+
+func TestConnPoolPutBrokenDiscardsAndRedials(t *testing.T) {
+	dial, calls := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 1, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	pool.Put(conn, true) // broken - should be discarded, not returned to idle
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("dial called %d times after warm-up, want 1", got)
+	}
+
+	conn2, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get() after broken Put() error = %v", err)
+	}
+	if conn2 == conn {
+		t.Error("Get() handed out a connection that was marked broken")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("dial called %d times, want 2 (redial after broken Put)", got)
+	}
+}
+
+func TestConnPoolCloseClosesIdleAndRejectsGet(t *testing.T) {
+	dial, _ := newCountingDialer()
+
+	pool, err := NewConnPool(dial, 2, 2)
+	if err != nil {
+		t.Fatalf("NewConnPool() error = %v", err)
+	}
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := pool.Get(); err != ErrConnPoolClosed {
+		t.Errorf("Get() after Close() error = %v, want ErrConnPoolClosed", err)
+	}
+
+	// Close is idempotent.
+	if err := pool.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+// Package fs mounts a Python worker's local filesystem over the same
+// framed UDS connection pyproc.Pool.Call uses, instead of a second socket
+// or an assumption that Go and the worker share a filesystem. It borrows
+// the miniccc/9P "mount the guest over the existing control connection"
+// pattern: open/read/stat/readdir/close requests are tagged with
+// framing.CodecIDFileOp so a MultiplexedConn routes them to a Mux here
+// rather than through its usual protocol.Message/Codec path, and the
+// result is wrapped as a standard io/fs.FS so fs.WalkDir and fs.ReadFile
+// work against it unmodified.
+//
+// This package is the Go side only. There is no Python-side file-op server
+// in this repository yet to answer these requests - the small worker-side
+// handler the request this was built against calls for is still out of
+// scope here, the same kind of Go/Python boundary noted on
+// MultiplexedTransport and NewCodec.
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdfs "io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OpKind names a file operation a Request carries.
+type OpKind string
+
+const (
+	OpOpen    OpKind = "open"
+	OpRead    OpKind = "read"
+	OpReadDir OpKind = "readdir"
+	OpStat    OpKind = "stat"
+	OpClose   OpKind = "close"
+)
+
+// Request is one file-op call, framed with framing.CodecIDFileOp instead of
+// a protocol.Message envelope. The framing layer's own RequestID correlates
+// a Request with its Response, so neither carries one of its own.
+type Request struct {
+	Op     OpKind `json:"op"`
+	Path   string `json:"path,omitempty"`   // OpOpen, OpStat
+	FD     uint64 `json:"fd,omitempty"`     // OpRead, OpReadDir, OpClose
+	Offset int64  `json:"offset,omitempty"` // OpRead
+	Length int    `json:"length,omitempty"` // OpRead (bytes), OpReadDir (max entries, 0 = all)
+}
+
+// Response answers a Request. OK false means Error explains why the worker
+// refused or failed the operation, the same shape as protocol.Response.
+type Response struct {
+	OK      bool       `json:"ok"`
+	Error   string     `json:"error,omitempty"`
+	FD      uint64     `json:"fd,omitempty"`      // OpOpen
+	Data    []byte     `json:"data,omitempty"`    // OpRead
+	EOF     bool       `json:"eof,omitempty"`     // OpRead
+	Info    *FileInfo  `json:"info,omitempty"`    // OpOpen, OpStat
+	Entries []FileInfo `json:"entries,omitempty"` // OpReadDir
+}
+
+// Transport is what Mux needs from the connection it rides on: a way to
+// send a file-op frame tagged with requestID and have the matching
+// response payload eventually delivered back via Dispatch.
+// pyproc.MultiplexedConn implements this by tagging frames with
+// framing.CodecIDFileOp instead of going through its usual codec.
+type Transport interface {
+	SendFileOp(ctx context.Context, requestID uint64, payload []byte) error
+}
+
+// Mux multiplexes one mounted fs.FS's operations over a single Transport,
+// keyed by the framing layer's own request ID - the same per-call pending
+// map pattern pyproc.MultiplexedConn uses for ordinary calls, addressed by
+// a disjoint CodecID so the two never collide on the wire.
+type Mux struct {
+	transport Transport
+	nextID    atomic.Uint64
+
+	mu      sync.Mutex
+	pending map[uint64]chan *Response
+}
+
+// NewMux creates a Mux that sends its requests over transport.
+func NewMux(transport Transport) *Mux {
+	return &Mux{
+		transport: transport,
+		pending:   make(map[uint64]chan *Response),
+	}
+}
+
+// Dispatch delivers a file-op response frame's payload to the call waiting
+// on requestID. A requestID with no waiter (e.g. one that already timed
+// out) is silently dropped, the same as an unmatched pyproc call response.
+func (m *Mux) Dispatch(requestID uint64, payload []byte) {
+	m.mu.Lock()
+	ch, ok := m.pending[requestID]
+	if ok {
+		delete(m.pending, requestID)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	var resp Response
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		resp = Response{Error: fmt.Sprintf("fs: failed to decode response: %v", err)}
+	}
+	ch <- &resp
+}
+
+// call sends req and waits for its matching Response, ctx cancellation, or
+// a transport-level send failure, whichever happens first.
+func (m *Mux) call(ctx context.Context, req *Request) (*Response, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("fs: failed to encode request: %w", err)
+	}
+
+	id := m.nextID.Add(1)
+	respCh := make(chan *Response, 1)
+	m.mu.Lock()
+	m.pending[id] = respCh
+	m.mu.Unlock()
+
+	if err := m.transport.SendFileOp(ctx, id, payload); err != nil {
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case resp := <-respCh:
+		if !resp.OK {
+			return nil, errors.New(resp.Error)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		delete(m.pending, id)
+		m.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// FileInfo is a remote file or directory's metadata. It implements both
+// stdfs.FileInfo and stdfs.DirEntry so a Response can satisfy whichever the
+// caller (directly, or a stdlib helper like fs.WalkDir) asks for.
+type FileInfo struct {
+	name    string
+	size    int64
+	mode    stdfs.FileMode
+	modTime time.Time
+	isDir   bool
+}
+
+// fileInfoWire is FileInfo's wire shape - FileInfo's own fields are
+// unexported so json can't populate them directly.
+type fileInfoWire struct {
+	Name    string `json:"name"`
+	Size    int64  `json:"size"`
+	Mode    uint32 `json:"mode"`
+	ModTime int64  `json:"mod_time"` // Unix seconds
+	IsDir   bool   `json:"is_dir"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (fi FileInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fileInfoWire{
+		Name:    fi.name,
+		Size:    fi.size,
+		Mode:    uint32(fi.mode),
+		ModTime: fi.modTime.Unix(),
+		IsDir:   fi.isDir,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (fi *FileInfo) UnmarshalJSON(data []byte) error {
+	var w fileInfoWire
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	fi.name = w.Name
+	fi.size = w.Size
+	fi.mode = stdfs.FileMode(w.Mode)
+	fi.modTime = time.Unix(w.ModTime, 0)
+	fi.isDir = w.IsDir
+	return nil
+}
+
+func (fi *FileInfo) Name() string                  { return fi.name }
+func (fi *FileInfo) Size() int64                   { return fi.size }
+func (fi *FileInfo) Mode() stdfs.FileMode          { return fi.mode }
+func (fi *FileInfo) ModTime() time.Time            { return fi.modTime }
+func (fi *FileInfo) IsDir() bool                   { return fi.isDir }
+func (fi *FileInfo) Sys() interface{}              { return nil }
+func (fi *FileInfo) Type() stdfs.FileMode          { return fi.mode.Type() }
+func (fi *FileInfo) Info() (stdfs.FileInfo, error) { return fi, nil }
+
+// remoteFS implements io/fs.FS over a Mux, presenting whatever path a
+// worker's file-op server is willing to serve (model checkpoints, cached
+// embeddings, tempfiles in its CWD) as a read-only filesystem.
+// Pool.Mount returns one of these.
+type remoteFS struct {
+	ctx context.Context
+	mux *Mux
+}
+
+// New wraps mux as an io/fs.FS, scoping every operation to ctx - typically
+// the context Pool.Mount was called with.
+func New(ctx context.Context, mux *Mux) stdfs.FS {
+	return &remoteFS{ctx: ctx, mux: mux}
+}
+
+// Open implements io/fs.FS.
+func (r *remoteFS) Open(name string) (stdfs.File, error) {
+	if !stdfs.ValidPath(name) {
+		return nil, &stdfs.PathError{Op: "open", Path: name, Err: stdfs.ErrInvalid}
+	}
+
+	resp, err := r.mux.call(r.ctx, &Request{Op: OpOpen, Path: name})
+	if err != nil {
+		return nil, &stdfs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return &remoteFile{ctx: r.ctx, mux: r.mux, fd: resp.FD, name: name, info: resp.Info}, nil
+}
+
+// Stat implements io/fs.StatFS, letting callers avoid an Open+Stat+Close
+// round trip just to check a file's metadata.
+func (r *remoteFS) Stat(name string) (stdfs.FileInfo, error) {
+	if !stdfs.ValidPath(name) {
+		return nil, &stdfs.PathError{Op: "stat", Path: name, Err: stdfs.ErrInvalid}
+	}
+
+	resp, err := r.mux.call(r.ctx, &Request{Op: OpStat, Path: name})
+	if err != nil {
+		return nil, &stdfs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return resp.Info, nil
+}
+
+// remoteFile implements io/fs.File, and io/fs.ReadDirFile when it names a
+// directory, so fs.WalkDir can descend into a remoteFS the same way it
+// would os.DirFS.
+type remoteFile struct {
+	ctx  context.Context
+	mux  *Mux
+	fd   uint64
+	name string
+	info *FileInfo
+
+	offset int64
+	closed bool
+}
+
+// Stat implements io/fs.File.
+func (f *remoteFile) Stat() (stdfs.FileInfo, error) {
+	if f.info == nil {
+		return nil, fmt.Errorf("fs: %s: no file info", f.name)
+	}
+	return f.info, nil
+}
+
+// Read implements io.Reader, fetching up to len(p) bytes starting at the
+// file's current offset from the worker on every call - remoteFile keeps no
+// local read-ahead buffer of its own.
+func (f *remoteFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, stdfs.ErrClosed
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	resp, err := f.mux.call(f.ctx, &Request{Op: OpRead, FD: f.fd, Offset: f.offset, Length: len(p)})
+	if err != nil {
+		return 0, err
+	}
+
+	n := copy(p, resp.Data)
+	f.offset += int64(n)
+	if n == 0 && resp.EOF {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// Close implements io/fs.File.
+func (f *remoteFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+
+	_, err := f.mux.call(f.ctx, &Request{Op: OpClose, FD: f.fd})
+	return err
+}
+
+// ReadDir implements io/fs.ReadDirFile for a directory handle, so
+// fs.WalkDir can list a mounted directory's entries.
+func (f *remoteFile) ReadDir(n int) ([]stdfs.DirEntry, error) {
+	resp, err := f.mux.call(f.ctx, &Request{Op: OpReadDir, FD: f.fd, Length: n})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]stdfs.DirEntry, len(resp.Entries))
+	for i := range resp.Entries {
+		entry := resp.Entries[i]
+		entries[i] = &entry
+	}
+	return entries, nil
+}
@@ -0,0 +1,202 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	stdfs "io/fs"
+	"testing"
+	"time"
+)
+
+// fakeWorker answers Mux requests in-process, standing in for the Python
+// file-op server that doesn't exist in this repo yet.
+type fakeWorker struct {
+	mux *Mux
+
+	files map[string][]byte
+	dirs  map[string][]FileInfo
+
+	nextFD uint64
+	open   map[uint64]*Request
+}
+
+func newFakeWorker() *fakeWorker {
+	return &fakeWorker{
+		files: make(map[string][]byte),
+		dirs:  make(map[string][]FileInfo),
+		open:  make(map[uint64]*Request),
+	}
+}
+
+// SendFileOp implements Transport by handling req synchronously and
+// dispatching the response back through the same Mux, as if it had arrived
+// over the wire.
+func (w *fakeWorker) SendFileOp(ctx context.Context, requestID uint64, payload []byte) error {
+	var req Request
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return err
+	}
+
+	resp := w.handle(&req)
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	w.mux.Dispatch(requestID, data)
+	return nil
+}
+
+func (w *fakeWorker) handle(req *Request) *Response {
+	switch req.Op {
+	case OpOpen:
+		data, ok := w.files[req.Path]
+		if !ok {
+			if _, ok := w.dirs[req.Path]; !ok {
+				return &Response{Error: "no such file: " + req.Path}
+			}
+			w.nextFD++
+			w.open[w.nextFD] = req
+			return &Response{OK: true, FD: w.nextFD, Info: &FileInfo{name: req.Path, isDir: true}}
+		}
+		w.nextFD++
+		w.open[w.nextFD] = req
+		return &Response{OK: true, FD: w.nextFD, Info: &FileInfo{name: req.Path, size: int64(len(data))}}
+	case OpStat:
+		if data, ok := w.files[req.Path]; ok {
+			return &Response{OK: true, Info: &FileInfo{name: req.Path, size: int64(len(data))}}
+		}
+		if _, ok := w.dirs[req.Path]; ok {
+			return &Response{OK: true, Info: &FileInfo{name: req.Path, isDir: true}}
+		}
+		return &Response{Error: "no such file: " + req.Path}
+	case OpRead:
+		opened := w.open[req.FD]
+		data := w.files[opened.Path]
+		if req.Offset >= int64(len(data)) {
+			return &Response{OK: true, EOF: true}
+		}
+		end := req.Offset + int64(req.Length)
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		return &Response{OK: true, Data: data[req.Offset:end]}
+	case OpReadDir:
+		opened := w.open[req.FD]
+		return &Response{OK: true, Entries: w.dirs[opened.Path]}
+	case OpClose:
+		delete(w.open, req.FD)
+		return &Response{OK: true}
+	default:
+		return &Response{Error: "unknown op: " + string(req.Op)}
+	}
+}
+
+func TestRemoteFSReadFile(t *testing.T) {
+	worker := newFakeWorker()
+	worker.files["model.bin"] = []byte("weights go here")
+	mux := NewMux(worker)
+	worker.mux = mux
+
+	rfs := New(context.Background(), mux)
+
+	data, err := stdfs.ReadFile(rfs, "model.bin")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "weights go here" {
+		t.Errorf("expected %q, got %q", "weights go here", data)
+	}
+}
+
+func TestRemoteFSOpenMissing(t *testing.T) {
+	worker := newFakeWorker()
+	mux := NewMux(worker)
+	worker.mux = mux
+
+	rfs := New(context.Background(), mux)
+
+	if _, err := rfs.Open("missing.bin"); err == nil {
+		t.Fatal("expected an error for a missing file, got nil")
+	}
+}
+
+func TestRemoteFSStat(t *testing.T) {
+	worker := newFakeWorker()
+	worker.files["checkpoint.pt"] = []byte("0123456789")
+	mux := NewMux(worker)
+	worker.mux = mux
+
+	rfs := New(context.Background(), mux)
+
+	info, err := stdfs.Stat(rfs, "checkpoint.pt")
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Size() != 10 {
+		t.Errorf("expected size 10, got %d", info.Size())
+	}
+}
+
+func TestRemoteFSWalkDir(t *testing.T) {
+	worker := newFakeWorker()
+	worker.dirs["checkpoints"] = []FileInfo{
+		{name: "epoch-1.pt", size: 4},
+		{name: "epoch-2.pt", size: 8},
+	}
+	mux := NewMux(worker)
+	worker.mux = mux
+
+	rfs := New(context.Background(), mux)
+
+	var names []string
+	err := stdfs.WalkDir(rfs, "checkpoints", func(path string, d stdfs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		names = append(names, d.Name())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir failed: %v", err)
+	}
+	if len(names) != 3 { // "checkpoints" itself plus its two entries
+		t.Errorf("expected 3 walked entries, got %d: %v", len(names), names)
+	}
+}
+
+func TestMuxCallContextCancelled(t *testing.T) {
+	mux := NewMux(blockingTransport{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := mux.call(ctx, &Request{Op: OpStat, Path: "x"})
+	if err == nil {
+		t.Fatal("expected a context-cancellation error, got nil")
+	}
+}
+
+// blockingTransport never calls Dispatch, so a Mux call against it can only
+// return via ctx cancellation.
+type blockingTransport struct{}
+
+func (blockingTransport) SendFileOp(ctx context.Context, requestID uint64, payload []byte) error {
+	return nil
+}
+
+func TestFileInfoJSONRoundTrip(t *testing.T) {
+	want := FileInfo{name: "x.bin", size: 42, isDir: false, modTime: time.Unix(1700000000, 0)}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var got FileInfo
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.Name() != want.Name() || got.Size() != want.Size() || !got.ModTime().Equal(want.ModTime()) {
+		t.Errorf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
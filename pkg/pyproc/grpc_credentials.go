@@ -0,0 +1,49 @@
+package pyproc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// BearerTokenCredentials implements credentials.PerRPCCredentials with a
+// static bearer token, attached to every RPC as an "authorization" metadata
+// entry. Pass it via TransportConfig.Options["per_rpc_credentials"].
+type BearerTokenCredentials struct {
+	Token string
+	// RequireTLS refuses to attach the token unless the channel is
+	// transport-secure, so it can't leak over a plaintext connection.
+	RequireTLS bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c BearerTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.Token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (c BearerTokenCredentials) RequireTransportSecurity() bool {
+	return c.RequireTLS
+}
+
+var _ credentials.PerRPCCredentials = BearerTokenCredentials{}
+
+// CallbackCredentials implements credentials.PerRPCCredentials by invoking a
+// user-supplied function for metadata on every RPC, e.g. to mint a
+// short-lived token per call instead of reusing one static value.
+type CallbackCredentials struct {
+	Fn         func(ctx context.Context) (map[string]string, error)
+	RequireTLS bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c CallbackCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return c.Fn(ctx)
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (c CallbackCredentials) RequireTransportSecurity() bool {
+	return c.RequireTLS
+}
+
+var _ credentials.PerRPCCredentials = CallbackCredentials{}
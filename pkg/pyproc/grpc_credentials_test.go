@@ -0,0 +1,77 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBearerTokenCredentialsGetRequestMetadata(t *testing.T) {
+	c := BearerTokenCredentials{Token: "secret"}
+	md, err := c.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata failed: %v", err)
+	}
+	if md["authorization"] != "Bearer secret" {
+		t.Errorf("expected Bearer token metadata, got %q", md["authorization"])
+	}
+}
+
+func TestBearerTokenCredentialsRequireTransportSecurity(t *testing.T) {
+	if (BearerTokenCredentials{RequireTLS: true}).RequireTransportSecurity() != true {
+		t.Error("expected RequireTransportSecurity to reflect RequireTLS")
+	}
+	if (BearerTokenCredentials{RequireTLS: false}).RequireTransportSecurity() != false {
+		t.Error("expected RequireTransportSecurity to reflect RequireTLS")
+	}
+}
+
+func TestCallbackCredentialsGetRequestMetadata(t *testing.T) {
+	c := CallbackCredentials{
+		Fn: func(ctx context.Context) (map[string]string, error) {
+			return map[string]string{"x-api-key": "rotating-token"}, nil
+		},
+	}
+	md, err := c.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata failed: %v", err)
+	}
+	if md["x-api-key"] != "rotating-token" {
+		t.Errorf("expected callback-provided metadata, got %v", md)
+	}
+}
+
+func TestCallbackCredentialsPropagatesError(t *testing.T) {
+	wantErr := errors.New("token mint failed")
+	c := CallbackCredentials{
+		Fn: func(ctx context.Context) (map[string]string, error) {
+			return nil, wantErr
+		},
+	}
+	if _, err := c.GetRequestMetadata(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("expected Fn's error to propagate, got %v", err)
+	}
+}
+
+func TestIsRetryableGRPCError(t *testing.T) {
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{status.Error(codes.Unavailable, "down"), true},
+		{status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{status.Error(codes.ResourceExhausted, "too many requests"), true},
+		{status.Error(codes.InvalidArgument, "bad input"), false},
+		{status.Error(codes.PermissionDenied, "nope"), false},
+		{errors.New("not a grpc status"), false},
+	}
+
+	for _, tc := range cases {
+		if got := isRetryableGRPCError(tc.err); got != tc.retryable {
+			t.Errorf("isRetryableGRPCError(%v) = %v, want %v", tc.err, got, tc.retryable)
+		}
+	}
+}
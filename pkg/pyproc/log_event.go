@@ -0,0 +1,85 @@
+package pyproc
+
+import (
+	"fmt"
+	"time"
+)
+
+// LogEvent is the structured record delivered to every LogHook for a
+// request-scoped occurrence (request start/end, worker restart, pool
+// health), in addition to whatever line the Logger's own slog.Handler
+// writes. Err is the error's Str, or "" on success.
+type LogEvent struct {
+	Timestamp time.Time
+	Level     string
+	WorkerID  string
+	RequestID uint64
+	Method    string
+	LatencyUs int64
+	OK        bool
+	Err       string
+}
+
+// LogHook receives every LogEvent Logger.EmitEvent is given. Handle must not
+// block the caller on a slow sink - a hook that talks to the network (Kafka,
+// a remote syslogd) is expected to do its own buffering/async dispatch.
+type LogHook interface {
+	Handle(event LogEvent)
+}
+
+// EventPublisher sends a LogEvent to an external system, e.g. a Kafka topic
+// or NATS subject named by topic. pyproc has no client dependency on
+// either - callers adapt their own producer to this interface instead of
+// pyproc importing one.
+type EventPublisher interface {
+	Publish(topic string, event LogEvent) error
+}
+
+// HookConfig selects and configures a built-in LogHook for
+// LoggingConfig.Hooks.
+type HookConfig struct {
+	// Type selects the hook: "syslog", "journald", "file", or "kafka".
+	Type string `mapstructure:"type"`
+
+	// Syslog fields (Type == "syslog"). Network/Address dial a remote
+	// syslogd (e.g. "udp", "collector:514"); leave both empty to log to the
+	// local syslog daemon instead.
+	SyslogNetwork  string `mapstructure:"syslog_network"`
+	SyslogAddress  string `mapstructure:"syslog_address"`
+	SyslogFacility string `mapstructure:"syslog_facility"` // default "user"
+	SyslogTag      string `mapstructure:"syslog_tag"`      // default "pyproc"
+
+	// File fields (Type == "file"): a size-rotated log file.
+	FilePath   string `mapstructure:"file_path"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"` // default 100
+	MaxBackups int    `mapstructure:"max_backups"` // default 3
+
+	// Kafka/NATS fields (Type == "kafka"). Publisher does the actual send;
+	// Topic is passed through for publishers that route by topic/subject
+	// name rather than having it baked into their own config.
+	Topic     string         `mapstructure:"-"`
+	Publisher EventPublisher `mapstructure:"-"`
+}
+
+// NewHook builds the LogHook selected by cfg.Type.
+func NewHook(cfg HookConfig) (LogHook, error) {
+	switch cfg.Type {
+	case "syslog":
+		return newSyslogHook(cfg)
+	case "journald":
+		return newJournaldHook(cfg)
+	case "file":
+		return newFileHook(cfg)
+	case "kafka":
+		return newKafkaHook(cfg)
+	default:
+		return nil, fmt.Errorf("unknown log hook type: %s", cfg.Type)
+	}
+}
+
+// eventLine formats event as a single syslog/journald-friendly text line,
+// used by hooks that don't have their own structured wire format.
+func eventLine(event LogEvent) string {
+	return fmt.Sprintf("level=%s worker_id=%s req_id=%d method=%s latency_us=%d ok=%t err=%q",
+		event.Level, event.WorkerID, event.RequestID, event.Method, event.LatencyUs, event.OK, event.Err)
+}
@@ -0,0 +1,103 @@
+package pyproc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// fileHook writes each LogEvent as a JSON line to a file, rotating it once
+// it exceeds MaxSizeMB by renaming it to a numbered backup (path.1, path.2,
+// ...), keeping at most MaxBackups of them.
+type fileHook struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func newFileHook(cfg HookConfig) (*fileHook, error) {
+	if cfg.FilePath == "" {
+		return nil, fmt.Errorf("file log hook requires FilePath")
+	}
+	maxSizeMB := cfg.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = 100
+	}
+	maxBackups := cfg.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = 3
+	}
+
+	h := &fileHook{
+		path:       cfg.FilePath,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+	if err := h.openCurrent(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *fileHook) openCurrent() error {
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", h.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat log file %s: %w", h.path, err)
+	}
+	h.file = f
+	h.size = info.Size()
+	return nil
+}
+
+// Handle appends event as a JSON line, rotating first if it would push the
+// file past MaxSizeMB. Errors are swallowed - a logging sink must never
+// cause the request it's describing to fail.
+func (h *fileHook) Handle(event LogEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.size+int64(len(data)) > h.maxSize {
+		h.rotate()
+	}
+	if h.file == nil {
+		return
+	}
+	n, err := h.file.Write(data)
+	if err == nil {
+		h.size += int64(n)
+	}
+}
+
+// rotate renames the current file through path.1..path.maxBackups-1 ->
+// path.2..path.maxBackups, dropping the oldest, then reopens path fresh.
+// Callers must hold h.mu.
+func (h *fileHook) rotate() {
+	_ = h.file.Close()
+
+	for i := h.maxBackups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", h.path, i)
+		dst := fmt.Sprintf("%s.%d", h.path, i+1)
+		_ = os.Rename(src, dst)
+	}
+	_ = os.Rename(h.path, fmt.Sprintf("%s.1", h.path))
+
+	if err := h.openCurrent(); err != nil {
+		h.file = nil
+		h.size = 0
+	}
+}
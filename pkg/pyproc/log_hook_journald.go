@@ -0,0 +1,58 @@
+//go:build linux
+
+package pyproc
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// journaldSocketPath is systemd's well-known journal datagram socket.
+const journaldSocketPath = "/run/systemd/journal/socket"
+
+// journaldHook sends each LogEvent to the systemd journal as a datagram of
+// newline-separated KEY=VALUE fields - the simple (non binary-safe) variant
+// of the native journal protocol, sufficient since none of LogEvent's
+// fields can contain a newline.
+type journaldHook struct {
+	conn net.Conn
+}
+
+func newJournaldHook(cfg HookConfig) (*journaldHook, error) {
+	conn, err := net.Dial("unixgram", journaldSocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial journald socket: %w", err)
+	}
+	return &journaldHook{conn: conn}, nil
+}
+
+// Handle sends event to the journal. Errors are swallowed - a logging sink
+// must never cause the request it's describing to fail.
+func (h *journaldHook) Handle(event LogEvent) {
+	fields := []string{
+		"MESSAGE=" + eventLine(event),
+		"PRIORITY=" + journaldPriority(event.Level),
+		"WORKER_ID=" + event.WorkerID,
+		fmt.Sprintf("REQ_ID=%d", event.RequestID),
+		"METHOD=" + event.Method,
+		fmt.Sprintf("LATENCY_US=%d", event.LatencyUs),
+		fmt.Sprintf("OK=%t", event.OK),
+	}
+	_, _ = h.conn.Write([]byte(strings.Join(fields, "\n") + "\n"))
+}
+
+// journaldPriority maps a Logger level to the syslog priority journald
+// expects in its PRIORITY field.
+func journaldPriority(level string) string {
+	switch level {
+	case "error":
+		return "3"
+	case "warn":
+		return "4"
+	case "debug":
+		return "7"
+	default:
+		return "6" // info
+	}
+}
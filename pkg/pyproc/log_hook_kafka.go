@@ -0,0 +1,25 @@
+package pyproc
+
+import "fmt"
+
+// kafkaHook forwards each LogEvent to cfg.Publisher, e.g. a Kafka or NATS
+// producer, for structured off-box event streaming. pyproc has no client
+// dependency on either - the caller supplies an EventPublisher wrapping
+// whichever one they use.
+type kafkaHook struct {
+	topic     string
+	publisher EventPublisher
+}
+
+func newKafkaHook(cfg HookConfig) (*kafkaHook, error) {
+	if cfg.Publisher == nil {
+		return nil, fmt.Errorf("kafka log hook requires Publisher")
+	}
+	return &kafkaHook{topic: cfg.Topic, publisher: cfg.Publisher}, nil
+}
+
+// Handle publishes event to topic. Errors are swallowed - a logging sink
+// must never cause the request it's describing to fail.
+func (h *kafkaHook) Handle(event LogEvent) {
+	_ = h.publisher.Publish(h.topic, event)
+}
@@ -0,0 +1,77 @@
+//go:build !windows
+
+package pyproc
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// syslogHook writes each LogEvent as a single syslog line, at a priority
+// derived from event.Level, through the standard library's syslog writer.
+type syslogHook struct {
+	writer *syslog.Writer
+}
+
+func newSyslogHook(cfg HookConfig) (*syslogHook, error) {
+	facility, err := parseSyslogFacility(cfg.SyslogFacility)
+	if err != nil {
+		return nil, err
+	}
+	tag := cfg.SyslogTag
+	if tag == "" {
+		tag = "pyproc"
+	}
+
+	w, err := syslog.Dial(cfg.SyslogNetwork, cfg.SyslogAddress, facility|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial syslog: %w", err)
+	}
+	return &syslogHook{writer: w}, nil
+}
+
+// Handle writes event at the syslog priority matching its level. Errors are
+// swallowed - a logging sink must never cause the request it's describing
+// to fail.
+func (h *syslogHook) Handle(event LogEvent) {
+	line := eventLine(event)
+	switch event.Level {
+	case "error":
+		_ = h.writer.Err(line)
+	case "warn":
+		_ = h.writer.Warning(line)
+	case "debug":
+		_ = h.writer.Debug(line)
+	default:
+		_ = h.writer.Info(line)
+	}
+}
+
+// parseSyslogFacility maps a facility name (as used in syslog.conf) to its
+// syslog.Priority value. Empty defaults to LOG_USER.
+func parseSyslogFacility(name string) (syslog.Priority, error) {
+	switch name {
+	case "", "user":
+		return syslog.LOG_USER, nil
+	case "daemon":
+		return syslog.LOG_DAEMON, nil
+	case "local0":
+		return syslog.LOG_LOCAL0, nil
+	case "local1":
+		return syslog.LOG_LOCAL1, nil
+	case "local2":
+		return syslog.LOG_LOCAL2, nil
+	case "local3":
+		return syslog.LOG_LOCAL3, nil
+	case "local4":
+		return syslog.LOG_LOCAL4, nil
+	case "local5":
+		return syslog.LOG_LOCAL5, nil
+	case "local6":
+		return syslog.LOG_LOCAL6, nil
+	case "local7":
+		return syslog.LOG_LOCAL7, nil
+	default:
+		return 0, fmt.Errorf("unknown syslog facility: %s", name)
+	}
+}
@@ -2,8 +2,10 @@ package pyproc
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
+	"sync"
 	"sync/atomic"
 )
 
@@ -17,26 +19,136 @@ var traceIDCounter atomic.Uint64
 type Logger struct {
 	*slog.Logger
 	traceEnabled bool
+	level        *slog.LevelVar
+	hooks        []LogHook
 }
 
-// NewLogger creates a new logger with the specified configuration
+// NewLogger creates a new logger with the specified configuration. Each
+// entry in cfg.Hooks is built via NewHook; a hook that fails to build (e.g.
+// a syslog dial failure) is dropped after an error is logged rather than failing
+// NewLogger outright, so a misconfigured sink can't take down the process
+// whose requests it was meant to observe.
 func NewLogger(cfg LoggingConfig) *Logger {
 	var handler slog.Handler
-	
+
+	level := &slog.LevelVar{}
+	level.Set(parseLogLevel(cfg.Level))
 	opts := &slog.HandlerOptions{
-		Level: parseLogLevel(cfg.Level),
+		Level: level,
 	}
-	
+
 	switch cfg.Format {
 	case "json":
 		handler = slog.NewJSONHandler(os.Stdout, opts)
 	default:
 		handler = slog.NewTextHandler(os.Stdout, opts)
 	}
-	
+
+	slogger := slog.New(handler)
+
+	var hooks []LogHook
+	for _, hookCfg := range cfg.Hooks {
+		hook, err := NewHook(hookCfg)
+		if err != nil {
+			slogger.Error("failed to build log hook", "type", hookCfg.Type, "error", err)
+			continue
+		}
+		hooks = append(hooks, hook)
+	}
+
 	return &Logger{
-		Logger:       slog.New(handler),
+		Logger:       slogger,
 		traceEnabled: cfg.TraceEnabled,
+		level:        level,
+		hooks:        hooks,
+	}
+}
+
+// NoopLogger returns a Logger that discards everything, including hooks -
+// for benchmarks and tests that need a Logger but not its output.
+func NoopLogger() *Logger {
+	level := &slog.LevelVar{}
+	level.Set(slog.LevelError + 1) // above any real level: nothing is ever emitted
+	return &Logger{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: level})),
+		level:  level,
+	}
+}
+
+// TestLogger is a Logger whose EmitEvent calls are also captured for
+// assertion, e.g. in TestMultiplexedTransport-style tests that want to
+// check a request actually got logged without scraping text output.
+type TestLogger struct {
+	*Logger
+	mu     sync.Mutex
+	events []LogEvent
+}
+
+// NewTestLogger returns a TestLogger at level (default "debug") that
+// records every EmitEvent call for Events to inspect.
+func NewTestLogger(level string) *TestLogger {
+	if level == "" {
+		level = "debug"
+	}
+	tl := &TestLogger{Logger: NewLogger(LoggingConfig{Level: level})}
+	tl.Logger.hooks = append(tl.Logger.hooks, tl)
+	return tl
+}
+
+// Handle implements LogHook by recording event.
+func (tl *TestLogger) Handle(event LogEvent) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	tl.events = append(tl.events, event)
+}
+
+// Events returns a copy of every LogEvent recorded so far.
+func (tl *TestLogger) Events() []LogEvent {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	out := make([]LogEvent, len(tl.events))
+	copy(out, tl.events)
+	return out
+}
+
+// EmitEvent runs event through every configured hook (request start/end,
+// worker restart, pool health, ...) and writes a matching line through the
+// Logger's own handler at event's level. Safe to call with no hooks
+// configured - it's then equivalent to a single leveled log line.
+func (l *Logger) EmitEvent(event LogEvent) {
+	for _, hook := range l.hooks {
+		hook.Handle(event)
+	}
+
+	args := []any{
+		"worker_id", event.WorkerID,
+		"req_id", event.RequestID,
+		"method", event.Method,
+		"latency_us", event.LatencyUs,
+		"ok", event.OK,
+	}
+	if event.Err != "" {
+		args = append(args, "error", event.Err)
+	}
+	switch event.Level {
+	case "error":
+		l.Logger.Error("request", args...)
+	case "warn":
+		l.Logger.Warn("request", args...)
+	case "debug":
+		l.Logger.Debug("request", args...)
+	default:
+		l.Logger.Info("request", args...)
+	}
+}
+
+// SetLevel changes the logger's minimum level at runtime (e.g. in response
+// to a hot-reloaded logging.level config change). Loggers derived via
+// WithWorker/WithMethod share the same *slog.LevelVar, so they see the
+// change too.
+func (l *Logger) SetLevel(level string) {
+	if l.level != nil {
+		l.level.Set(parseLogLevel(level))
 	}
 }
 
@@ -52,12 +164,25 @@ func GetTraceID(ctx context.Context) (uint64, bool) {
 	return id, ok
 }
 
+// traceArgs returns the slog args a *Context method should prepend, so logs
+// correlate with whichever tracing a caller's ctx actually carries: a real
+// TraceContext (see trace.go) if one was attached - e.g. by
+// UDSTransport.Call - identified by trace_id/span_id, falling back to the
+// homegrown counter-based WithTraceID/GetTraceID otherwise.
+func traceArgs(ctx context.Context) []any {
+	if tc, ok := TraceContextFromContext(ctx); ok {
+		return []any{"trace_id", tc.TraceIDHex(), "span_id", tc.SpanIDHex()}
+	}
+	if traceID, ok := GetTraceID(ctx); ok {
+		return []any{"trace_id", traceID}
+	}
+	return nil
+}
+
 // InfoContext logs an info message with trace ID if enabled
 func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
 	if l.traceEnabled {
-		if traceID, ok := GetTraceID(ctx); ok {
-			args = append([]any{"trace_id", traceID}, args...)
-		}
+		args = append(traceArgs(ctx), args...)
 	}
 	l.Logger.InfoContext(ctx, msg, args...)
 }
@@ -65,9 +190,7 @@ func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
 // ErrorContext logs an error message with trace ID if enabled
 func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
 	if l.traceEnabled {
-		if traceID, ok := GetTraceID(ctx); ok {
-			args = append([]any{"trace_id", traceID}, args...)
-		}
+		args = append(traceArgs(ctx), args...)
 	}
 	l.Logger.ErrorContext(ctx, msg, args...)
 }
@@ -75,9 +198,7 @@ func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
 // DebugContext logs a debug message with trace ID if enabled
 func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
 	if l.traceEnabled {
-		if traceID, ok := GetTraceID(ctx); ok {
-			args = append([]any{"trace_id", traceID}, args...)
-		}
+		args = append(traceArgs(ctx), args...)
 	}
 	l.Logger.DebugContext(ctx, msg, args...)
 }
@@ -85,9 +206,7 @@ func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
 // WarnContext logs a warning message with trace ID if enabled
 func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
 	if l.traceEnabled {
-		if traceID, ok := GetTraceID(ctx); ok {
-			args = append([]any{"trace_id", traceID}, args...)
-		}
+		args = append(traceArgs(ctx), args...)
 	}
 	l.Logger.WarnContext(ctx, msg, args...)
 }
@@ -97,6 +216,8 @@ func (l *Logger) WithWorker(workerID string) *Logger {
 	return &Logger{
 		Logger:       l.Logger.With("worker_id", workerID),
 		traceEnabled: l.traceEnabled,
+		level:        l.level,
+		hooks:        l.hooks,
 	}
 }
 
@@ -105,6 +226,8 @@ func (l *Logger) WithMethod(method string) *Logger {
 	return &Logger{
 		Logger:       l.Logger.With("method", method),
 		traceEnabled: l.traceEnabled,
+		level:        l.level,
+		hooks:        l.hooks,
 	}
 }
 
@@ -0,0 +1,114 @@
+// Package metricsprom exposes pyproc.MetricsSnapshot to Prometheus, either
+// as a prometheus.Collector for users who already depend on the official
+// client library, or through Handler's zero-dependency net/http.Handler for
+// users who don't want that dependency. Both read the same MetricsSource on
+// every scrape; neither holds its own state.
+package metricsprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc"
+)
+
+// MetricsSource is satisfied by *pyproc.PoolWithMetrics.
+type MetricsSource interface {
+	GetMetrics() pyproc.MetricsSnapshot
+}
+
+// Collector adapts a MetricsSource to prometheus.Collector. Register it with
+// a prometheus.Registry the normal way:
+//
+//	prometheus.MustRegister(metricsprom.NewCollector(pool))
+type Collector struct {
+	source MetricsSource
+
+	requestsTotal          *prometheus.Desc
+	poolUtilization        *prometheus.Desc
+	queueDepth             *prometheus.Desc
+	workerRestartsTotal    *prometheus.Desc
+	workerFailuresTotal    *prometheus.Desc
+	reconnectAttemptsTotal *prometheus.Desc
+	requestLatencySeconds  *prometheus.Desc
+}
+
+// NewCollector builds a Collector that reads source on every Collect.
+func NewCollector(source MetricsSource) *Collector {
+	return &Collector{
+		source: source,
+		requestsTotal: prometheus.NewDesc(
+			"pyproc_requests_total",
+			"Total pool requests, by outcome.",
+			[]string{"status"}, nil,
+		),
+		poolUtilization: prometheus.NewDesc(
+			"pyproc_pool_utilization",
+			"Fraction of pooled connections currently active (0-1).",
+			nil, nil,
+		),
+		queueDepth: prometheus.NewDesc(
+			"pyproc_queue_depth",
+			"Number of calls currently queued or in flight.",
+			nil, nil,
+		),
+		workerRestartsTotal: prometheus.NewDesc(
+			"pyproc_worker_restarts_total",
+			"Total successful worker restarts.",
+			nil, nil,
+		),
+		workerFailuresTotal: prometheus.NewDesc(
+			"pyproc_worker_failures_total",
+			"Total worker restart attempts that gave up or failed.",
+			nil, nil,
+		),
+		reconnectAttemptsTotal: prometheus.NewDesc(
+			"pyproc_reconnect_attempts_total",
+			"Total worker restart/reconnect attempts, successful or not.",
+			nil, nil,
+		),
+		requestLatencySeconds: prometheus.NewDesc(
+			"pyproc_request_latency_seconds",
+			"Pool call latency distribution (p50/p95/p99, as tracked by PoolMetrics).",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.requestsTotal
+	ch <- c.poolUtilization
+	ch <- c.queueDepth
+	ch <- c.workerRestartsTotal
+	ch <- c.workerFailuresTotal
+	ch <- c.reconnectAttemptsTotal
+	ch <- c.requestLatencySeconds
+}
+
+// Collect implements prometheus.Collector, pulling a fresh snapshot from the
+// MetricsSource on every scrape.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	snap := c.source.GetMetrics()
+
+	ch <- prometheus.MustNewConstMetric(c.requestsTotal, prometheus.CounterValue, float64(snap.RequestsSucceeded), "ok")
+	ch <- prometheus.MustNewConstMetric(c.requestsTotal, prometheus.CounterValue, float64(snap.RequestsFailed), "failed")
+	ch <- prometheus.MustNewConstMetric(c.requestsTotal, prometheus.CounterValue, float64(snap.RequestsTimeout), "timeout")
+
+	ch <- prometheus.MustNewConstMetric(c.poolUtilization, prometheus.GaugeValue, snap.PoolUtilization)
+	ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(snap.QueueDepth))
+	ch <- prometheus.MustNewConstMetric(c.workerRestartsTotal, prometheus.CounterValue, float64(snap.WorkerRestarts))
+	ch <- prometheus.MustNewConstMetric(c.workerFailuresTotal, prometheus.CounterValue, float64(snap.WorkerFailures))
+	ch <- prometheus.MustNewConstMetric(c.reconnectAttemptsTotal, prometheus.CounterValue, float64(snap.ReconnectAttempts))
+
+	// PoolMetrics only tracks p50/p95/p99, not a running sum, so this is
+	// exposed as a summary (quantiles) rather than a true histogram (fixed
+	// buckets) - there's no bucket data to report. Sum is approximated as
+	// count*p50 since there's no cumulative latency total to report exactly.
+	count := snap.RequestsSucceeded + snap.RequestsFailed + snap.RequestsTimeout
+	sum := float64(count) * snap.LatencyP50.Seconds()
+	ch <- prometheus.MustNewConstSummary(c.requestLatencySeconds, count, sum, map[float64]float64{
+		0.5:  snap.LatencyP50.Seconds(),
+		0.95: snap.LatencyP95.Seconds(),
+		0.99: snap.LatencyP99.Seconds(),
+	})
+}
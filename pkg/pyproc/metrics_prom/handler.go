@@ -0,0 +1,77 @@
+package metricsprom
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc"
+)
+
+// OpenMetricsContentType is the Content-Type Handler serves, per the
+// OpenMetrics exposition format spec.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Handler returns an http.Handler that serves source's current
+// MetricsSnapshot in OpenMetrics text format, with no dependency on the
+// Prometheus client library - an alternative to registering Collector with
+// a prometheus.Registry for callers who don't want that dependency.
+func Handler(source MetricsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", OpenMetricsContentType)
+		writeOpenMetrics(w, source.GetMetrics())
+	})
+}
+
+func writeOpenMetrics(w io.Writer, snap pyproc.MetricsSnapshot) {
+	counter(w, "pyproc_requests_total", "Total pool requests, by outcome.", []labeledValue{
+		{labels: `status="ok"`, value: float64(snap.RequestsSucceeded)},
+		{labels: `status="failed"`, value: float64(snap.RequestsFailed)},
+		{labels: `status="timeout"`, value: float64(snap.RequestsTimeout)},
+	})
+
+	gauge(w, "pyproc_pool_utilization", "Fraction of pooled connections currently active (0-1).", snap.PoolUtilization)
+	gauge(w, "pyproc_queue_depth", "Number of calls currently queued or in flight.", float64(snap.QueueDepth))
+	counter(w, "pyproc_worker_restarts_total", "Total successful worker restarts.", []labeledValue{{value: float64(snap.WorkerRestarts)}})
+	counter(w, "pyproc_worker_failures_total", "Total worker restart attempts that gave up or failed.", []labeledValue{{value: float64(snap.WorkerFailures)}})
+	counter(w, "pyproc_reconnect_attempts_total", "Total worker restart/reconnect attempts, successful or not.", []labeledValue{{value: float64(snap.ReconnectAttempts)}})
+
+	count := snap.RequestsSucceeded + snap.RequestsFailed + snap.RequestsTimeout
+	sum := float64(count) * snap.LatencyP50.Seconds()
+	fmt.Fprintf(w, "# TYPE pyproc_request_latency_seconds summary\n")
+	fmt.Fprintf(w, "# HELP pyproc_request_latency_seconds Pool call latency distribution (p50/p95/p99, as tracked by PoolMetrics).\n")
+	fmt.Fprintf(w, "pyproc_request_latency_seconds{quantile=\"0.5\"} %g\n", snap.LatencyP50.Seconds())
+	fmt.Fprintf(w, "pyproc_request_latency_seconds{quantile=\"0.95\"} %g\n", snap.LatencyP95.Seconds())
+	fmt.Fprintf(w, "pyproc_request_latency_seconds{quantile=\"0.99\"} %g\n", snap.LatencyP99.Seconds())
+	fmt.Fprintf(w, "pyproc_request_latency_seconds_sum %g\n", sum)
+	fmt.Fprintf(w, "pyproc_request_latency_seconds_count %d\n", count)
+
+	fmt.Fprint(w, "# EOF\n")
+}
+
+type labeledValue struct {
+	labels string // e.g. `status="ok"`, empty for an unlabeled series
+	value  float64
+}
+
+func counter(w io.Writer, name, help string, values []labeledValue) {
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	for _, v := range values {
+		writeSample(w, name, v)
+	}
+}
+
+func gauge(w io.Writer, name, help string, value float64) {
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	writeSample(w, name, labeledValue{value: value})
+}
+
+func writeSample(w io.Writer, name string, v labeledValue) {
+	if v.labels == "" {
+		fmt.Fprintf(w, "%s %g\n", name, v.value)
+		return
+	}
+	fmt.Fprintf(w, "%s{%s} %g\n", name, v.labels, v.value)
+}
@@ -0,0 +1,85 @@
+package metricsprom
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc"
+)
+
+type fakeSource struct {
+	snap pyproc.MetricsSnapshot
+}
+
+func (f fakeSource) GetMetrics() pyproc.MetricsSnapshot {
+	return f.snap
+}
+
+func TestHandlerServesOpenMetrics(t *testing.T) {
+	src := fakeSource{snap: pyproc.MetricsSnapshot{
+		RequestsSucceeded: 10,
+		RequestsFailed:    2,
+		RequestsTimeout:   1,
+		PoolUtilization:   0.75,
+		QueueDepth:        3,
+		WorkerRestarts:    4,
+		WorkerFailures:    1,
+		ReconnectAttempts: 5,
+		LatencyP50:        10 * time.Millisecond,
+		LatencyP95:        50 * time.Millisecond,
+		LatencyP99:        100 * time.Millisecond,
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	Handler(src).ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	if ct := resp.Header.Get("Content-Type"); ct != OpenMetricsContentType {
+		t.Errorf("unexpected Content-Type: %s", ct)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		`pyproc_requests_total{status="ok"} 10`,
+		`pyproc_requests_total{status="failed"} 2`,
+		`pyproc_requests_total{status="timeout"} 1`,
+		"pyproc_pool_utilization 0.75",
+		"pyproc_queue_depth 3",
+		"pyproc_worker_restarts_total 4",
+		"pyproc_worker_failures_total 1",
+		"pyproc_reconnect_attempts_total 5",
+		`pyproc_request_latency_seconds{quantile="0.5"} 0.01`,
+		`pyproc_request_latency_seconds{quantile="0.95"} 0.05`,
+		`pyproc_request_latency_seconds{quantile="0.99"} 0.1`,
+		"pyproc_request_latency_seconds_count 13",
+		"# EOF",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandlerEndsWithEOFMarker(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	Handler(fakeSource{}).ServeHTTP(rec, req)
+
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if !strings.HasSuffix(strings.TrimRight(string(body), "\n"), "# EOF") {
+		t.Errorf("expected body to end with the OpenMetrics EOF marker, got:\n%s", body)
+	}
+}
@@ -5,29 +5,79 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/YuminosukeSato/pyproc/internal/framing"
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
+	pyprocfs "github.com/YuminosukeSato/pyproc/pkg/pyproc/fs"
 )
 
 // PoolOptions provides additional options for creating a pool
 type PoolOptions struct {
 	Config       PoolConfig   // Base pool configuration
 	WorkerConfig WorkerConfig // Configuration for each worker
+
+	// BackoffStrategy overrides the strategy derived from Config.Restart.Strategy.
+	// Set via WithBackoffStrategy; nil means "build from Config.Restart".
+	BackoffStrategy BackoffStrategy
+
+	// Balancer selects which worker handles each Pool.Call. Nil defaults to
+	// NewLeastInFlightBalancer().
+	Balancer Balancer
+
+	// TransportPool configures the LoadBalancer used by PoolWithTransport's
+	// underlying TransportPool. Zero value defaults to round-robin.
+	TransportPool TransportPoolOptions
+
+	// Reattach, if non-empty, replaces spawning Config.Workers children:
+	// the pool instead connects to these already-running, externally
+	// managed workers (e.g. `python worker.py` started by hand under a
+	// debugger), keyed by worker ID. See ReattachConfig and
+	// ReattachSpecsFromEnv/PYPROC_REATTACH.
+	Reattach map[string]*ReattachConfig
+
+	// WorkerEvents, if non-nil, receives every WorkerEvent emitted by the
+	// pool's per-worker Supervisors - crashes, restarts, and degraded
+	// transitions - for callers that want more detail than PoolMetrics'
+	// counters or HealthStatus' counts provide. Sends never block.
+	WorkerEvents chan<- WorkerEvent
+
+	// Codec overrides the Codec each worker's MultiplexedConn uses to
+	// encode/decode the protocol.Message envelope (see WithCodec). Nil
+	// defaults to JSON, Pool's behavior before WithCodec existed.
+	Codec Codec
+}
+
+// WithCodec returns a copy of opts with Codec set, so every worker's
+// MultiplexedConn encodes/decodes its Message envelope with codec instead of
+// the default JSONCodec. All workers in a pool share one codec - there's no
+// per-call override, since the Python worker on the other end is configured
+// the same way ahead of time (see framing.NegotiationRequest.Codecs for the
+// per-connection alternative once a caller is using ConnectToWorkerNegotiated).
+func WithCodec(opts PoolOptions, codec Codec) PoolOptions {
+	opts.Codec = codec
+	return opts
+}
+
+// WithBackoffStrategy returns a copy of opts with a programmatic BackoffStrategy
+// override, bypassing the Config.Restart.Strategy/InitialBackoff/MaxBackoff fields.
+func WithBackoffStrategy(opts PoolOptions, strategy BackoffStrategy) PoolOptions {
+	opts.BackoffStrategy = strategy
+	return opts
 }
 
 // Pool manages multiple Python workers with load balancing
 type Pool struct {
-	opts     PoolOptions
-	logger   *Logger
-	workers  []*poolWorker
-	nextIdx  atomic.Uint64
-	shutdown atomic.Bool
-	wg       sync.WaitGroup
+	opts      PoolOptions
+	logger    *Logger
+	workersMu sync.RWMutex // guards workers, so Resize can run concurrently with Call
+	workers   []*poolWorker
+	shutdown  atomic.Bool
+	wg        sync.WaitGroup
 
 	// Backpressure control
 	semaphore chan struct{}
@@ -36,14 +86,93 @@ type Pool struct {
 	healthMu     sync.RWMutex
 	healthStatus HealthStatus
 	healthCancel context.CancelFunc
+
+	// Restart supervision
+	restartCtx    context.Context
+	restartCancel context.CancelFunc
+
+	// requestTimeout overrides protocol.request_timeout for future calls
+	// whose ctx carries no deadline of its own; updated by ApplyConfigChange.
+	requestTimeout atomic.Int64 // time.Duration, 0 = no default
+
+	// onMetricsToggle is invoked when a hot-reloaded metrics.enabled change
+	// arrives. Metrics server lifecycle lives outside Pool; this just routes
+	// the signal to whoever registered with SetMetricsToggle.
+	onMetricsToggle func(enabled bool)
+
+	// callbacks holds Go handlers registered via Register, shared by every
+	// worker's MultiplexedConn so a Python worker can call back into Go over
+	// the same socket it was called on.
+	callbacks *callbackRegistry
+
+	// balancer picks which worker handles each Call.
+	balancer Balancer
+
+	// metrics tracks restart/reconnect counters (and, when wrapped by
+	// PoolWithMetrics, request/latency counters too). Always non-nil.
+	metrics *PoolMetrics
 }
 
-// poolWorker wraps a Worker with connection pooling
+// Metrics returns the pool's metrics tracker, e.g. for operators who want
+// restart/reconnect counters without wrapping the pool in PoolWithMetrics.
+func (p *Pool) Metrics() *PoolMetrics {
+	return p.metrics
+}
+
+// Size returns the current number of workers in the pool. It reflects the
+// latest Resize, if any, since Resize runs concurrently with Call.
+func (p *Pool) Size() int {
+	p.workersMu.RLock()
+	defer p.workersMu.RUnlock()
+	return len(p.workers)
+}
+
+// CallbackHandler answers a pyproc.call_go(method, payload) request issued by
+// a Python worker. payload is the request body as raw JSON; the returned
+// value is marshalled as the response body, same as a Pool.Call result.
+type CallbackHandler func(ctx context.Context, payload json.RawMessage) (interface{}, error)
+
+// Register installs handler as the Go-side implementation of method, callable
+// from any worker in the pool via pyproc.call_go(method, payload). Registering
+// the same method twice replaces the previous handler. Register may be called
+// before or after Start.
+func (p *Pool) Register(method string, handler CallbackHandler) {
+	p.callbacks.register(method, handler)
+}
+
+// poolWorker wraps a Worker with a multiplexed connection
 type poolWorker struct {
-	worker    *Worker
-	connPool  chan net.Conn
-	requestID atomic.Uint64
-	healthy   atomic.Bool
+	worker          *Worker
+	mconn           *MultiplexedConn
+	healthy         atomic.Bool
+	backoff         BackoffStrategy
+	restartAttempts atomic.Int32
+	supervisor      *Supervisor
+
+	// inFlight counts calls currently assigned to this worker; maintained by
+	// whichever Balancer is in use (load-aware balancers increment/decrement
+	// it, others ignore it).
+	inFlight atomic.Int64
+
+	// draining is set by Shutdown before it waits for this worker's
+	// in-flight calls to finish; healthyWorkers excludes it from
+	// consideration so new calls go to a sibling worker instead, while
+	// calls already assigned here keep running to completion.
+	draining atomic.Bool
+}
+
+// Draining reports whether the pool has begun shutting this worker down. A
+// draining worker finishes whatever calls it already has in flight but is
+// never picked for a new one.
+func (pw *poolWorker) Draining() bool {
+	return pw.draining.Load()
+}
+
+// Degraded reports whether this worker's Supervisor has given up restarting
+// it after too many restarts in too short a window. A degraded worker stays
+// down until the pool is recreated. See SupervisorConfig.
+func (pw *poolWorker) Degraded() bool {
+	return pw.supervisor != nil && pw.supervisor.Degraded()
 }
 
 // HealthStatus represents the health of the pool
@@ -51,11 +180,33 @@ type HealthStatus struct {
 	TotalWorkers   int
 	HealthyWorkers int
 	LastCheck      time.Time
+
+	// Open and HalfOpen count transports whose CircuitBreakerTransport is
+	// currently in that state. Always 0 for Pool (which doesn't use
+	// Transport/CircuitBreakerTransport); populated by PoolWithTransport.
+	Open     int
+	HalfOpen int
+
+	// DrainingWorkers counts workers Shutdown has begun tearing down but
+	// whose in-flight calls haven't finished yet. They're excluded from
+	// HealthyWorkers even though their connection is still serving calls.
+	DrainingWorkers int
+
+	// DegradedWorkers counts workers whose Supervisor has tripped its
+	// restart-rate circuit breaker and stopped restarting them. They're
+	// excluded from HealthyWorkers and stay down until the pool is recreated.
+	DegradedWorkers int
 }
 
-// NewPool creates a new worker pool
+// NewPool creates a new worker pool. If opts.Reattach is non-empty, it
+// connects to those externally managed workers instead of spawning
+// opts.Config.Workers children.
 func NewPool(opts PoolOptions, logger *Logger) (*Pool, error) {
-	if opts.Config.Workers <= 0 {
+	numWorkers := opts.Config.Workers
+	if len(opts.Reattach) > 0 {
+		numWorkers = len(opts.Reattach)
+	}
+	if numWorkers <= 0 {
 		return nil, errors.New("workers must be > 0")
 	}
 	if opts.Config.MaxInFlight <= 0 {
@@ -69,32 +220,94 @@ func NewPool(opts PoolOptions, logger *Logger) (*Pool, error) {
 		logger = NewLogger(LoggingConfig{Level: "info", Format: "json"})
 	}
 
+	balancer := opts.Balancer
+	if balancer == nil {
+		balancer = NewLeastInFlightBalancer()
+	}
+
 	pool := &Pool{
 		opts:      opts,
 		logger:    logger,
-		workers:   make([]*poolWorker, opts.Config.Workers),
-		semaphore: make(chan struct{}, opts.Config.Workers*opts.Config.MaxInFlight),
+		workers:   make([]*poolWorker, numWorkers),
+		semaphore: make(chan struct{}, numWorkers*opts.Config.MaxInFlight),
+		callbacks: newCallbackRegistry(opts.Config.CallbackConcurrency),
+		balancer:  balancer,
+		metrics:   NewPoolMetrics(),
+	}
+
+	if len(opts.Reattach) > 0 {
+		ids := make([]string, 0, len(opts.Reattach))
+		for id := range opts.Reattach {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for i, id := range ids {
+			workerCfg := opts.WorkerConfig
+			workerCfg.ID = id
+			workerCfg.Reattach = opts.Reattach[id]
+			workerCfg.SocketPath = workerCfg.Reattach.SocketPath
+			if workerCfg.StartTimeout == 0 {
+				workerCfg.StartTimeout = 5 * time.Second
+			}
+			if workerCfg.LameDuckTimeout == 0 {
+				workerCfg.LameDuckTimeout = opts.Config.LameDuckTimeout
+			}
+
+			worker := NewWorker(workerCfg, logger)
+			pool.workers[i] = &poolWorker{
+				worker:     worker,
+				backoff:    newPoolBackoffStrategy(opts),
+				supervisor: newPoolSupervisor(opts, workerCfg.ID),
+			}
+		}
+
+		return pool, nil
 	}
 
 	// Create workers
-	for i := 0; i < opts.Config.Workers; i++ {
+	for i := 0; i < numWorkers; i++ {
 		workerCfg := opts.WorkerConfig
 		workerCfg.ID = fmt.Sprintf("worker-%d", i)
 		workerCfg.SocketPath = fmt.Sprintf("%s-%d", opts.WorkerConfig.SocketPath, i)
 		if workerCfg.StartTimeout == 0 {
 			workerCfg.StartTimeout = 5 * time.Second
 		}
+		if workerCfg.LameDuckTimeout == 0 {
+			workerCfg.LameDuckTimeout = opts.Config.LameDuckTimeout
+		}
 
 		worker := NewWorker(workerCfg, logger)
 		pool.workers[i] = &poolWorker{
-			worker:   worker,
-			connPool: make(chan net.Conn, opts.Config.MaxInFlight),
+			worker:     worker,
+			backoff:    newPoolBackoffStrategy(opts),
+			supervisor: newPoolSupervisor(opts, workerCfg.ID),
 		}
 	}
 
 	return pool, nil
 }
 
+// newPoolBackoffStrategy builds a fresh BackoffStrategy for one worker. Each
+// worker gets its own instance (rather than sharing one) because
+// DecorrelatedJitterBackoff carries mutable state across calls.
+func newPoolBackoffStrategy(opts PoolOptions) BackoffStrategy {
+	if opts.BackoffStrategy != nil {
+		return opts.BackoffStrategy
+	}
+	return NewBackoffStrategy(BackoffStrategyType(opts.Config.Restart.Strategy), opts.Config.Restart)
+}
+
+// newPoolSupervisor builds the Supervisor for one worker, wiring up the
+// pool-wide restart-rate circuit breaker and event channel.
+func newPoolSupervisor(opts PoolOptions, workerID string) *Supervisor {
+	return NewSupervisor(workerID, SupervisorConfig{
+		MaxRestarts: opts.Config.MaxRestartsPerWindow,
+		Window:      opts.Config.RestartWindow,
+		Events:      opts.WorkerEvents,
+	})
+}
+
 // Start starts all workers in the pool
 func (p *Pool) Start(ctx context.Context) error {
 	p.logger.Info("starting worker pool", "workers", p.opts.Config.Workers)
@@ -110,19 +323,20 @@ func (p *Pool) Start(ctx context.Context) error {
 		}
 		pw.healthy.Store(true)
 
-		// Pre-populate connection pool
-		for j := 0; j < p.opts.Config.MaxInFlight; j++ {
-			conn, err := p.connect(pw.worker.cfg.SocketPath)
-			if err != nil {
-				p.logger.Warn("failed to pre-populate connection", "error", err)
-				break
-			}
-			select {
-			case pw.connPool <- conn:
-			default:
-				conn.Close()
+		var mconn *MultiplexedConn
+		var err error
+		if pw.worker.cfg.TransportMode == TransportModeSocketPair {
+			mconn, err = NewMultiplexedConnFromConn(pw.worker.Conn(), p.logger, p.callbacks, p.opts.Codec)
+		} else {
+			mconn, err = NewMultiplexedConn(pw.worker.cfg.SocketPath, p.logger, pw.backoff, p.callbacks, p.opts.Codec)
+		}
+		if err != nil {
+			for j := 0; j <= i; j++ {
+				_ = p.workers[j].worker.Stop()
 			}
+			return fmt.Errorf("failed to open multiplexed connection to worker %d: %w", i, err)
 		}
+		pw.mconn = mconn
 	}
 
 	// Start health monitoring
@@ -131,91 +345,222 @@ func (p *Pool) Start(ctx context.Context) error {
 	p.wg.Add(1)
 	go p.healthMonitor(healthCtx)
 
+	// Start restart supervision, one goroutine per worker
+	restartCtx, restartCancel := context.WithCancel(context.Background())
+	p.restartCtx = restartCtx
+	p.restartCancel = restartCancel
+	for _, pw := range p.workers {
+		p.wg.Add(1)
+		go p.superviseRestart(restartCtx, pw)
+	}
+
 	p.updateHealthStatus()
 	p.logger.Info("worker pool started successfully")
 	return nil
 }
 
-// Call invokes a method on one of the workers using round-robin
-func (p *Pool) Call(ctx context.Context, method string, input interface{}, output interface{}) error {
-	if p.shutdown.Load() {
-		return errors.New("pool is shut down")
-	}
+// superviseRestart watches a worker for an unexpected exit and restarts it
+// using the pool's BackoffStrategy, giving up after Config.Restart.MaxAttempts
+// or after pw.supervisor's restart-rate circuit breaker trips. Worker.monitor
+// already detects the exit itself the instant cmd.Wait returns; this ticker
+// only needs to notice the resulting state change and react, not race a
+// SIGCHLD signal of its own.
+func (p *Pool) superviseRestart(ctx context.Context, pw *poolWorker) {
+	defer p.wg.Done()
 
-	// Acquire semaphore for backpressure
-	select {
-	case p.semaphore <- struct{}{}:
-		defer func() { <-p.semaphore }()
-	case <-ctx.Done():
-		return ctx.Err()
-	}
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
 
-	// Select worker using round-robin
-	idx := p.nextIdx.Add(1) - 1
-	pw := p.workers[idx%uint64(len(p.workers))]
+	wasRunning := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stopped := pw.worker.GetState() == WorkerStateStopped
+			if p.shutdown.Load() || !stopped {
+				wasRunning = !stopped
+				continue
+			}
+			if wasRunning {
+				// Mark unhealthy the moment the exit is noticed, not only once
+				// a restart attempt actually begins below - the balancer must
+				// stop routing new calls here well before NextDelay's backoff
+				// elapses, so Pool.CallIdempotent's retry lands on a sibling
+				// worker instead of the one that just died.
+				pw.healthy.Store(false)
+				pw.supervisor.RecordExit(pw.worker.ExitError())
+				wasRunning = false
+			}
 
-	if !pw.healthy.Load() {
-		// Try to find a healthy worker
-		for _, w := range p.workers {
-			if w.healthy.Load() {
-				pw = w
-				break
+			if pw.supervisor.Degraded() {
+				continue
 			}
-		}
-		if !pw.healthy.Load() {
-			return errors.New("no healthy workers available")
+
+			attempts := int(pw.restartAttempts.Load())
+			maxAttempts := p.opts.Config.Restart.MaxAttempts
+			if maxAttempts > 0 && attempts >= maxAttempts {
+				p.logger.Error("worker exceeded max restart attempts, giving up",
+					"worker_id", pw.worker.GetID(), "attempts", attempts)
+				p.metrics.WorkerFailures.Add(1)
+				continue
+			}
+			if !pw.supervisor.AllowRestart(time.Now()) {
+				p.logger.Error("worker exceeded restart-rate limit, marking degraded",
+					"worker_id", pw.worker.GetID())
+				pw.healthy.Store(false)
+				continue
+			}
+
+			delay := pw.backoff.NextDelay(attempts)
+			p.logger.Warn("restarting crashed worker",
+				"worker_id", pw.worker.GetID(), "attempt", attempts+1, "delay", delay)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+
+			p.metrics.ReconnectAttempts.Add(1)
+			pw.restartAttempts.Add(1)
+			pw.healthy.Store(false)
+			if err := pw.worker.Start(ctx); err != nil {
+				p.logger.Error("worker restart failed", "worker_id", pw.worker.GetID(), "error", err)
+				p.metrics.WorkerFailures.Add(1)
+				pw.supervisor.RecordRestartResult(err)
+				continue
+			}
+			p.metrics.WorkerRestarts.Add(1)
+			pw.restartAttempts.Store(0)
+			pw.healthy.Store(true)
+			pw.supervisor.RecordRestartResult(nil)
+			wasRunning = true
 		}
 	}
+}
 
-	// Get connection from pool
-	var conn net.Conn
-	select {
-	case conn = <-pw.connPool:
-	default:
-		// Create new connection if pool is empty
-		var err error
-		conn, err = p.connect(pw.worker.cfg.SocketPath)
-		if err != nil {
-			return fmt.Errorf("failed to connect: %w", err)
+// Call invokes a method on a worker chosen by the pool's Balancer
+func (p *Pool) Call(ctx context.Context, method string, input interface{}, output interface{}) error {
+	return p.callOnce(ctx, method, input, output)
+}
+
+// Mount exposes workerID's local filesystem as an io/fs.FS, multiplexing
+// open/read/readdir/stat/close operations over that worker's existing
+// MultiplexedConn instead of opening a second socket or assuming Go and the
+// worker share a filesystem - see pkg/pyproc/fs. ctx scopes every operation
+// made through the returned fs.FS, not just the Mount call itself. A
+// connection supports at most one mount at a time; mounting it again
+// replaces the previous one.
+func (p *Pool) Mount(ctx context.Context, workerID string, remotePath string) (fs.FS, error) {
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
+	for _, pw := range workers {
+		if pw.worker.ID() != workerID {
+			continue
 		}
-	}
 
-	// Return connection to pool after use
-	defer func() {
-		select {
-		case pw.connPool <- conn:
-		default:
-			conn.Close()
+		mux := pyprocfs.NewMux(pw.mconn)
+		pw.mconn.mountFS(mux)
+
+		root := pyprocfs.New(ctx, mux)
+		if remotePath == "" || remotePath == "." {
+			return root, nil
 		}
-	}()
+		return fs.Sub(root, remotePath)
+	}
 
-	// Send request
-	reqID := pw.requestID.Add(1)
-	req, err := protocol.NewRequest(reqID, method, input)
-	if err != nil {
-		return err
+	return nil, fmt.Errorf("pool: no worker with ID %q", workerID)
+}
+
+// Notify sends a fire-and-forget call to a worker chosen by the pool's
+// Balancer: it returns once the request is written, without waiting for
+// (or expecting) a response. Intended for telemetry/logging calls into
+// Python workers where the caller has no use for a result - see
+// MultiplexedConn.Notify.
+func (p *Pool) Notify(ctx context.Context, method string, input interface{}) error {
+	if p.shutdown.Load() {
+		return errors.New("pool is shut down")
+	}
+
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
+	pw, release := p.balancer.Pick(workers)
+	if pw == nil {
+		return errors.New("no healthy workers available")
 	}
+	defer release()
 
-	framer := framing.NewFramer(conn)
-	reqData, err := req.Marshal()
+	req, err := protocol.NewRequest(0, method, input)
 	if err != nil {
 		return err
 	}
 
-	if err := framer.WriteMessage(reqData); err != nil {
-		conn.Close() // Connection is bad, don't return to pool
+	return pw.mconn.Notify(ctx, req)
+}
+
+// CallIdempotent is like Call, but retries once on a sibling worker if the
+// first attempt fails with ErrConnectionFailed - its assigned worker's
+// connection broke outright (e.g. the worker crashed mid-request) - rather
+// than an ordinary application error. Only use this for methods safe to
+// re-run from scratch: a worker crash gives no guarantee about how far the
+// first attempt got before dying, so a non-idempotent method could be
+// applied twice.
+func (p *Pool) CallIdempotent(ctx context.Context, method string, input interface{}, output interface{}) error {
+	err := p.callOnce(ctx, method, input, output)
+	if err == nil || !errors.Is(err, ErrConnectionFailed) {
 		return err
 	}
 
-	// Read response
-	respData, err := framer.ReadMessage()
+	p.logger.Warn("retrying idempotent call after connection failure", "method", method)
+	return p.callOnce(ctx, method, input, output)
+}
+
+func (p *Pool) callOnce(ctx context.Context, method string, input interface{}, output interface{}) error {
+	if p.shutdown.Load() {
+		return errors.New("pool is shut down")
+	}
+
+	if timeout := p.requestTimeout.Load(); timeout > 0 {
+		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout))
+			defer cancel()
+		}
+	}
+
+	// Acquire semaphore for backpressure
+	select {
+	case p.semaphore <- struct{}{}:
+		defer func() { <-p.semaphore }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
+	pw, release := p.balancer.Pick(workers)
+	if pw == nil {
+		return errors.New("no healthy workers available")
+	}
+	defer release()
+
+	// Send the request over the worker's multiplexed connection. It may be
+	// mid-reconnect after a transient failure; Call() blocks until the
+	// connection recovers, req.ctx is done, or it's permanently closed.
+	req, err := protocol.NewRequest(0, method, input)
 	if err != nil {
-		conn.Close() // Connection is bad, don't return to pool
 		return err
 	}
 
-	var resp protocol.Response
-	if err := resp.Unmarshal(respData); err != nil {
+	resp, err := pw.mconn.Call(ctx, req)
+	if err != nil {
 		return err
 	}
 
@@ -228,7 +573,12 @@ func (p *Pool) Call(ctx context.Context, method string, input interface{}, outpu
 		// Add worker ID to response
 		var result map[string]interface{}
 		if err := json.Unmarshal(resp.Body, &result); err == nil {
-			result["worker_id"] = float64(idx % uint64(len(p.workers)))
+			for i, w := range workers {
+				if w == pw {
+					result["worker_id"] = float64(i)
+					break
+				}
+			}
 			modifiedBody, _ := json.Marshal(result)
 			resp.Body = modifiedBody
 		}
@@ -237,6 +587,57 @@ func (p *Pool) Call(ctx context.Context, method string, input interface{}, outpu
 	return resp.UnmarshalBody(output)
 }
 
+// CallStream is like Call, but expects the worker to reply with a sequence
+// of chunks (sent as STREAM_DATA frames, terminated by STREAM_END or
+// STREAM_ERROR) instead of a single response - e.g. streaming token
+// generation from an LLM, progressive image inference, or incremental data
+// pipeline output. On the Python side the method must be registered with
+// @expose_stream instead of @expose. The returned channel is closed once
+// the stream ends, including when ctx is cancelled; CallStream itself
+// applies no timeout beyond whatever ctx carries; long-running streams
+// should rely on ctx rather than requestTimeout.
+func (p *Pool) CallStream(ctx context.Context, method string, input interface{}) (<-chan Msg, error) {
+	if p.shutdown.Load() {
+		return nil, errors.New("pool is shut down")
+	}
+
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
+	pw, release := p.balancer.Pick(workers)
+	if pw == nil {
+		return nil, errors.New("no healthy workers available")
+	}
+
+	req, err := protocol.NewRequest(0, method, input)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	msgCh, err := pw.mconn.CallStream(ctx, req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Call releases the worker's in-flight slot via defer because it blocks
+	// until its single response arrives; CallStream returns immediately, so
+	// this goroutine relays chunks onward and holds the slot open until the
+	// stream itself ends instead.
+	out := make(chan Msg, cap(msgCh))
+	go func() {
+		defer release()
+		defer close(out)
+		for msg := range msgCh {
+			out <- msg
+		}
+	}()
+
+	return out, nil
+}
+
 // Shutdown gracefully shuts down all workers
 func (p *Pool) Shutdown(ctx context.Context) error {
 	if !p.shutdown.CompareAndSwap(false, true) {
@@ -250,17 +651,34 @@ func (p *Pool) Shutdown(ctx context.Context) error {
 		p.healthCancel()
 	}
 
-	// Close all connection pools
-	for _, pw := range p.workers {
-		close(pw.connPool)
-		for conn := range pw.connPool {
-			conn.Close()
+	// Cancel restart supervision so we don't race worker.Stop() below
+	if p.restartCancel != nil {
+		p.restartCancel()
+	}
+
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
+	// Lame duck: mark every worker draining before any of them are torn
+	// down, so the balancer stops routing new calls to them immediately,
+	// then give already-assigned calls a chance to finish normally instead
+	// of being cut off by the Close/Stop below.
+	for _, pw := range workers {
+		pw.draining.Store(true)
+	}
+	p.drainWorkers(workers)
+
+	// Close all multiplexed connections
+	for _, pw := range workers {
+		if pw.mconn != nil {
+			pw.mconn.Close()
 		}
 	}
 
 	// Stop all workers
 	var errs []error
-	for i, pw := range p.workers {
+	for i, pw := range workers {
 		if err := pw.worker.Stop(); err != nil {
 			errs = append(errs, fmt.Errorf("worker %d: %w", i, err))
 		}
@@ -277,6 +695,39 @@ func (p *Pool) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// drainWorkers waits for every worker's in-flight call count to reach zero,
+// up to Config.LameDuckTimeout (5 seconds if unset), before Shutdown closes
+// their connections. It gives up on whichever workers are still busy once
+// the deadline passes; their in-flight calls then fail the same way any
+// other connection close would.
+func (p *Pool) drainWorkers(workers []*poolWorker) {
+	timeout := p.opts.Config.LameDuckTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		busy := 0
+		for _, pw := range workers {
+			if pw.inFlight.Load() > 0 {
+				busy++
+			}
+		}
+		if busy == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			p.logger.Warn("lame duck timeout expired with calls still in flight", "workers_busy", busy)
+			return
+		}
+		<-ticker.C
+	}
+}
+
 // Health returns the current health status of the pool
 func (p *Pool) Health() HealthStatus {
 	p.healthMu.RLock()
@@ -329,11 +780,29 @@ func (p *Pool) healthMonitor(ctx context.Context) {
 
 // updateHealthStatus updates the health status of all workers
 func (p *Pool) updateHealthStatus() {
+	p.workersMu.RLock()
+	workers := p.workers
+	p.workersMu.RUnlock()
+
 	healthy := 0
+	draining := 0
+	degraded := 0
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	for _, pw := range p.workers {
+	for _, pw := range workers {
+		if pw.Draining() {
+			draining++
+			if pw.Degraded() {
+				degraded++
+			}
+			continue
+		}
+		if pw.Degraded() {
+			degraded++
+			pw.healthy.Store(false)
+			continue
+		}
 		if pw.worker.IsHealthy(ctx) {
 			pw.healthy.Store(true)
 			healthy++
@@ -344,14 +813,16 @@ func (p *Pool) updateHealthStatus() {
 
 	p.healthMu.Lock()
 	p.healthStatus = HealthStatus{
-		TotalWorkers:   len(p.workers),
-		HealthyWorkers: healthy,
-		LastCheck:      time.Now(),
+		TotalWorkers:    len(workers),
+		HealthyWorkers:  healthy,
+		DrainingWorkers: draining,
+		DegradedWorkers: degraded,
+		LastCheck:       time.Now(),
 	}
 	p.healthMu.Unlock()
 
-	if healthy < len(p.workers) {
+	if healthy < len(workers) {
 		p.logger.Warn("some workers are unhealthy",
-			"healthy", healthy, "total", len(p.workers))
+			"healthy", healthy, "total", len(workers))
 	}
 }
@@ -0,0 +1,137 @@
+package pyproc
+
+import (
+	"math/rand"
+	"sync/atomic"
+)
+
+// Balancer selects which worker should handle the next Pool.Call among the
+// pool's current worker set, skipping unhealthy workers itself rather than
+// leaving Pool.Call to fix up the choice afterward. Pick returns nil if no
+// worker is eligible. The returned release func must be called once the call
+// has finished (success or failure) so load-aware balancers can track
+// in-flight counts; implementations that don't need this return a no-op.
+type Balancer interface {
+	Pick(workers []*poolWorker) (pw *poolWorker, release func())
+}
+
+// healthyWorkers returns the subset of workers that are currently healthy
+// and not draining - a worker Shutdown has started tearing down keeps
+// serving the calls already assigned to it, but never receives a new one.
+func healthyWorkers(workers []*poolWorker) []*poolWorker {
+	healthy := make([]*poolWorker, 0, len(workers))
+	for _, w := range workers {
+		if w.healthy.Load() && !w.draining.Load() {
+			healthy = append(healthy, w)
+		}
+	}
+	return healthy
+}
+
+// noopRelease is shared by balancers that don't track per-worker state.
+func noopRelease() {}
+
+// RoundRobinBalancer cycles through healthy workers in order. It's the
+// simplest policy and the best fit when every method call costs about the
+// same; it does not adapt to uneven latencies between workers.
+type RoundRobinBalancer struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobinBalancer returns a Balancer that distributes calls evenly
+// across healthy workers in round-robin order.
+func NewRoundRobinBalancer() *RoundRobinBalancer {
+	return &RoundRobinBalancer{}
+}
+
+func (b *RoundRobinBalancer) Pick(workers []*poolWorker) (*poolWorker, func()) {
+	healthy := healthyWorkers(workers)
+	if len(healthy) == 0 {
+		return nil, nil
+	}
+	idx := (b.next.Add(1) - 1) % uint64(len(healthy))
+	return healthy[idx], noopRelease
+}
+
+// RandomBalancer picks a uniformly random healthy worker per call.
+type RandomBalancer struct{}
+
+// NewRandomBalancer returns a Balancer that picks a random healthy worker.
+func NewRandomBalancer() *RandomBalancer {
+	return &RandomBalancer{}
+}
+
+func (b *RandomBalancer) Pick(workers []*poolWorker) (*poolWorker, func()) {
+	healthy := healthyWorkers(workers)
+	if len(healthy) == 0 {
+		return nil, nil
+	}
+	return healthy[rand.Intn(len(healthy))], noopRelease
+}
+
+// LeastInFlightBalancer always picks the healthy worker with the fewest
+// in-flight calls, so a worker stuck processing a slow request (e.g. a large
+// batch) stops receiving new work until it catches up. This is the default
+// balancer: round-robin and random both send new work to a busy worker just
+// because "it's its turn", which hurts tail latency once call durations vary.
+type LeastInFlightBalancer struct{}
+
+// NewLeastInFlightBalancer returns a Balancer that favors the least-loaded
+// healthy worker.
+func NewLeastInFlightBalancer() *LeastInFlightBalancer {
+	return &LeastInFlightBalancer{}
+}
+
+func (b *LeastInFlightBalancer) Pick(workers []*poolWorker) (*poolWorker, func()) {
+	healthy := healthyWorkers(workers)
+	if len(healthy) == 0 {
+		return nil, nil
+	}
+
+	best := healthy[0]
+	for _, w := range healthy[1:] {
+		if w.inFlight.Load() < best.inFlight.Load() {
+			best = w
+		}
+	}
+
+	best.inFlight.Add(1)
+	return best, func() { best.inFlight.Add(-1) }
+}
+
+// PowerOfTwoChoicesBalancer samples two distinct random healthy workers and
+// picks the less-loaded of the two. It approximates LeastInFlightBalancer's
+// tail-latency benefit without scanning every worker on every Pick, which
+// matters once the pool has many workers and Pick is on the hot path.
+type PowerOfTwoChoicesBalancer struct{}
+
+// NewPowerOfTwoChoicesBalancer returns a Balancer that picks the less-loaded
+// of two randomly sampled healthy workers.
+func NewPowerOfTwoChoicesBalancer() *PowerOfTwoChoicesBalancer {
+	return &PowerOfTwoChoicesBalancer{}
+}
+
+func (b *PowerOfTwoChoicesBalancer) Pick(workers []*poolWorker) (*poolWorker, func()) {
+	healthy := healthyWorkers(workers)
+	switch len(healthy) {
+	case 0:
+		return nil, nil
+	case 1:
+		healthy[0].inFlight.Add(1)
+		return healthy[0], func() { healthy[0].inFlight.Add(-1) }
+	}
+
+	i := rand.Intn(len(healthy))
+	j := rand.Intn(len(healthy) - 1)
+	if j >= i {
+		j++
+	}
+
+	pick := healthy[i]
+	if healthy[j].inFlight.Load() < pick.inFlight.Load() {
+		pick = healthy[j]
+	}
+
+	pick.inFlight.Add(1)
+	return pick, func() { pick.inFlight.Add(-1) }
+}
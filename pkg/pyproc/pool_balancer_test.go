@@ -0,0 +1,198 @@
+package pyproc
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestPoolWorkers(n int) []*poolWorker {
+	workers := make([]*poolWorker, n)
+	for i := range workers {
+		pw := &poolWorker{}
+		pw.healthy.Store(true)
+		workers[i] = pw
+	}
+	return workers
+}
+
+func TestRoundRobinBalancerDistributesEvenly(t *testing.T) {
+	workers := newTestPoolWorkers(3)
+	b := NewRoundRobinBalancer()
+
+	counts := make(map[*poolWorker]int)
+	for i := 0; i < 9; i++ {
+		pw, release := b.Pick(workers)
+		if pw == nil {
+			t.Fatal("expected a worker")
+		}
+		release()
+		counts[pw]++
+	}
+
+	for _, w := range workers {
+		if counts[w] != 3 {
+			t.Errorf("expected each worker to be picked 3 times, got %d", counts[w])
+		}
+	}
+}
+
+func TestRoundRobinBalancerSkipsUnhealthy(t *testing.T) {
+	workers := newTestPoolWorkers(3)
+	workers[1].healthy.Store(false)
+	b := NewRoundRobinBalancer()
+
+	for i := 0; i < 6; i++ {
+		pw, release := b.Pick(workers)
+		if pw == workers[1] {
+			t.Fatal("balancer picked an unhealthy worker")
+		}
+		release()
+	}
+}
+
+func TestRandomBalancerSkipsUnhealthy(t *testing.T) {
+	workers := newTestPoolWorkers(3)
+	workers[0].healthy.Store(false)
+	workers[2].healthy.Store(false)
+	b := NewRandomBalancer()
+
+	for i := 0; i < 10; i++ {
+		pw, release := b.Pick(workers)
+		if pw != workers[1] {
+			t.Fatal("balancer picked an unhealthy worker")
+		}
+		release()
+	}
+}
+
+func TestBalancersReturnNilWithNoHealthyWorkers(t *testing.T) {
+	workers := newTestPoolWorkers(2)
+	for _, w := range workers {
+		w.healthy.Store(false)
+	}
+
+	for _, b := range []Balancer{
+		NewRoundRobinBalancer(),
+		NewRandomBalancer(),
+		NewLeastInFlightBalancer(),
+		NewPowerOfTwoChoicesBalancer(),
+	} {
+		if pw, _ := b.Pick(workers); pw != nil {
+			t.Errorf("%T: expected nil with no healthy workers, got %v", b, pw)
+		}
+	}
+}
+
+func TestLeastInFlightBalancerPrefersIdleWorker(t *testing.T) {
+	workers := newTestPoolWorkers(3)
+	workers[0].inFlight.Store(5)
+	workers[1].inFlight.Store(0)
+	workers[2].inFlight.Store(2)
+
+	b := NewLeastInFlightBalancer()
+	pw, release := b.Pick(workers)
+	if pw != workers[1] {
+		t.Fatalf("expected the least-loaded worker to be picked")
+	}
+	if workers[1].inFlight.Load() != 1 {
+		t.Errorf("expected inFlight to be incremented on Pick, got %d", workers[1].inFlight.Load())
+	}
+	release()
+	if workers[1].inFlight.Load() != 0 {
+		t.Errorf("expected inFlight to be decremented on release, got %d", workers[1].inFlight.Load())
+	}
+}
+
+func TestLeastInFlightBalancerSkipsDrainingWorker(t *testing.T) {
+	workers := newTestPoolWorkers(2)
+	workers[0].draining.Store(true)
+
+	b := NewLeastInFlightBalancer()
+	for i := 0; i < 3; i++ {
+		pw, release := b.Pick(workers)
+		if pw != workers[1] {
+			t.Fatal("balancer picked a draining worker")
+		}
+		release()
+	}
+}
+
+func TestLeastInFlightBalancerSpreadsConcurrentLoad(t *testing.T) {
+	workers := newTestPoolWorkers(4)
+	b := NewLeastInFlightBalancer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pw, release := b.Pick(workers)
+			defer release()
+			time.Sleep(time.Millisecond)
+			_ = pw
+		}()
+	}
+	wg.Wait()
+
+	for _, w := range workers {
+		if w.inFlight.Load() != 0 {
+			t.Errorf("expected inFlight to settle at 0, got %d", w.inFlight.Load())
+		}
+	}
+}
+
+func TestPowerOfTwoChoicesBalancerPrefersLessLoaded(t *testing.T) {
+	workers := newTestPoolWorkers(2)
+	workers[0].inFlight.Store(10)
+	workers[1].inFlight.Store(0)
+
+	b := NewPowerOfTwoChoicesBalancer()
+	for i := 0; i < 20; i++ {
+		pw, release := b.Pick(workers)
+		if pw != workers[1] {
+			t.Fatalf("expected the only less-loaded worker to be picked")
+		}
+		release()
+	}
+}
+
+// BenchmarkBalancers compares tail latency under a mix of fast and slow
+// simulated method calls. Every 10th call "holds" its worker for 20ms (e.g. a
+// large batch); the rest return in under a microsecond. LeastInFlight and
+// PowerOfTwoChoices should route around the held worker far more often than
+// RoundRobin or Random, which assign to it regardless of its current load.
+func BenchmarkBalancers(b *testing.B) {
+	balancers := map[string]Balancer{
+		"RoundRobin":        NewRoundRobinBalancer(),
+		"Random":            NewRandomBalancer(),
+		"LeastInFlight":     NewLeastInFlightBalancer(),
+		"PowerOfTwoChoices": NewPowerOfTwoChoicesBalancer(),
+	}
+
+	for name, balancer := range balancers {
+		balancer := balancer
+		b.Run(name, func(b *testing.B) {
+			workers := newTestPoolWorkers(8)
+			var wg sync.WaitGroup
+			for i := 0; i < b.N; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					pw, release := balancer.Pick(workers)
+					if pw == nil {
+						return
+					}
+					if i%10 == 0 {
+						time.Sleep(20 * time.Millisecond)
+					} else {
+						_ = rand.Int()
+					}
+					release()
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
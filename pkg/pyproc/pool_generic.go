@@ -3,6 +3,7 @@ package pyproc
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 // CallTyped is a type-safe wrapper for Pool.Call using Go generics
@@ -83,35 +84,166 @@ func (tc *TypedWorkerClient[TIn, TOut]) Call(ctx context.Context, input TIn) (TO
 	return CallTyped[TIn, TOut](ctx, tc.pool, tc.method, input)
 }
 
-// BatchCall executes multiple requests in parallel
-func (tc *TypedWorkerClient[TIn, TOut]) BatchCall(ctx context.Context, inputs []TIn) ([]TOut, []error) {
-	results := make([]TOut, len(inputs))
-	errors := make([]error, len(inputs))
+// BatchOptions configures BatchCall/BatchCallStream's concurrency and
+// cancellation behavior. The zero value runs with StopOnError disabled and
+// Concurrency defaulted (see Concurrency's doc comment).
+type BatchOptions struct {
+	// Concurrency bounds how many Calls are in flight at once. <= 0
+	// defaults to min(inputCount, pool.Size()*2) - enough to keep every
+	// worker's MultiplexedConn saturated without spawning one goroutine per
+	// input, which used to let a large batch blow past the pool's own
+	// backpressure semaphore and memory budget.
+	Concurrency int
+	// StopOnError cancels every not-yet-started and in-flight call as soon
+	// as one of them returns an error, instead of running the whole batch
+	// to completion regardless.
+	StopOnError bool
+}
+
+// BatchResult is one item's outcome from BatchCallStream, tagged with Index
+// so a caller receiving results as they complete (not necessarily in input
+// order) can still align each one with its input.
+type BatchResult[TOut any] struct {
+	Index  int
+	Output TOut
+	Err    error
+}
 
-	// Use goroutines for parallel execution
-	type result struct {
-		index  int
-		output TOut
-		err    error
+// batchConcurrency resolves opts' effective concurrency against pool p and
+// inputCount, applying the min(inputCount, pool.Size()*2) default described
+// on BatchOptions.Concurrency.
+func batchConcurrency(opts BatchOptions, pool *Pool, inputCount int) int {
+	if opts.Concurrency > 0 {
+		return opts.Concurrency
+	}
+	concurrency := pool.Size() * 2
+	if concurrency <= 0 || concurrency > inputCount {
+		concurrency = inputCount
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return concurrency
+}
+
+// firstBatchOptions returns opts[0], or the zero value if the caller passed
+// none - the same variadic-trailing-option pattern used by WithCodec's
+// NewMultiplexedConn.
+func firstBatchOptions(opts []BatchOptions) BatchOptions {
+	if len(opts) == 0 {
+		return BatchOptions{}
+	}
+	return opts[0]
+}
+
+// BatchCall executes inputs through tc.Call with bounded concurrency (see
+// BatchOptions.Concurrency) instead of one goroutine per input, and returns
+// once every item has either completed or been cancelled. If opts.StopOnError
+// is set, the first error cancels every other in-flight and not-yet-started
+// call; ctx being cancelled does the same regardless of StopOnError.
+func (tc *TypedWorkerClient[TIn, TOut]) BatchCall(ctx context.Context, inputs []TIn, opts ...BatchOptions) ([]TOut, []error) {
+	results := make([]TOut, len(inputs))
+	errs := make([]error, len(inputs))
+	if len(inputs) == 0 {
+		return results, errs
 	}
 
-	resultCh := make(chan result, len(inputs))
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
+	o := firstBatchOptions(opts)
+	sem := make(chan struct{}, batchConcurrency(o, tc.pool, len(inputs)))
+
+	var wg sync.WaitGroup
 	for i, input := range inputs {
+		select {
+		case sem <- struct{}{}:
+		case <-batchCtx.Done():
+			errs[i] = batchCtx.Err()
+			continue
+		}
+
+		wg.Add(1)
 		go func(idx int, in TIn) {
-			out, err := tc.Call(ctx, in)
-			resultCh <- result{index: idx, output: out, err: err}
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			out, err := tc.Call(batchCtx, in)
+			results[idx] = out
+			errs[idx] = err
+			if err != nil && o.StopOnError {
+				cancel()
+			}
 		}(i, input)
 	}
+	wg.Wait()
 
-	// Collect results
-	for i := 0; i < len(inputs); i++ {
-		res := <-resultCh
-		results[res.index] = res.output
-		errors[res.index] = res.err
+	return results, errs
+}
+
+// BatchCallStream is like BatchCall, but for inputs arriving over time
+// instead of available as a slice up front (e.g. feature-batching for ML
+// inference) - it drains in as it's filled and closes its returned channel
+// once in is closed and every call it produced has finished. Results may
+// arrive out of order; each carries its Index so the caller can re-align
+// them with the input that produced it.
+func (tc *TypedWorkerClient[TIn, TOut]) BatchCallStream(ctx context.Context, in <-chan TIn, opts ...BatchOptions) <-chan BatchResult[TOut] {
+	o := firstBatchOptions(opts)
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = tc.pool.Size() * 2
+	}
+	if concurrency <= 0 {
+		concurrency = 1
 	}
 
-	return results, errors
+	batchCtx, cancel := context.WithCancel(ctx)
+	sem := make(chan struct{}, concurrency)
+	out := make(chan BatchResult[TOut])
+
+	go func() {
+		defer cancel()
+		defer close(out)
+
+		var wg sync.WaitGroup
+		index := 0
+	loop:
+		for {
+			select {
+			case input, ok := <-in:
+				if !ok {
+					break loop
+				}
+
+				select {
+				case sem <- struct{}{}:
+				case <-batchCtx.Done():
+					out <- BatchResult[TOut]{Index: index, Err: batchCtx.Err()}
+					index++
+					continue
+				}
+
+				wg.Add(1)
+				go func(idx int, item TIn) {
+					defer wg.Done()
+					defer func() { <-sem }()
+
+					output, err := tc.Call(batchCtx, item)
+					out <- BatchResult[TOut]{Index: idx, Output: output, Err: err}
+					if err != nil && o.StopOnError {
+						cancel()
+					}
+				}(index, input)
+				index++
+
+			case <-batchCtx.Done():
+				break loop
+			}
+		}
+		wg.Wait()
+	}()
+
+	return out
 }
 
 // Example usage types for common patterns
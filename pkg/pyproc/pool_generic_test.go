@@ -116,6 +116,41 @@ func TestTypedPool(t *testing.T) {
 				t.Errorf("Batch result %d: got %v, want %v", i, output.Result, expectedResults[i])
 			}
 		}
+
+		// BatchCall with an explicit Concurrency cap still returns every
+		// result in input order.
+		boundedInputs := []PredictRequest{{Value: 10}, {Value: 20}, {Value: 30}, {Value: 40}}
+		boundedOutputs, boundedErrors := predictClient.BatchCall(ctx, boundedInputs, BatchOptions{Concurrency: 1})
+		for i, err := range boundedErrors {
+			if err != nil {
+				t.Errorf("Bounded batch call %d failed: %v", i, err)
+			}
+		}
+		expectedBounded := []float64{20, 40, 60, 80}
+		for i, output := range boundedOutputs {
+			if output.Result != expectedBounded[i] {
+				t.Errorf("Bounded batch result %d: got %v, want %v", i, output.Result, expectedBounded[i])
+			}
+		}
+
+		// BatchCallStream delivers a BatchResult per input without requiring
+		// the whole slice up front.
+		streamIn := make(chan PredictRequest, 2)
+		streamIn <- PredictRequest{Value: 5}
+		streamIn <- PredictRequest{Value: 6}
+		close(streamIn)
+
+		streamResults := map[int]float64{}
+		for res := range predictClient.BatchCallStream(ctx, streamIn) {
+			if res.Err != nil {
+				t.Errorf("BatchCallStream result %d failed: %v", res.Index, res.Err)
+				continue
+			}
+			streamResults[res.Index] = res.Output.Result
+		}
+		if streamResults[0] != 10 || streamResults[1] != 12 {
+			t.Errorf("Unexpected BatchCallStream results: %v", streamResults)
+		}
 	})
 
 	t.Run("CallTyped Function", func(t *testing.T) {
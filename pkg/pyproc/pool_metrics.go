@@ -2,11 +2,52 @@ package pyproc
 
 import (
 	"context"
-	"sync"
+	"math"
 	"sync/atomic"
 	"time"
 )
 
+const (
+	// latencyHistMin and latencyHistMax bound the range the latency
+	// histogram tracks; samples outside this range are clamped into the
+	// first or last bucket.
+	latencyHistMin = time.Microsecond
+	latencyHistMax = 60 * time.Second
+	// latencyHistSubBuckets is the number of buckets per power-of-two
+	// octave, i.e. bucket bounds are latencyHistMin * 2^(i/latencyHistSubBuckets).
+	latencyHistSubBuckets = 4
+)
+
+// latencyHistBuckets is the number of buckets needed to cover
+// [latencyHistMin, latencyHistMax] at latencyHistSubBuckets per octave,
+// plus one catch-all bucket. The last regular bucket's upper bound rounds up
+// past latencyHistMax, so the catch-all receives only samples above it.
+var latencyHistBuckets = int(math.Ceil(latencyHistSubBuckets*math.Log2(float64(latencyHistMax)/float64(latencyHistMin)))) + 1
+
+// latencyBucketIndex maps a latency sample to its histogram bucket. It runs
+// in O(1): no locks, no scan, just a log2 and a clamp.
+func latencyBucketIndex(d time.Duration) int {
+	if d <= latencyHistMin {
+		return 0
+	}
+	idx := int(latencyHistSubBuckets * math.Log2(float64(d)/float64(latencyHistMin)))
+	if idx >= latencyHistBuckets {
+		idx = latencyHistBuckets - 1
+	}
+	return idx
+}
+
+// latencyBucketRange returns the [lower, upper) bound, in nanoseconds, that
+// bucket i covers.
+func latencyBucketRange(i int) (lower, upper float64) {
+	minNs := float64(latencyHistMin)
+	if i > 0 {
+		lower = minNs * math.Pow(2, float64(i)/latencyHistSubBuckets)
+	}
+	upper = minNs * math.Pow(2, float64(i+1)/latencyHistSubBuckets)
+	return lower, upper
+}
+
 // PoolMetrics tracks metrics for connection pooling
 type PoolMetrics struct {
 	// Connection metrics
@@ -21,14 +62,17 @@ type PoolMetrics struct {
 	RequestsFailed    atomic.Uint64
 	RequestsTimeout   atomic.Uint64
 
-	// Latency tracking
-	latencyMu    sync.RWMutex
-	latencies    []time.Duration
-	maxLatencies int
+	// Latency tracking: a lock-free logarithmic-bucket histogram rather
+	// than a sorted sample slice, so RecordLatency is O(1) and doesn't
+	// contend with readers.
+	latencyBuckets []atomic.Uint64
 
 	// Worker metrics
 	WorkerRestarts atomic.Uint64
 	WorkerFailures atomic.Uint64
+	// ReconnectAttempts counts every restart/reconnect attempt, successful or
+	// not; WorkerRestarts/WorkerFailures only count the outcome.
+	ReconnectAttempts atomic.Uint64
 
 	// Pool utilization
 	PoolUtilization atomic.Uint64 // percentage * 100
@@ -38,53 +82,57 @@ type PoolMetrics struct {
 // NewPoolMetrics creates a new metrics tracker
 func NewPoolMetrics() *PoolMetrics {
 	return &PoolMetrics{
-		maxLatencies: 10000, // Keep last 10k latencies for percentile calculation
-		latencies:    make([]time.Duration, 0, 10000),
+		latencyBuckets: make([]atomic.Uint64, latencyHistBuckets),
 	}
 }
 
-// RecordLatency records a request latency
+// RecordLatency records a request latency. O(1): a bucket lookup and a
+// single atomic increment, no locking.
 func (m *PoolMetrics) RecordLatency(latency time.Duration) {
-	m.latencyMu.Lock()
-	defer m.latencyMu.Unlock()
-
-	if len(m.latencies) >= m.maxLatencies {
-		// Remove oldest entry
-		m.latencies = m.latencies[1:]
-	}
-	m.latencies = append(m.latencies, latency)
+	m.latencyBuckets[latencyBucketIndex(latency)].Add(1)
 }
 
-// GetLatencyPercentile calculates latency percentile
+// GetLatencyPercentile estimates a latency percentile from the histogram.
 func (m *PoolMetrics) GetLatencyPercentile(percentile float64) time.Duration {
-	m.latencyMu.RLock()
-	defer m.latencyMu.RUnlock()
+	return histogramPercentile(m.latencyBuckets, percentile)
+}
 
-	if len(m.latencies) == 0 {
+// histogramPercentile estimates a percentile from a logarithmic-bucket
+// histogram built by latencyBucketIndex/latencyBucketRange. It scans the
+// (small, fixed) bucket set to find the bucket containing the target rank,
+// then linearly interpolates within that bucket's range. Shared by
+// PoolMetrics and transportPoolMetrics so both read the same histogram the
+// same way.
+func histogramPercentile(buckets []atomic.Uint64, percentile float64) time.Duration {
+	counts := make([]uint64, len(buckets))
+	var total uint64
+	for i := range buckets {
+		counts[i] = buckets[i].Load()
+		total += counts[i]
+	}
+	if total == 0 {
 		return 0
 	}
 
-	// Create a copy for sorting
-	sorted := make([]time.Duration, len(m.latencies))
-	copy(sorted, m.latencies)
-
-	// Simple percentile calculation (not perfectly accurate but fast)
-	index := int(float64(len(sorted)-1) * percentile / 100.0)
-	if index < 0 {
-		index = 0
-	}
-	if index >= len(sorted) {
-		index = len(sorted) - 1
+	target := percentile / 100.0 * float64(total)
+	var cumulative uint64
+	for i, count := range counts {
+		if float64(cumulative+count) >= target && count > 0 {
+			lower, upper := latencyBucketRange(i)
+			fraction := (target - float64(cumulative)) / float64(count)
+			return time.Duration(lower + fraction*(upper-lower))
+		}
+		cumulative += count
 	}
 
-	return sorted[index]
+	// All samples landed below target due to rounding at the very top of
+	// the range; report the top bucket's upper bound.
+	_, upper := latencyBucketRange(len(counts) - 1)
+	return time.Duration(upper)
 }
 
 // GetMetricsSnapshot returns a snapshot of current metrics
 func (m *PoolMetrics) GetMetricsSnapshot() MetricsSnapshot {
-	m.latencyMu.RLock()
-	defer m.latencyMu.RUnlock()
-
 	return MetricsSnapshot{
 		ConnectionsCreated:   m.ConnectionsCreated.Load(),
 		ConnectionsDestroyed: m.ConnectionsDestroyed.Load(),
@@ -96,6 +144,7 @@ func (m *PoolMetrics) GetMetricsSnapshot() MetricsSnapshot {
 		RequestsTimeout:      m.RequestsTimeout.Load(),
 		WorkerRestarts:       m.WorkerRestarts.Load(),
 		WorkerFailures:       m.WorkerFailures.Load(),
+		ReconnectAttempts:    m.ReconnectAttempts.Load(),
 		PoolUtilization:      float64(m.PoolUtilization.Load()) / 100.0,
 		QueueDepth:           m.QueueDepth.Load(),
 		LatencyP50:           m.GetLatencyPercentile(50),
@@ -119,8 +168,9 @@ type MetricsSnapshot struct {
 	RequestsTimeout   uint64
 
 	// Workers
-	WorkerRestarts uint64
-	WorkerFailures uint64
+	WorkerRestarts    uint64
+	WorkerFailures    uint64
+	ReconnectAttempts uint64
 
 	// Performance
 	PoolUtilization float64
@@ -139,7 +189,10 @@ type PoolWithMetrics struct {
 	metrics *PoolMetrics
 }
 
-// NewPoolWithMetrics creates a pool with metrics tracking
+// NewPoolWithMetrics creates a pool with metrics tracking. It reuses the
+// embedded Pool's own metrics tracker, so WorkerRestarts/WorkerFailures/
+// ReconnectAttempts recorded by the pool's restart supervisor show up
+// alongside the request/latency counters Call adds here.
 func NewPoolWithMetrics(opts PoolOptions, logger *Logger) (*PoolWithMetrics, error) {
 	pool, err := NewPool(opts, logger)
 	if err != nil {
@@ -148,7 +201,7 @@ func NewPoolWithMetrics(opts PoolOptions, logger *Logger) (*PoolWithMetrics, err
 
 	return &PoolWithMetrics{
 		Pool:    pool,
-		metrics: NewPoolMetrics(),
+		metrics: pool.Metrics(),
 	}, nil
 }
 
@@ -192,8 +245,12 @@ func (p *PoolWithMetrics) GetMetrics() MetricsSnapshot {
 	return snapshot
 }
 
-// ResetMetrics resets all metrics counters
+// ResetMetrics resets all metrics counters. It replaces the Pool's own
+// tracker too, so a subsequent worker restart keeps incrementing the same
+// instance this wrapper reads from.
 func (p *PoolWithMetrics) ResetMetrics() {
-	p.metrics = NewPoolMetrics()
+	fresh := NewPoolMetrics()
+	p.metrics = fresh
+	p.Pool.metrics = fresh
 }
 
@@ -0,0 +1,68 @@
+package pyproc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyBucketIndexMonotonic(t *testing.T) {
+	prev := latencyBucketIndex(latencyHistMin)
+	for d := latencyHistMin; d <= latencyHistMax; d *= 2 {
+		idx := latencyBucketIndex(d)
+		if idx < prev {
+			t.Fatalf("bucket index decreased at %v: %d < %d", d, idx, prev)
+		}
+		prev = idx
+	}
+}
+
+func TestLatencyBucketIndexClamped(t *testing.T) {
+	if idx := latencyBucketIndex(0); idx != 0 {
+		t.Errorf("expected bucket 0 for a zero duration, got %d", idx)
+	}
+	if idx := latencyBucketIndex(time.Hour); idx != latencyHistBuckets-1 {
+		t.Errorf("expected top bucket for a 1h duration, got %d want %d", idx, latencyHistBuckets-1)
+	}
+}
+
+func TestRecordAndGetLatencyPercentile(t *testing.T) {
+	m := NewPoolMetrics()
+
+	if got := m.GetLatencyPercentile(50); got != 0 {
+		t.Fatalf("expected 0 with no samples, got %v", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		m.RecordLatency(10 * time.Millisecond)
+	}
+	for i := 0; i < 100; i++ {
+		m.RecordLatency(100 * time.Millisecond)
+	}
+
+	// The histogram resolves a sample only to its bucket (4 per octave), and
+	// interpolation puts p50 at the top of the bucket holding 10ms.
+	p50 := m.GetLatencyPercentile(50)
+	lower, upper := latencyBucketRange(latencyBucketIndex(10 * time.Millisecond))
+	if float64(p50) < lower || float64(p50) > upper {
+		t.Errorf("expected p50 within 10ms's bucket [%v, %v], got %v", time.Duration(lower), time.Duration(upper), p50)
+	}
+
+	p99 := m.GetLatencyPercentile(99)
+	if p99 < 90*time.Millisecond || p99 > 110*time.Millisecond {
+		t.Errorf("expected p99 near 100ms, got %v", p99)
+	}
+}
+
+func TestRecordLatencyOutOfRangeClampsIntoEndBuckets(t *testing.T) {
+	m := NewPoolMetrics()
+
+	m.RecordLatency(0)
+	m.RecordLatency(time.Hour)
+
+	if got := m.latencyBuckets[0].Load(); got != 1 {
+		t.Errorf("expected 1 sample in the first bucket, got %d", got)
+	}
+	if got := m.latencyBuckets[latencyHistBuckets-1].Load(); got != 1 {
+		t.Errorf("expected 1 sample in the last bucket, got %d", got)
+	}
+}
@@ -0,0 +1,868 @@
+package pyproc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc/fs"
+)
+
+// ErrConnectionFailed marks a Call/CallStream failure caused by the
+// underlying connection itself breaking - a worker crash, a read/write
+// error, or Close() - rather than an ordinary application-level error
+// response. Pool.CallIdempotent checks for it via errors.Is to tell "safe to
+// retry on a sibling worker" apart from a response the worker itself
+// returned.
+var ErrConnectionFailed = errors.New("multiplexed conn: connection failed")
+
+// mpPendingRequest tracks one in-flight call on a MultiplexedConn: either a
+// single request/response (Call, via responseCh) or a multi-chunk response
+// (CallStream, via msgCh) - exactly one of the two is used, selected by
+// which method started the call. errCh delivers a connection-level failure
+// either way.
+type mpPendingRequest struct {
+	responseCh chan *protocol.Response // Call only, buffered 1
+	errCh      chan error              // buffered 1
+
+	// msgCh is the channel CallStream returns to its caller. It is written
+	// to and closed solely by streamForwarder, never by readLoop directly -
+	// readLoop only ever touches streamCh, so it can never race
+	// streamForwarder's close(msgCh) the way a direct send from readLoop
+	// once could (finishStream closing msgCh out from under an in-flight
+	// readLoop send).
+	msgCh chan Msg
+	// streamCh is readLoop's non-blocking delivery queue for STREAM_DATA
+	// chunks, drained by streamForwarder into msgCh. Buffering it here
+	// means a consumer slow to read msgCh backs up only this one stream's
+	// queue instead of blocking readLoop, the sole demuxer for every call
+	// sharing this connection; readLoop drops (with a log, and sets dropped)
+	// rather than blocking if streamCh itself fills up.
+	streamCh chan json.RawMessage
+	// dropped is set by readLoop when streamCh's buffer was full and a chunk
+	// had to be discarded, so finishStream can turn what would otherwise be
+	// a clean STREAM_END into a reported error instead of silently handing
+	// the caller an incomplete stream.
+	dropped atomic.Bool
+	// final carries finishStream's terminal Msg (buffered 1) for
+	// streamForwarder to deliver after draining streamCh, and done signals
+	// streamForwarder to stop waiting for more from streamCh.
+	final      chan Msg
+	done       chan struct{} // CallStream only, closed once by finishStream
+	streamOnce sync.Once     // guards done/final against being signalled twice
+}
+
+// errStreamChunksDropped is finishStream's final error when readLoop had to
+// discard at least one STREAM_DATA chunk because streamCh's buffer was full
+// - the stream is incomplete even though it otherwise ended cleanly.
+var errStreamChunksDropped = errors.New("multiplexed conn: one or more stream chunks were dropped (consumer too slow)")
+
+// Msg is one item delivered on the channel CallStream returns. Body is the
+// chunk's raw payload; Err is set only on the final item, when the stream
+// ended because of a worker-reported STREAM_ERROR or a connection failure
+// rather than a clean STREAM_END. The channel is always closed after
+// either.
+type Msg struct {
+	Body json.RawMessage
+	Err  error
+}
+
+// UnmarshalBody unmarshals m.Body into v.
+func (m Msg) UnmarshalBody(v interface{}) error {
+	return json.Unmarshal(m.Body, v)
+}
+
+// finishStream queues final for streamForwarder to deliver (if deliver) and
+// closes done, at most once - readLoop (on STREAM_END/STREAM_ERROR) and
+// CallStream's own ctx/error watcher can both race to end the same stream,
+// and only the first should act. It never touches msgCh itself - that's
+// streamForwarder's job alone, so there is exactly one writer/closer for it.
+func (pending *mpPendingRequest) finishStream(final Msg, deliver bool) {
+	pending.streamOnce.Do(func() {
+		if !deliver && pending.dropped.Load() {
+			// What would otherwise be a silent, clean STREAM_END actually
+			// lost chunks along the way - say so instead of handing the
+			// caller an incomplete stream with no indication anything's
+			// missing.
+			final, deliver = Msg{Err: errStreamChunksDropped}, true
+		}
+		if deliver {
+			pending.final <- final
+		}
+		close(pending.done)
+	})
+}
+
+// streamForwarder is CallStream's dedicated delivery goroutine. It drains
+// pending.streamCh (readLoop's non-blocking queue for STREAM_DATA chunks)
+// into pending.msgCh, so a caller slow to read msgCh backs up only this
+// stream's own queue instead of blocking readLoop - and it is msgCh's only
+// writer and closer, so it can never race readLoop trying to send on a
+// channel finishStream (called concurrently from the ctx/error watcher)
+// just closed.
+func (pending *mpPendingRequest) streamForwarder() {
+	defer close(pending.msgCh)
+	for {
+		select {
+		case raw := <-pending.streamCh:
+			select {
+			case pending.msgCh <- Msg{Body: raw}:
+			case <-pending.done:
+				// done fired while this send was blocked on a full msgCh;
+				// fall through to drainRemaining instead of returning here,
+				// so raw and anything else still queued (plus final) isn't
+				// silently dropped.
+				pending.drainRemaining(raw)
+				return
+			}
+		case <-pending.done:
+			pending.drainRemaining(nil)
+			return
+		}
+	}
+}
+
+// drainRemaining delivers whatever streamCh already had queued - starting
+// with first (a chunk streamForwarder had just pulled off streamCh but not
+// yet forwarded when done fired, or nil if there wasn't one) - followed by
+// finishStream's terminal Msg, if any, before streamForwarder closes msgCh.
+// It runs only once finishStream has already closed pending.done, so
+// streamCh only shrinks from here: nothing can block forever.
+func (pending *mpPendingRequest) drainRemaining(first json.RawMessage) {
+	if first != nil {
+		pending.msgCh <- Msg{Body: first}
+	}
+	for {
+		select {
+		case raw := <-pending.streamCh:
+			pending.msgCh <- Msg{Body: raw}
+		default:
+			select {
+			case final := <-pending.final:
+				pending.msgCh <- final
+			default:
+			}
+			return
+		}
+	}
+}
+
+// callbackRegistry holds the Go-side handlers a pool's workers may call back
+// into via pyproc.call_go(method, payload), and a semaphore bounding how many
+// such inbound calls may execute at once across every worker sharing it.
+type callbackRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]CallbackHandler
+	sem      chan struct{}
+}
+
+// newCallbackRegistry builds a registry allowing up to maxConcurrency
+// in-flight callback invocations at once; maxConcurrency <= 0 defaults to 10.
+func newCallbackRegistry(maxConcurrency int) *callbackRegistry {
+	if maxConcurrency <= 0 {
+		maxConcurrency = 10
+	}
+	return &callbackRegistry{
+		handlers: make(map[string]CallbackHandler),
+		sem:      make(chan struct{}, maxConcurrency),
+	}
+}
+
+func (r *callbackRegistry) register(method string, handler CallbackHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[method] = handler
+}
+
+func (r *callbackRegistry) lookup(method string) (CallbackHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.handlers[method]
+	return handler, ok
+}
+
+// MultiplexedConn is a single long-lived UDS connection to a worker that
+// multiplexes many concurrent Call()s over it, keyed by Request.ID /
+// Response.ID. It replaces keeping MaxInFlight separate connections per
+// worker purely for concurrency: one MultiplexedConn sustains as much
+// concurrency as the worker can actually process, with no head-of-line
+// blocking between unrelated requests. Writes are serialized through a
+// single writer goroutine; a single reader goroutine demultiplexes
+// responses back to their caller by ID. On a connection error, all pending
+// callers are unblocked with an error and the connection is redialed with
+// backoff.
+//
+// Every frame payload is a protocol.Message envelope, not a bare Request or
+// Response, so the worker can distinguish a regular call from a
+// MessageTypeCancellation signal sent when a caller's ctx is cancelled or
+// times out mid-request. Cancellation never poisons the connection: the
+// caller's Call() simply returns ctx.Err() and the request ID is freed for
+// reuse, whether or not the worker manages to stop in time.
+//
+// The same connection also carries calls in the other direction: a worker
+// may issue pyproc.call_go("method", payload), which arrives as a
+// MessageTypeRequest frame whose ID is not in pending (Go only ever waits on
+// IDs it allocated itself). Go allocates its own call IDs as even numbers so
+// they can never collide with a worker's IDs, which are expected to be odd.
+// Such frames are dispatched to callbacks, the pool's shared handler
+// registry, and answered with a MessageTypeResponse frame carrying the same
+// ID.
+type MultiplexedConn struct {
+	socketPath string
+	logger     *Logger
+	backoff    BackoffStrategy
+	callbacks  *callbackRegistry // nil means the worker has nothing to call back into
+
+	// codec encodes/decodes every Message envelope this conn sends and
+	// receives; codecID is the matching framing.CodecID* tag stamped on
+	// each outgoing Frame so a receiver sharing the pool across codecs
+	// (e.g. during a rollout) can tell which one to use. Defaults to JSON,
+	// unchanged from this type's behavior before WithCodec existed.
+	codec   Codec
+	codecID byte
+
+	// fsMux, once installed by Mount, receives every inbound frame tagged
+	// framing.CodecIDFileOp instead of the usual protocol.Message decode
+	// path - a worker's file-op responses never go through codec at all.
+	// nil means this connection has nothing mounted.
+	fsMux *fs.Mux
+
+	connMu sync.RWMutex
+	conn   net.Conn
+	framer *framing.Framer
+
+	nextID atomic.Uint64
+
+	pendingMu sync.Mutex
+	pending   map[uint64]*mpPendingRequest
+
+	writeCh chan *framing.Frame
+
+	closed       atomic.Bool // set once Close() is called; suppresses reconnection
+	reconnecting atomic.Bool // guards against write+read errors both triggering reconnect
+	stopCh       chan struct{}
+	stopOnce     sync.Once
+	wg           sync.WaitGroup
+}
+
+// NewMultiplexedConn dials socketPath and starts its writer/reader
+// goroutines. backoff governs reconnect delays after a connection error; a
+// nil backoff falls back to NewBackoffStrategy(BackoffExponentialJitter, ...).
+// callbacks, if non-nil, lets the worker on the other end of socketPath call
+// back into Go-registered handlers over this same connection; pass nil for a
+// connection that should reject any such inbound request. codec optionally
+// overrides the envelope codec (see WithCodec); omitted or nil defaults to
+// JSON, this type's behavior before WithCodec existed.
+func NewMultiplexedConn(socketPath string, logger *Logger, backoff BackoffStrategy, callbacks *callbackRegistry, codec ...Codec) (*MultiplexedConn, error) {
+	if backoff == nil {
+		backoff = NewBackoffStrategy(BackoffExponentialJitter, RestartConfig{})
+	}
+
+	c := &MultiplexedConn{
+		socketPath: socketPath,
+		logger:     logger,
+		backoff:    backoff,
+		callbacks:  callbacks,
+		pending:    make(map[uint64]*mpPendingRequest),
+		writeCh:    make(chan *framing.Frame, 64),
+		stopCh:     make(chan struct{}),
+	}
+	c.setCodec(firstCodec(codec))
+
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+
+	c.spawnLoops()
+	return c, nil
+}
+
+// NewMultiplexedConnFromConn wraps an already-established connection (e.g.
+// a Worker's TransportModeSocketPair net.FileConn) instead of dialing a
+// socket path. There's no rendezvous point to redial against if conn fails,
+// so reconnection is disabled: a connection error just fails every pending
+// and future call, the same as after Close(). codec optionally overrides the
+// envelope codec (see WithCodec); omitted or nil defaults to JSON.
+func NewMultiplexedConnFromConn(conn net.Conn, logger *Logger, callbacks *callbackRegistry, codec ...Codec) (*MultiplexedConn, error) {
+	c := &MultiplexedConn{
+		logger:    logger,
+		backoff:   NewBackoffStrategy(BackoffExponentialJitter, RestartConfig{}),
+		callbacks: callbacks,
+		pending:   make(map[uint64]*mpPendingRequest),
+		writeCh:   make(chan *framing.Frame, 64),
+		stopCh:    make(chan struct{}),
+		conn:      conn,
+		framer:    framing.NewEnhancedFramer(conn),
+	}
+	c.setCodec(firstCodec(codec))
+	// No socketPath means dial() can't redial; reconnecting is pinned so
+	// handleConnError gives up immediately instead of retrying forever.
+	c.reconnecting.Store(true)
+
+	c.spawnLoops()
+	return c, nil
+}
+
+// firstCodec returns codec[0], or nil if the caller passed none - the
+// variadic equivalent of an optional trailing parameter.
+func firstCodec(codec []Codec) Codec {
+	if len(codec) == 0 {
+		return nil
+	}
+	return codec[0]
+}
+
+// setCodec installs codec (defaulting to &JSONCodec{} when nil) and caches
+// the framing.CodecID* tag that matches it, so every Frame this conn writes
+// is stamped with the codec the receiver needs to decode it.
+func (c *MultiplexedConn) setCodec(codec Codec) {
+	if codec == nil {
+		codec = &JSONCodec{}
+	}
+	c.codec = codec
+	c.codecID = frameCodecID(codec)
+}
+
+// frameCodecID maps a Codec to the framing.CodecID* byte Frame headers carry,
+// so mixed deployments can tell JSON, msgpack, and protobuf frames apart on
+// the wire. Codecs outside those three (e.g. ArrowCodec) fall back to
+// CodecIDJSON's slot purely as frame-header metadata - their actual wire
+// format is whatever the codec itself produces, unaffected by this tag.
+func frameCodecID(codec Codec) byte {
+	switch codec.Name() {
+	case "msgpack":
+		return framing.CodecIDMsgpack
+	case "protobuf":
+		return framing.CodecIDProtobuf
+	default:
+		return framing.CodecIDJSON
+	}
+}
+
+func (c *MultiplexedConn) dial() error {
+	conn, err := net.Dial("unix", c.socketPath)
+	if err != nil {
+		return fmt.Errorf("multiplexed conn: failed to connect to %s: %w", c.socketPath, err)
+	}
+
+	c.connMu.Lock()
+	c.conn = conn
+	c.framer = framing.NewEnhancedFramer(conn)
+	c.connMu.Unlock()
+
+	return nil
+}
+
+func (c *MultiplexedConn) spawnLoops() {
+	c.wg.Add(2)
+	go c.writeLoop()
+	go c.readLoop()
+}
+
+// Call sends req over the connection and waits for its matching response,
+// ctx cancellation, or a connection error - whichever happens first.
+func (c *MultiplexedConn) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	if c.closed.Load() {
+		return nil, fmt.Errorf("multiplexed conn: closed")
+	}
+
+	// Even IDs are reserved for Go-originated calls so they can never
+	// collide with a worker's own (odd) IDs for pyproc.call_go requests.
+	id := c.nextID.Add(1) * 2
+	req.ID = id
+
+	pending := &mpPendingRequest{
+		responseCh: make(chan *protocol.Response, 1),
+		errCh:      make(chan error, 1),
+	}
+
+	c.pendingMu.Lock()
+	c.pending[id] = pending
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	reqData, err := c.encodeMessage(protocol.MessageTypeRequest, req)
+	if err != nil {
+		return nil, fmt.Errorf("multiplexed conn: failed to marshal request: %w", err)
+	}
+	frame := framing.NewFrameWithCodec(id, reqData, c.codecID)
+
+	select {
+	case c.writeCh <- frame:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case resp := <-pending.responseCh:
+		return resp, nil
+	case err := <-pending.errCh:
+		return nil, err
+	case <-ctx.Done():
+		// The request is already on the wire. Tell the worker to stop rather
+		// than silently abandoning it: without this it keeps running (e.g. a
+		// slow model inference) and its eventual response arrives for an ID
+		// nobody is listening for anymore.
+		c.sendCancellation(id, ctx.Err())
+		return nil, ctx.Err()
+	}
+}
+
+// mountFS installs mux as this connection's file-op router, so inbound
+// frames tagged framing.CodecIDFileOp are dispatched to it - see Pool.Mount.
+// A connection supports at most one mount at a time.
+func (c *MultiplexedConn) mountFS(mux *fs.Mux) {
+	c.fsMux = mux
+}
+
+// SendFileOp implements fs.Transport: it writes payload as a frame tagged
+// framing.CodecIDFileOp, bypassing c.codec entirely, so the worker's
+// file-op server (not a protocol.Codec) decodes it.
+func (c *MultiplexedConn) SendFileOp(ctx context.Context, requestID uint64, payload []byte) error {
+	if c.closed.Load() {
+		return fmt.Errorf("multiplexed conn: closed")
+	}
+
+	select {
+	case c.writeCh <- framing.NewFrameWithCodec(requestID, payload, framing.CodecIDFileOp):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Notify sends req as a fire-and-forget MessageTypeNotification frame: it
+// returns as soon as the frame is on the wire, without registering a
+// pending entry or waiting for any reply - the worker is expected to answer
+// nothing. Useful for telemetry or logging calls where the caller has no
+// use for a result and would rather not pay for a round trip.
+func (c *MultiplexedConn) Notify(ctx context.Context, req *protocol.Request) error {
+	if c.closed.Load() {
+		return fmt.Errorf("multiplexed conn: closed")
+	}
+
+	id := c.nextID.Add(1) * 2
+	req.ID = id
+
+	data, err := c.encodeMessage(protocol.MessageTypeNotification, req)
+	if err != nil {
+		return fmt.Errorf("multiplexed conn: failed to marshal notification: %w", err)
+	}
+
+	select {
+	case c.writeCh <- framing.NewFrameWithCodec(id, data, c.codecID):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CallStream sends req the same way Call does, but expects the worker to
+// reply with zero or more MessageTypeStreamData frames instead of one
+// MessageTypeResponse, terminated by MessageTypeStreamEnd or
+// MessageTypeStreamError - e.g. streaming token generation from an LLM,
+// progressive image inference, or incremental data pipeline output. Unlike
+// Call, CallStream returns as soon as the request is on the wire; readLoop
+// delivers chunks onto the returned channel as they arrive, and it's closed
+// once the stream ends (cleanly, on error, or because ctx was cancelled).
+func (c *MultiplexedConn) CallStream(ctx context.Context, req *protocol.Request) (<-chan Msg, error) {
+	if c.closed.Load() {
+		return nil, fmt.Errorf("multiplexed conn: closed")
+	}
+
+	id := c.nextID.Add(1) * 2
+	req.ID = id
+
+	pending := &mpPendingRequest{
+		errCh:    make(chan error, 1),
+		msgCh:    make(chan Msg, 16),
+		streamCh: make(chan json.RawMessage, 64),
+		final:    make(chan Msg, 1),
+		done:     make(chan struct{}),
+	}
+	go pending.streamForwarder()
+
+	c.pendingMu.Lock()
+	c.pending[id] = pending
+	c.pendingMu.Unlock()
+
+	reqData, err := c.encodeMessage(protocol.MessageTypeRequest, req)
+	if err != nil {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		pending.finishStream(Msg{}, false) // unblocks and retires streamForwarder
+		return nil, fmt.Errorf("multiplexed conn: failed to marshal request: %w", err)
+	}
+
+	select {
+	case c.writeCh <- framing.NewFrameWithCodec(id, reqData, c.codecID):
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		pending.finishStream(Msg{}, false) // unblocks and retires streamForwarder
+		return nil, ctx.Err()
+	}
+
+	// readLoop queues STREAM_DATA onto pending.streamCh (for streamForwarder
+	// to deliver) and ends the stream itself on STREAM_END/STREAM_ERROR
+	// (closing pending.done, which streamForwarder is also watching); this
+	// goroutine only needs to step in if ctx is cancelled first or the
+	// connection fails outright, neither of which readLoop has any other way
+	// to learn about.
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.sendCancellation(id, ctx.Err())
+			c.pendingMu.Lock()
+			delete(c.pending, id)
+			c.pendingMu.Unlock()
+			pending.finishStream(Msg{Err: ctx.Err()}, true)
+		case err := <-pending.errCh:
+			pending.finishStream(Msg{Err: err}, true)
+		case <-pending.done:
+			// readLoop already ended the stream; nothing left to do.
+		}
+	}()
+
+	return pending.msgCh, nil
+}
+
+// sendCancellation best-effort notifies the worker that reqID should stop.
+// It never blocks the caller: if the write queue is backed up, the
+// cancellation is dropped and the worker simply runs the request to
+// completion, same as before this existed.
+func (c *MultiplexedConn) sendCancellation(reqID uint64, reason error) {
+	cancelReq := protocol.NewCancellationRequest(reqID, reason.Error())
+	data, err := c.encodeMessage(protocol.MessageTypeCancellation, cancelReq)
+	if err != nil {
+		c.logger.Warn("multiplexed conn: failed to encode cancellation", "id", reqID, "error", err)
+		return
+	}
+
+	select {
+	case c.writeCh <- framing.NewFrameWithCodec(reqID, data, c.codecID):
+	default:
+		c.logger.Warn("multiplexed conn: dropped cancellation, write queue full", "id", reqID)
+	}
+}
+
+// encodeMessage wraps payload in the Message envelope the worker expects and
+// serializes both the payload and the envelope with c.codec, so a non-JSON
+// codec (see WithCodec) governs the whole frame, not just Request/Response's
+// own fixed-JSON Body field.
+func (c *MultiplexedConn) encodeMessage(msgType protocol.MessageType, payload interface{}) ([]byte, error) {
+	payloadBytes, err := c.codec.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return c.codec.Marshal(&protocol.Message{Type: msgType, Payload: payloadBytes})
+}
+
+// writeLoop is the sole goroutine that writes to the connection, so
+// concurrent Call()s never interleave frames on the wire.
+func (c *MultiplexedConn) writeLoop() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case frame := <-c.writeCh:
+			c.connMu.RLock()
+			framer := c.framer
+			c.connMu.RUnlock()
+
+			if err := framer.WriteFrame(frame); err != nil {
+				c.logger.Error("multiplexed conn: write failed", "error", err)
+				c.handleConnError(err)
+				return
+			}
+		}
+	}
+}
+
+// readLoop reads responses and delivers each to its pending Call() by ID.
+// It never blocks: responseCh has buffer 1 and receives exactly one value.
+func (c *MultiplexedConn) readLoop() {
+	defer c.wg.Done()
+
+	for {
+		c.connMu.RLock()
+		framer := c.framer
+		c.connMu.RUnlock()
+
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			select {
+			case <-c.stopCh:
+				return
+			default:
+			}
+			c.logger.Error("multiplexed conn: read failed", "error", err)
+			c.handleConnError(err)
+			return
+		}
+
+		if frame.Header.CodecID == framing.CodecIDFileOp {
+			// Not a protocol.Message envelope at all - a fs.Mux response
+			// (see Mount), dispatched by its own RequestID namespace rather
+			// than c.pending.
+			if c.fsMux != nil {
+				c.fsMux.Dispatch(frame.Header.RequestID, append([]byte(nil), frame.Payload...))
+			}
+			framer.ReleaseFrame(frame)
+			continue
+		}
+
+		var msg protocol.Message
+		err = c.codec.Unmarshal(frame.Payload, &msg)
+		// msg.Payload is c.codec.Unmarshal's own copy, not a view into
+		// frame's bytes, so the pooled frame can be released right away.
+		framer.ReleaseFrame(frame)
+		if err != nil {
+			c.logger.Error("multiplexed conn: failed to unmarshal message", "error", err)
+			continue
+		}
+
+		// STREAM_DATA doesn't end the call, so the pending entry stays in
+		// the map until STREAM_END/STREAM_ERROR (or MessageTypeResponse,
+		// for a plain Call) arrives - everything else deletes it here.
+		id := frame.Header.RequestID
+		c.pendingMu.Lock()
+		pending, isPending := c.pending[id]
+		if isPending && msg.Type != protocol.MessageTypeStreamData {
+			delete(c.pending, id)
+		}
+		c.pendingMu.Unlock()
+
+		if !isPending {
+			// Not a response to a call we made: either a worker-initiated
+			// pyproc.call_go request, or a stray frame for an ID we no
+			// longer care about (e.g. one we already gave up on).
+			c.handleInboundRequest(id, &msg)
+			continue
+		}
+
+		switch msg.Type {
+		case protocol.MessageTypeResponse:
+			var resp protocol.Response
+			if err := c.codec.Unmarshal(msg.Payload, &resp); err != nil {
+				c.logger.Error("multiplexed conn: failed to unmarshal response", "error", err)
+				continue
+			}
+			resp.ID = id
+			pending.responseCh <- &resp
+
+		case protocol.MessageTypeStreamData:
+			var chunk protocol.Response
+			if err := c.codec.Unmarshal(msg.Payload, &chunk); err != nil {
+				c.logger.Error("multiplexed conn: failed to unmarshal stream chunk", "id", id, "error", err)
+				continue
+			}
+			// Never block readLoop itself on a slow stream consumer -
+			// streamForwarder drains this queue into pending.msgCh on its
+			// own goroutine, so one stalled CallStream reader can't stall
+			// every other in-flight Call sharing this connection.
+			select {
+			case pending.streamCh <- chunk.Body:
+			default:
+				pending.dropped.Store(true)
+				c.logger.Warn("multiplexed conn: dropped stream chunk, consumer too slow", "id", id)
+			}
+
+		case protocol.MessageTypeStreamEnd:
+			pending.finishStream(Msg{}, false)
+
+		case protocol.MessageTypeStreamError:
+			var resp protocol.Response
+			if err := c.codec.Unmarshal(msg.Payload, &resp); err != nil {
+				c.logger.Error("multiplexed conn: failed to unmarshal stream error", "id", id, "error", err)
+				pending.finishStream(Msg{Err: fmt.Errorf("multiplexed conn: malformed stream error: %w", err)}, true)
+				continue
+			}
+			pending.finishStream(Msg{Err: resp.Error()}, true)
+
+		default:
+			c.logger.Warn("multiplexed conn: unexpected message type for pending request", "id", id, "type", msg.Type)
+		}
+	}
+}
+
+// handleInboundRequest answers a frame whose ID did not match any pending
+// Go-originated call. A worker calling pyproc.call_go(method, payload) lands
+// here as a MessageTypeRequest; anything else for an unrecognized ID is
+// logged and dropped, since Go never sends anything a worker would reply to
+// with an unsolicited frame.
+func (c *MultiplexedConn) handleInboundRequest(id uint64, msg *protocol.Message) {
+	if msg.Type != protocol.MessageTypeRequest {
+		c.logger.Warn("multiplexed conn: message for unknown id", "id", id, "type", msg.Type)
+		return
+	}
+
+	var req protocol.Request
+	if err := c.codec.Unmarshal(msg.Payload, &req); err != nil {
+		c.logger.Error("multiplexed conn: failed to unmarshal inbound request", "id", id, "error", err)
+		return
+	}
+
+	if c.callbacks == nil {
+		c.writeCallbackResponse(id, nil, fmt.Errorf("multiplexed conn: no Go callback handlers registered"))
+		return
+	}
+
+	handler, ok := c.callbacks.lookup(req.Method)
+	if !ok {
+		c.writeCallbackResponse(id, nil, fmt.Errorf("multiplexed conn: no such Go handler: %s", req.Method))
+		return
+	}
+
+	select {
+	case c.callbacks.sem <- struct{}{}:
+	case <-c.stopCh:
+		return
+	}
+
+	go func() {
+		defer func() { <-c.callbacks.sem }()
+		result, err := callCallbackSafely(handler, req.Body)
+		c.writeCallbackResponse(id, result, err)
+	}()
+}
+
+// callCallbackSafely invokes handler, converting a panic into an error
+// response so one misbehaving Go handler can't take down the reader's
+// dispatch goroutine or otherwise affect unrelated in-flight calls.
+func callCallbackSafely(handler CallbackHandler, payload json.RawMessage) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("callback handler panicked: %v", r)
+		}
+	}()
+	return handler(context.Background(), payload)
+}
+
+// writeCallbackResponse sends a MessageTypeResponse frame for id, the result
+// of handling an inbound pyproc.call_go request.
+func (c *MultiplexedConn) writeCallbackResponse(id uint64, result interface{}, callErr error) {
+	var resp *protocol.Response
+	if callErr != nil {
+		resp = protocol.NewErrorResponse(id, callErr)
+	} else {
+		var err error
+		resp, err = protocol.NewResponse(id, result)
+		if err != nil {
+			c.logger.Error("multiplexed conn: failed to build callback response", "id", id, "error", err)
+			resp = protocol.NewErrorResponse(id, err)
+		}
+	}
+
+	data, err := c.encodeMessage(protocol.MessageTypeResponse, resp)
+	if err != nil {
+		c.logger.Error("multiplexed conn: failed to encode callback response", "id", id, "error", err)
+		return
+	}
+
+	select {
+	case c.writeCh <- framing.NewFrameWithCodec(id, data, c.codecID):
+	case <-c.stopCh:
+	}
+}
+
+// handleConnError fails every pending Call() and, unless Close() already ran,
+// redials the worker with backoff and restarts the writer/reader goroutines.
+func (c *MultiplexedConn) handleConnError(connErr error) {
+	c.failPending(fmt.Errorf("%w: %w", ErrConnectionFailed, connErr))
+
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+	if conn != nil {
+		conn.Close()
+	}
+
+	if c.closed.Load() {
+		return
+	}
+
+	if !c.reconnecting.CompareAndSwap(false, true) {
+		return // the write or read loop's counterpart is already reconnecting
+	}
+	defer c.reconnecting.Store(false)
+
+	attempts := 0
+	for !c.closed.Load() {
+		delay := c.backoff.NextDelay(attempts)
+		select {
+		case <-c.stopCh:
+			return
+		case <-time.After(delay):
+		}
+
+		if err := c.dial(); err != nil {
+			c.logger.Warn("multiplexed conn: reconnect attempt failed", "error", err, "attempt", attempts+1)
+			attempts++
+			continue
+		}
+
+		c.logger.Info("multiplexed conn: reconnected", "attempts", attempts+1)
+		c.spawnLoops()
+		return
+	}
+}
+
+func (c *MultiplexedConn) failPending(err error) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	for id, pending := range c.pending {
+		select {
+		case pending.errCh <- err:
+		default:
+		}
+		delete(c.pending, id)
+	}
+}
+
+// Close shuts down the connection and its goroutines, and suppresses
+// reconnection.
+func (c *MultiplexedConn) Close() error {
+	var closeErr error
+
+	c.stopOnce.Do(func() {
+		c.closed.Store(true)
+		close(c.stopCh)
+
+		c.connMu.RLock()
+		conn := c.conn
+		c.connMu.RUnlock()
+		if conn != nil {
+			closeErr = conn.Close()
+		}
+
+		c.failPending(fmt.Errorf("multiplexed conn: closed"))
+	})
+
+	c.wg.Wait()
+	return closeErr
+}
+
+// IsHealthy reports whether the connection is usable (not explicitly closed;
+// a mid-reconnect connection is still considered healthy since callers just
+// block in Call() until it recovers).
+func (c *MultiplexedConn) IsHealthy() bool {
+	return !c.closed.Load()
+}
@@ -0,0 +1,841 @@
+package pyproc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// serveEchoServer accepts a single connection on ln and answers each
+// MessageTypeRequest frame with a successful response envelope, delaying
+// requests whose method is "slow" by a few milliseconds to exercise
+// demultiplexing. Any MessageTypeCancellation frames it receives are
+// recorded in cancelled, keyed by request ID, and the matching in-flight
+// request's response is suppressed.
+func serveEchoServer(t *testing.T, ln net.Listener, cancelled *sync.Map) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	framer := framing.NewEnhancedFramer(conn)
+
+	var wg sync.WaitGroup
+	var writeMu sync.Mutex
+
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			break
+		}
+
+		msg, err := protocol.UnwrapMessage(frame.Payload)
+		if err != nil {
+			continue
+		}
+
+		if msg.Type == protocol.MessageTypeCancellation {
+			var cancelReq protocol.CancellationRequest
+			if err := json.Unmarshal(msg.Payload, &cancelReq); err == nil && cancelled != nil {
+				cancelled.Store(cancelReq.ID, cancelReq.Reason)
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(frame *framing.Frame, msg *protocol.Message) {
+			defer wg.Done()
+
+			var req protocol.Request
+			if err := json.Unmarshal(msg.Payload, &req); err != nil {
+				return
+			}
+
+			if req.Method == "slow" {
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			if cancelled != nil {
+				if _, wasCancelled := cancelled.Load(req.ID); wasCancelled {
+					return
+				}
+			}
+
+			resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"method": req.Method})
+			if err != nil {
+				return
+			}
+			respData, err := json.Marshal(protocol.Message{Type: protocol.MessageTypeResponse, Payload: mustMarshal(t, resp)})
+			if err != nil {
+				return
+			}
+
+			writeMu.Lock()
+			_ = framer.WriteFrame(framing.NewFrame(frame.Header.RequestID, respData))
+			writeMu.Unlock()
+		}(frame, msg)
+	}
+
+	wg.Wait()
+	conn.Close()
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %v: %v", v, err)
+	}
+	return data
+}
+
+func TestMultiplexedConnCallRoundTrip(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveEchoServer(t, ln, nil)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	req, err := protocol.NewRequest(0, "echo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	resp, err := conn.Call(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK {
+		t.Fatalf("expected OK response, got error: %s", resp.ErrorMsg)
+	}
+}
+
+func TestMultiplexedConnConcurrentCallsDemultiplex(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveEchoServer(t, ln, nil)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	methods := []string{"slow", "fast", "fast", "slow", "fast"}
+	var wg sync.WaitGroup
+	errs := make([]error, len(methods))
+
+	for i, method := range methods {
+		wg.Add(1)
+		go func(i int, method string) {
+			defer wg.Done()
+			req, err := protocol.NewRequest(0, method, map[string]interface{}{})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			resp, err := conn.Call(context.Background(), req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if !resp.OK {
+				errs[i] = resp.Error()
+			}
+		}(i, method)
+	}
+
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("call %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestMultiplexedConnCallContextCancelled(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveEchoServer(t, ln, nil)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err := protocol.NewRequest(0, "echo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	if _, err := conn.Call(ctx, req); err == nil {
+		t.Fatal("expected error from cancelled context")
+	}
+}
+
+func TestMultiplexedConnCancelMidExecutionDoesNotPoisonConn(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	var cancelled sync.Map
+	go serveEchoServer(t, ln, &cancelled)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	req, err := protocol.NewRequest(0, "slow", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	if _, err := conn.Call(ctx, req); err == nil {
+		t.Fatal("expected error from request-timeout cancellation")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := cancelled.Load(req.ID); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, ok := cancelled.Load(req.ID); !ok {
+		t.Fatal("expected server to observe a cancellation for the request ID")
+	}
+
+	// The connection must still be usable afterward.
+	okReq, err := protocol.NewRequest(0, "fast", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	resp, err := conn.Call(context.Background(), okReq)
+	if err != nil {
+		t.Fatalf("Call after cancellation failed: %v", err)
+	}
+	if !resp.OK {
+		t.Fatalf("expected OK response, got error: %s", resp.ErrorMsg)
+	}
+}
+
+func TestMultiplexedConnCloseFailsPending(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveEchoServer(t, ln, nil)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if conn.IsHealthy() {
+		t.Error("expected IsHealthy to return false after Close")
+	}
+
+	req, err := protocol.NewRequest(0, "echo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	if _, err := conn.Call(context.Background(), req); err == nil {
+		t.Error("expected Call on closed conn to fail")
+	}
+}
+
+// serveCallGoServer accepts a single connection, sends one inbound
+// MessageTypeRequest frame (as if a worker had called pyproc.call_go) using
+// an odd ID, and reports the decoded response frame on respCh.
+func serveCallGoServer(t *testing.T, ln net.Listener, req *protocol.Request, respCh chan<- *protocol.Response) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	reqData := mustMarshal(t, protocol.Message{Type: protocol.MessageTypeRequest, Payload: mustMarshal(t, req)})
+	if err := framer.WriteFrame(framing.NewFrame(req.ID, reqData)); err != nil {
+		t.Errorf("failed to write inbound request frame: %v", err)
+		return
+	}
+
+	frame, err := framer.ReadFrame()
+	if err != nil {
+		t.Errorf("failed to read callback response frame: %v", err)
+		return
+	}
+	msg, err := protocol.UnwrapMessage(frame.Payload)
+	if err != nil {
+		t.Errorf("failed to unwrap callback response: %v", err)
+		return
+	}
+	var resp protocol.Response
+	if err := json.Unmarshal(msg.Payload, &resp); err != nil {
+		t.Errorf("failed to unmarshal callback response: %v", err)
+		return
+	}
+	resp.ID = frame.Header.RequestID
+	respCh <- &resp
+}
+
+func TestMultiplexedConnDispatchesInboundCallback(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	req, err := protocol.NewRequest(1, "get_config", map[string]interface{}{"key": "region"})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	respCh := make(chan *protocol.Response, 1)
+	go serveCallGoServer(t, ln, req, respCh)
+
+	callbacks := newCallbackRegistry(1)
+	callbacks.register("get_config", func(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(payload, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"value": "us-" + params["key"].(string)}, nil
+	})
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, callbacks)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	select {
+	case resp := <-respCh:
+		if !resp.OK {
+			t.Fatalf("expected OK callback response, got error: %s", resp.ErrorMsg)
+		}
+		var result map[string]interface{}
+		if err := resp.UnmarshalBody(&result); err != nil {
+			t.Fatalf("UnmarshalBody failed: %v", err)
+		}
+		if result["value"] != "us-region" {
+			t.Errorf("expected value=us-region, got %v", result["value"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for callback response")
+	}
+}
+
+func TestMultiplexedConnUnregisteredCallbackReturnsError(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	req, err := protocol.NewRequest(1, "no_such_handler", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	respCh := make(chan *protocol.Response, 1)
+	go serveCallGoServer(t, ln, req, respCh)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, newCallbackRegistry(1))
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	select {
+	case resp := <-respCh:
+		if resp.OK {
+			t.Fatal("expected an error response for an unregistered method")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for callback response")
+	}
+}
+
+// serveStreamServer accepts a single connection and answers every request
+// whose method is "stream" with n STREAM_DATA chunks followed by either
+// STREAM_END (failOnChunk < 0) or STREAM_ERROR (failOnChunk == the chunk
+// index that should report an error instead of finishing cleanly).
+func serveStreamServer(t *testing.T, ln net.Listener, n int, failOnChunk int) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	frame, err := framer.ReadFrame()
+	if err != nil {
+		return
+	}
+	msg, err := protocol.UnwrapMessage(frame.Payload)
+	if err != nil {
+		return
+	}
+	var req protocol.Request
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		if i == failOnChunk {
+			resp := protocol.NewErrorResponse(req.ID, fmt.Errorf("chunk %d failed", i))
+			data := mustMarshal(t, protocol.Message{Type: protocol.MessageTypeStreamError, Payload: mustMarshal(t, resp)})
+			_ = framer.WriteFrame(framing.NewFrame(req.ID, data))
+			return
+		}
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"chunk": i})
+		if err != nil {
+			return
+		}
+		data := mustMarshal(t, protocol.Message{Type: protocol.MessageTypeStreamData, Payload: mustMarshal(t, resp)})
+		if err := framer.WriteFrame(framing.NewFrame(req.ID, data)); err != nil {
+			return
+		}
+	}
+
+	data := mustMarshal(t, protocol.Message{Type: protocol.MessageTypeStreamEnd, Payload: json.RawMessage("{}")})
+	_ = framer.WriteFrame(framing.NewFrame(req.ID, data))
+}
+
+// serveSlowStreamServer sends STREAM_DATA chunks for req.ID indefinitely, a
+// few milliseconds apart, stopping only once a write fails (the client
+// closed the connection) - used to race a ctx cancellation against readLoop
+// still delivering chunks for the same stream.
+func serveSlowStreamServer(t *testing.T, ln net.Listener) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	frame, err := framer.ReadFrame()
+	if err != nil {
+		return
+	}
+	msg, err := protocol.UnwrapMessage(frame.Payload)
+	if err != nil {
+		return
+	}
+	var req protocol.Request
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return
+	}
+
+	for i := 0; ; i++ {
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"chunk": i})
+		if err != nil {
+			return
+		}
+		data := mustMarshal(t, protocol.Message{Type: protocol.MessageTypeStreamData, Payload: mustMarshal(t, resp)})
+		if err := framer.WriteFrame(framing.NewFrame(req.ID, data)); err != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestMultiplexedConnCallStreamContextCancelledMidStream cancels the caller's
+// ctx while the server is still actively sending STREAM_DATA chunks, so
+// readLoop's delivery of a chunk can race CallStream's ctx watcher calling
+// finishStream. Before the streamCh/streamForwarder split, this raced
+// readLoop sending directly on pending.msgCh against finishStream closing
+// it, a send-on-closed-channel panic; this only guards against it not
+// deadlocking or panicking, since readLoop's drop-on-full policy means no
+// particular number of chunks is guaranteed to arrive first.
+func TestMultiplexedConnCallStreamContextCancelledMidStream(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveSlowStreamServer(t, ln)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	req, err := protocol.NewRequest(0, "stream", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	msgCh, err := conn.CallStream(ctx, req)
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	// Draining to completion (rather than panicking) is the assertion -
+	// a closed channel range finishes cleanly either way.
+	var lastErr error
+	for msg := range msgCh {
+		lastErr = msg.Err
+	}
+	if lastErr == nil {
+		t.Fatal("expected the stream to end with ctx's error")
+	}
+}
+
+func TestMultiplexedConnCallStreamDeliversChunksThenEnds(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveStreamServer(t, ln, 3, -1)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	req, err := protocol.NewRequest(0, "stream", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	msgCh, err := conn.CallStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	var chunks []int
+	for msg := range msgCh {
+		if msg.Err != nil {
+			t.Fatalf("unexpected error chunk: %v", msg.Err)
+		}
+		var body map[string]int
+		if err := msg.UnmarshalBody(&body); err != nil {
+			t.Fatalf("UnmarshalBody failed: %v", err)
+		}
+		chunks = append(chunks, body["chunk"])
+	}
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d: %v", len(chunks), chunks)
+	}
+	for i, c := range chunks {
+		if c != i {
+			t.Errorf("chunk %d: expected value %d, got %d", i, i, c)
+		}
+	}
+}
+
+func TestMultiplexedConnCallStreamSurfacesStreamError(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	go serveStreamServer(t, ln, 3, 1)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	req, err := protocol.NewRequest(0, "stream", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	msgCh, err := conn.CallStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	var gotChunks int
+	var lastErr error
+	for msg := range msgCh {
+		if msg.Err != nil {
+			lastErr = msg.Err
+			continue
+		}
+		gotChunks++
+	}
+
+	if gotChunks != 1 {
+		t.Fatalf("expected 1 chunk before the error, got %d", gotChunks)
+	}
+	if lastErr == nil {
+		t.Fatal("expected a final error chunk from STREAM_ERROR")
+	}
+}
+
+func TestMultiplexedConnCallStreamContextCancelled(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+	// A listener that never replies: the stream should end via ctx
+	// cancellation rather than STREAM_END/STREAM_ERROR.
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		framing.NewEnhancedFramer(conn).ReadFrame()
+	}()
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	req, err := protocol.NewRequest(0, "stream", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	msgCh, err := conn.CallStream(ctx, req)
+	if err != nil {
+		t.Fatalf("CallStream failed: %v", err)
+	}
+
+	var lastErr error
+	for msg := range msgCh {
+		lastErr = msg.Err
+	}
+	if lastErr == nil {
+		t.Fatal("expected the stream to end with ctx's error")
+	}
+}
+
+// serveEchoServerWithCodec is serveEchoServer's envelope encoded with codec
+// instead of always JSON, so WithCodec's round trip can be exercised against
+// a peer that actually speaks the configured codec.
+func serveEchoServerWithCodec(t *testing.T, ln net.Listener, codec Codec) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			return
+		}
+
+		var msg protocol.Message
+		if err := codec.Unmarshal(frame.Payload, &msg); err != nil {
+			continue
+		}
+		if msg.Type != protocol.MessageTypeRequest {
+			continue
+		}
+
+		var req protocol.Request
+		if err := codec.Unmarshal(msg.Payload, &req); err != nil {
+			continue
+		}
+
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"method": req.Method})
+		if err != nil {
+			continue
+		}
+		payloadBytes, err := codec.Marshal(resp)
+		if err != nil {
+			continue
+		}
+		respData, err := codec.Marshal(&protocol.Message{Type: protocol.MessageTypeResponse, Payload: payloadBytes})
+		if err != nil {
+			continue
+		}
+		_ = framer.WriteFrame(framing.NewFrameWithCodec(frame.Header.RequestID, respData, frameCodecID(codec)))
+	}
+}
+
+func TestMultiplexedConnWithCodecUsesMsgpackEnvelope(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	codec := &MessagePackCodec{}
+	go serveEchoServerWithCodec(t, ln, codec)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil, codec)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	req, err := protocol.NewRequest(0, "echo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	resp, err := conn.Call(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK {
+		t.Fatalf("expected OK response, got error: %s", resp.ErrorMsg)
+	}
+}
+
+func TestFrameCodecIDMapsBuiltinCodecs(t *testing.T) {
+	cases := []struct {
+		codec Codec
+		want  byte
+	}{
+		{&JSONCodec{}, framing.CodecIDJSON},
+		{&MessagePackCodec{}, framing.CodecIDMsgpack},
+		{&ProtobufCodec{}, framing.CodecIDProtobuf},
+	}
+	for _, tc := range cases {
+		if got := frameCodecID(tc.codec); got != tc.want {
+			t.Errorf("frameCodecID(%s) = %d, want %d", tc.codec.Name(), got, tc.want)
+		}
+	}
+}
+
+// serveNotificationRecorder accepts a single connection on ln and records
+// every MessageTypeNotification frame's method into notified, keyed by
+// request ID; it never writes a response for one, since notifications
+// expect none.
+func serveNotificationRecorder(t *testing.T, ln net.Listener, notified *sync.Map) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			return
+		}
+
+		msg, err := protocol.UnwrapMessage(frame.Payload)
+		if err != nil || msg.Type != protocol.MessageTypeNotification {
+			continue
+		}
+
+		var req protocol.Request
+		if err := json.Unmarshal(msg.Payload, &req); err == nil {
+			notified.Store(req.ID, req.Method)
+		}
+	}
+}
+
+func TestMultiplexedConnNotifyDoesNotWaitForResponse(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	var notified sync.Map
+	go serveNotificationRecorder(t, ln, &notified)
+
+	conn, err := NewMultiplexedConn(socketPath, NewLogger(LoggingConfig{Level: "error", Format: "json"}), nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn failed: %v", err)
+	}
+	defer conn.Close()
+
+	req, err := protocol.NewRequest(0, "telemetry.record", map[string]interface{}{"event": "tick"})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := conn.Notify(ctx, req); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, ok := notified.Load(req.ID); ok {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("worker never observed the notification")
+}
@@ -0,0 +1,136 @@
+package pyproc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SetMetricsToggle registers a callback invoked whenever a hot-reloaded
+// metrics.enabled config change arrives. Pool has no metrics server of its
+// own; this just routes the signal to whoever owns one (e.g. cmd/pyproc).
+func (p *Pool) SetMetricsToggle(fn func(enabled bool)) {
+	p.onMetricsToggle = fn
+}
+
+// ApplyConfigChange applies a single hot-reloaded ConfigChange to a running
+// Pool. Fields outside the set below are accepted but have no live effect
+// (they only take effect on the next process start); change.Err from an
+// unsafeConfigFields change is returned unchanged without being applied.
+func (p *Pool) ApplyConfigChange(ctx context.Context, change ConfigChange) error {
+	if change.Err != nil {
+		return change.Err
+	}
+
+	switch change.Field {
+	case "Pool.Workers":
+		workers, ok := change.New.(int)
+		if !ok {
+			return fmt.Errorf("pool: Pool.Workers change has non-int value %v", change.New)
+		}
+		return p.Resize(ctx, workers)
+
+	case "Protocol.RequestTimeout":
+		timeout, ok := change.New.(interface{ Nanoseconds() int64 })
+		if !ok {
+			return fmt.Errorf("pool: Protocol.RequestTimeout change has unexpected type %T", change.New)
+		}
+		p.requestTimeout.Store(timeout.Nanoseconds())
+		return nil
+
+	case "Logging.Level":
+		level, ok := change.New.(string)
+		if !ok {
+			return fmt.Errorf("pool: Logging.Level change has non-string value %v", change.New)
+		}
+		p.logger.SetLevel(level)
+		return nil
+
+	case "Metrics.Enabled":
+		enabled, ok := change.New.(bool)
+		if !ok {
+			return fmt.Errorf("pool: Metrics.Enabled change has non-bool value %v", change.New)
+		}
+		if p.onMetricsToggle != nil {
+			p.onMetricsToggle(enabled)
+		}
+		return nil
+
+	default:
+		p.logger.Info("config change has no live effect, will apply on next restart", "field", change.Field)
+		return nil
+	}
+}
+
+// Resize grows or shrinks the pool to newCount workers. Growing starts fresh
+// workers and, if the pool is already running, puts them under the same
+// restart supervision as the originals. Shrinking stops and removes workers
+// from the end of the list. The shared backpressure semaphore's capacity is
+// fixed at NewPool time and is not resized.
+func (p *Pool) Resize(ctx context.Context, newCount int) error {
+	if newCount <= 0 {
+		return fmt.Errorf("pool: Resize requires newCount > 0, got %d", newCount)
+	}
+
+	p.workersMu.Lock()
+	defer p.workersMu.Unlock()
+
+	current := len(p.workers)
+	if newCount == current {
+		return nil
+	}
+
+	if newCount < current {
+		removed := p.workers[newCount:]
+		p.workers = p.workers[:newCount]
+		for i, pw := range removed {
+			if pw.mconn != nil {
+				pw.mconn.Close()
+			}
+			if err := pw.worker.Stop(); err != nil {
+				p.logger.Warn("error stopping worker during resize", "index", newCount+i, "error", err)
+			}
+		}
+		p.opts.Config.Workers = newCount
+		p.logger.Info("pool resized down", "workers", newCount)
+		return nil
+	}
+
+	for i := current; i < newCount; i++ {
+		workerCfg := p.opts.WorkerConfig
+		workerCfg.ID = fmt.Sprintf("worker-%d", i)
+		workerCfg.SocketPath = fmt.Sprintf("%s-%d", p.opts.WorkerConfig.SocketPath, i)
+		if workerCfg.StartTimeout == 0 {
+			workerCfg.StartTimeout = 5 * time.Second
+		}
+
+		worker := NewWorker(workerCfg, p.logger)
+		if err := worker.Start(ctx); err != nil {
+			return fmt.Errorf("pool: failed to start new worker %d during resize: %w", i, err)
+		}
+
+		pw := &poolWorker{
+			worker:     worker,
+			backoff:    newPoolBackoffStrategy(p.opts),
+			supervisor: newPoolSupervisor(p.opts, workerCfg.ID),
+		}
+		pw.healthy.Store(true)
+
+		mconn, err := NewMultiplexedConn(workerCfg.SocketPath, p.logger, pw.backoff, p.callbacks, p.opts.Codec)
+		if err != nil {
+			_ = worker.Stop()
+			return fmt.Errorf("pool: failed to open multiplexed connection to new worker %d: %w", i, err)
+		}
+		pw.mconn = mconn
+
+		p.workers = append(p.workers, pw)
+
+		if p.restartCtx != nil {
+			p.wg.Add(1)
+			go p.superviseRestart(p.restartCtx, pw)
+		}
+	}
+	p.opts.Config.Workers = newCount
+	p.logger.Info("pool resized up", "workers", newCount)
+	return nil
+}
@@ -2,9 +2,16 @@ package pyproc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
 func TestNewPool(t *testing.T) {
@@ -36,6 +43,40 @@ func TestNewPool(t *testing.T) {
 	}
 }
 
+func TestNewPoolReattach(t *testing.T) {
+	opts := PoolOptions{
+		Config: PoolConfig{MaxInFlight: 10},
+		WorkerConfig: WorkerConfig{
+			PythonExec: "python3",
+		},
+		Reattach: map[string]*ReattachConfig{
+			"worker-b": {SocketPath: "/tmp/worker-b.sock", PID: 222},
+			"worker-a": {SocketPath: "/tmp/worker-a.sock", PID: 111},
+		},
+	}
+
+	pool, err := NewPool(opts, nil)
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+	defer pool.Shutdown(context.Background())
+
+	if len(pool.workers) != 2 {
+		t.Fatalf("expected 2 reattached workers, got %d", len(pool.workers))
+	}
+
+	// Built in sorted-ID order regardless of map iteration order.
+	if got := pool.workers[0].worker.GetID(); got != "worker-a" {
+		t.Errorf("expected worker-a first, got %s", got)
+	}
+	if got := pool.workers[0].worker.GetSocketPath(); got != "/tmp/worker-a.sock" {
+		t.Errorf("expected worker-a's socket path to come from its ReattachConfig, got %s", got)
+	}
+	if got := pool.workers[1].worker.GetID(); got != "worker-b" {
+		t.Errorf("expected worker-b second, got %s", got)
+	}
+}
+
 func TestPoolStart(t *testing.T) {
 	opts := PoolOptions{
 		Config: PoolConfig{
@@ -125,6 +166,10 @@ func TestPoolRoundRobin(t *testing.T) {
 			WorkerScript: "../../examples/basic/worker.py",
 			StartTimeout: 5 * time.Second,
 		},
+		// The pool defaults to LeastInFlightBalancer; this test asserts an
+		// even round-robin distribution, so it must opt into that balancer
+		// explicitly.
+		Balancer: NewRoundRobinBalancer(),
 	}
 
 	pool, err := NewPool(opts, nil)
@@ -280,6 +325,101 @@ func TestPoolShutdown(t *testing.T) {
 	}
 }
 
+func TestPoolApplyConfigChange(t *testing.T) {
+	opts := PoolOptions{
+		Config: PoolConfig{
+			Workers:     2,
+			MaxInFlight: 10,
+		},
+		WorkerConfig: WorkerConfig{
+			SocketPath:   "/tmp/test-pool-apply-config.sock",
+			PythonExec:   "python3",
+			WorkerScript: "../../examples/basic/worker.py",
+			StartTimeout: 5 * time.Second,
+		},
+	}
+
+	pool, err := NewPool(opts, nil)
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+	defer pool.Shutdown(context.Background())
+
+	ctx := context.Background()
+
+	t.Run("unsafe field is rejected", func(t *testing.T) {
+		wantErr := errors.New("field Socket.Dir cannot be changed without a restart")
+		change := ConfigChange{Field: "Socket.Dir", Old: "/tmp", New: "/var/run", Err: wantErr}
+		if err := pool.ApplyConfigChange(ctx, change); err != wantErr {
+			t.Fatalf("expected unsafe field error, got %v", err)
+		}
+	})
+
+	t.Run("logging level updates shared LevelVar", func(t *testing.T) {
+		change := ConfigChange{Field: "Logging.Level", Old: "info", New: "debug"}
+		if err := pool.ApplyConfigChange(ctx, change); err != nil {
+			t.Fatalf("ApplyConfigChange failed: %v", err)
+		}
+	})
+
+	t.Run("request timeout is applied to future calls", func(t *testing.T) {
+		change := ConfigChange{Field: "Protocol.RequestTimeout", Old: time.Duration(0), New: 2 * time.Second}
+		if err := pool.ApplyConfigChange(ctx, change); err != nil {
+			t.Fatalf("ApplyConfigChange failed: %v", err)
+		}
+		if got := pool.requestTimeout.Load(); got != int64(2*time.Second) {
+			t.Errorf("expected requestTimeout 2s, got %v", time.Duration(got))
+		}
+	})
+
+	t.Run("metrics toggle invokes registered callback", func(t *testing.T) {
+		var got bool
+		pool.SetMetricsToggle(func(enabled bool) { got = enabled })
+		change := ConfigChange{Field: "Metrics.Enabled", Old: false, New: true}
+		if err := pool.ApplyConfigChange(ctx, change); err != nil {
+			t.Fatalf("ApplyConfigChange failed: %v", err)
+		}
+		if !got {
+			t.Error("expected metrics toggle callback to be invoked with true")
+		}
+	})
+
+	t.Run("unknown field is a no-op", func(t *testing.T) {
+		change := ConfigChange{Field: "Python.Env", Old: nil, New: nil}
+		if err := pool.ApplyConfigChange(ctx, change); err != nil {
+			t.Fatalf("ApplyConfigChange failed: %v", err)
+		}
+	})
+}
+
+func TestPoolResizeBeforeStart(t *testing.T) {
+	opts := PoolOptions{
+		Config: PoolConfig{
+			Workers:     2,
+			MaxInFlight: 10,
+		},
+		WorkerConfig: WorkerConfig{
+			SocketPath:   "/tmp/test-pool-resize.sock",
+			PythonExec:   "python3",
+			WorkerScript: "../../examples/basic/worker.py",
+			StartTimeout: 5 * time.Second,
+		},
+	}
+
+	pool, err := NewPool(opts, nil)
+	if err != nil {
+		t.Fatalf("NewPool failed: %v", err)
+	}
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.Resize(context.Background(), 1); err != nil {
+		t.Fatalf("Resize down failed: %v", err)
+	}
+	if len(pool.workers) != 1 {
+		t.Errorf("expected 1 worker after resize, got %d", len(pool.workers))
+	}
+}
+
 func TestPoolHealthCheck(t *testing.T) {
 	opts := PoolOptions{
 		Config: PoolConfig{
@@ -318,3 +458,160 @@ func TestPoolHealthCheck(t *testing.T) {
 		t.Errorf("expected %d healthy workers, got %d", opts.Config.Workers, health.HealthyWorkers)
 	}
 }
+
+// serveBlockingPoolWorker accepts one connection and, for every request
+// whose method is "block", waits until release is closed before answering;
+// any other method is answered immediately. It lets a test hold a Pool.Call
+// in flight for exactly as long as it needs without a fixed sleep.
+func serveBlockingPoolWorker(t *testing.T, ln net.Listener, release <-chan struct{}) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	framer := framing.NewEnhancedFramer(conn)
+
+	var writeMu sync.Mutex
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			return
+		}
+		msg, err := protocol.UnwrapMessage(frame.Payload)
+		if err != nil {
+			continue
+		}
+		var req protocol.Request
+		if err := json.Unmarshal(msg.Payload, &req); err != nil {
+			continue
+		}
+
+		go func(id uint64, method string) {
+			if method == "block" {
+				<-release
+			}
+			resp, err := protocol.NewResponse(id, map[string]interface{}{"method": method})
+			if err != nil {
+				return
+			}
+			data, err := json.Marshal(protocol.Message{Type: protocol.MessageTypeResponse, Payload: mustMarshal(t, resp)})
+			if err != nil {
+				return
+			}
+			writeMu.Lock()
+			_ = framer.WriteFrame(framing.NewFrame(id, data))
+			writeMu.Unlock()
+		}(req.ID, req.Method)
+	}
+}
+
+// TestPoolShutdownDrainsInFlightAndSkipsDrainingWorker builds a Pool around
+// two fake worker connections directly (no real Python process, as in
+// pool_multiplexed_conn_test.go) so it can deterministically hold one call
+// in flight: it confirms Shutdown's lame duck phase lets that call finish
+// instead of cutting it off, and that the balancer stops routing to the
+// worker it's assigned to the moment Shutdown marks it draining.
+func TestPoolShutdownDrainsInFlightAndSkipsDrainingWorker(t *testing.T) {
+	tmpDir := t.TempDir()
+	sockA := filepath.Join(tmpDir, "a.sock")
+	sockB := filepath.Join(tmpDir, "b.sock")
+
+	lnA, err := net.Listen("unix", sockA)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lnA.Close()
+	lnB, err := net.Listen("unix", sockB)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lnB.Close()
+
+	release := make(chan struct{})
+	go serveBlockingPoolWorker(t, lnA, release)
+	go serveEchoServer(t, lnB, nil)
+
+	logger := NewLogger(LoggingConfig{Level: "error", Format: "json"})
+	connA, err := NewMultiplexedConn(sockA, logger, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn A failed: %v", err)
+	}
+	defer connA.Close()
+	connB, err := NewMultiplexedConn(sockB, logger, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMultiplexedConn B failed: %v", err)
+	}
+	defer connB.Close()
+
+	pw1 := &poolWorker{worker: NewWorker(WorkerConfig{}, logger), mconn: connA}
+	pw1.healthy.Store(true)
+	pw2 := &poolWorker{worker: NewWorker(WorkerConfig{}, logger), mconn: connB}
+	pw2.healthy.Store(true)
+
+	pool := &Pool{
+		opts:      PoolOptions{Config: PoolConfig{LameDuckTimeout: 2 * time.Second}},
+		logger:    logger,
+		workers:   []*poolWorker{pw1, pw2},
+		semaphore: make(chan struct{}, 10),
+		callbacks: newCallbackRegistry(1),
+		balancer:  NewLeastInFlightBalancer(),
+		metrics:   NewPoolMetrics(),
+	}
+
+	slowDone := make(chan error, 1)
+	go func() {
+		var output map[string]interface{}
+		slowDone <- pool.Call(context.Background(), "block", map[string]interface{}{}, &output)
+	}()
+
+	// LeastInFlightBalancer ties go to the first worker, so the slow call
+	// deterministically lands on pw1; wait for that to be visible.
+	deadline := time.Now().Add(time.Second)
+	for pw1.inFlight.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the slow call to start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	shutdownDone := make(chan error, 1)
+	go func() { shutdownDone <- pool.Shutdown(context.Background()) }()
+
+	// Shutdown marks every worker draining before it blocks waiting for
+	// in-flight calls to finish.
+	deadline = time.Now().Add(time.Second)
+	for !pw1.Draining() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for shutdown to mark the worker draining")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	picked, pickedRelease := pool.balancer.Pick(pool.workers)
+	if picked != pw2 {
+		t.Fatal("expected the balancer to route around the draining worker to its sibling")
+	}
+	pickedRelease()
+
+	close(release)
+
+	select {
+	case err := <-slowDone:
+		if err != nil {
+			t.Fatalf("in-flight call failed instead of completing: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the in-flight call to complete")
+	}
+
+	select {
+	case err := <-shutdownDone:
+		if err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for Shutdown to finish")
+	}
+}
@@ -11,22 +11,37 @@ import (
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
+// TransportPoolOptions configures a TransportPool's transport-selection
+// policy.
+type TransportPoolOptions struct {
+	// Balancer selects which transport handles each TransportPool.Call. Nil
+	// defaults to NewRoundRobinLoadBalancer(), matching TransportPool's
+	// original (pre-LoadBalancer) behavior.
+	Balancer LoadBalancer
+}
+
 // TransportPool manages a pool of transports for load balancing
 type TransportPool struct {
 	transports []Transport
-	nextIdx    atomic.Uint64
+	balancer   LoadBalancer
 	logger     *Logger
 	mu         sync.RWMutex
 }
 
 // NewTransportPool creates a new transport pool
-func NewTransportPool(configs []TransportConfig, logger *Logger) (*TransportPool, error) {
+func NewTransportPool(configs []TransportConfig, logger *Logger, opts TransportPoolOptions) (*TransportPool, error) {
 	if len(configs) == 0 {
 		return nil, errors.New("at least one transport config is required")
 	}
 
+	balancer := opts.Balancer
+	if balancer == nil {
+		balancer = NewRoundRobinLoadBalancer()
+	}
+
 	pool := &TransportPool{
 		transports: make([]Transport, 0, len(configs)),
+		balancer:   balancer,
 		logger:     logger,
 	}
 
@@ -45,32 +60,55 @@ func NewTransportPool(configs []TransportConfig, logger *Logger) (*TransportPool
 	return pool, nil
 }
 
-// Call selects a transport and makes a call
+// excludedTransport wraps a Transport so IsHealthy reports false, letting
+// Call exclude a transport that just failed from the next Pick without the
+// LoadBalancer needing to know anything about retries.
+type excludedTransport struct {
+	Transport
+}
+
+func (excludedTransport) IsHealthy() bool { return false }
+
+// Call selects a transport via the pool's LoadBalancer and makes a call,
+// falling back to the next pick (excluding transports already tried) if the
+// call fails.
 func (p *TransportPool) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
 	p.mu.RLock()
-	defer p.mu.RUnlock()
+	transports := p.transports
+	balancer := p.balancer
+	p.mu.RUnlock()
 
-	if len(p.transports) == 0 {
+	if len(transports) == 0 {
 		return nil, errors.New("no transports available")
 	}
 
-	// Try round-robin with fallback
-	startIdx := p.nextIdx.Add(1) - 1
-	for i := 0; i < len(p.transports); i++ {
-		idx := (startIdx + uint64(i)) % uint64(len(p.transports))
-		transport := p.transports[idx]
+	view := make([]Transport, len(transports))
+	copy(view, transports)
 
-		if transport.IsHealthy() {
-			resp, err := transport.Call(ctx, req)
-			if err == nil {
-				return resp, nil
-			}
-			p.logger.Warn("transport call failed, trying next",
-				"index", idx,
-				"error", err)
+	var lastErr error
+	for attempt := 0; attempt < len(transports); attempt++ {
+		idx, done := balancer.Pick(view)
+		if idx < 0 {
+			break
+		}
+
+		start := time.Now()
+		resp, err := transports[idx].Call(ctx, req)
+		done(err, time.Since(start))
+		if err == nil {
+			return resp, nil
 		}
+
+		lastErr = err
+		p.logger.Warn("transport call failed, trying next",
+			"index", idx,
+			"error", err)
+		view[idx] = excludedTransport{transports[idx]}
 	}
 
+	if lastErr != nil {
+		return nil, fmt.Errorf("all transports failed: %w", lastErr)
+	}
 	return nil, errors.New("all transports failed")
 }
 
@@ -108,6 +146,36 @@ func (p *TransportPool) Health() (healthy, total int) {
 	return
 }
 
+// circuitBreakerReporter is implemented by CircuitBreakerTransport; checked
+// via type assertion so CircuitStatus works whether or not TransportConfig.
+// CircuitBreaker was set on a given transport.
+type circuitBreakerReporter interface {
+	circuitBreakerState() circuitState
+}
+
+// CircuitStatus returns how many transports currently have a
+// CircuitBreakerTransport in the Open and HalfOpen states, for transports
+// that were constructed with TransportConfig.CircuitBreaker set. Transports
+// without a breaker don't contribute to either count.
+func (p *TransportPool) CircuitStatus() (open, halfOpen int) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, transport := range p.transports {
+		reporter, ok := transport.(circuitBreakerReporter)
+		if !ok {
+			continue
+		}
+		switch reporter.circuitBreakerState() {
+		case circuitOpen:
+			open++
+		case circuitHalfOpen:
+			halfOpen++
+		}
+	}
+	return
+}
+
 // PoolWithTransport updates the Pool to use Transport interface
 type PoolWithTransport struct {
 	opts          PoolOptions
@@ -120,10 +188,114 @@ type PoolWithTransport struct {
 	// Backpressure control
 	semaphore chan struct{}
 
+	// rateLimiter enforces opts.Config.RateLimit before a call acquires a
+	// semaphore slot. Nil (the common case) means rate limiting is off.
+	rateLimiter *rateLimiter
+
+	// metrics tracks the observability surface exposed by Stats and
+	// MetricsHandler: per-method latency/error counts, rate-limit
+	// rejections, breaker trips, and queue wait time.
+	metrics *transportPoolMetrics
+
 	// Health monitoring
 	healthMu     sync.RWMutex
 	healthStatus HealthStatus
 	healthCancel context.CancelFunc
+
+	// submitQueue backs Submit/SubmitBatch: asyncWorker goroutines (started in
+	// Start, one per semaphore slot so the async path shares the same
+	// Workers*MaxInFlight concurrency bound as Call) drain it and drive
+	// transportPool.Call, resolving each task's Future on completion.
+	// asyncDone signals those goroutines to stop; submitQueue itself is never
+	// closed, since Submit can race Shutdown and a send on a closed channel
+	// would panic.
+	submitQueue chan *asyncTask
+	asyncDone   chan struct{}
+
+	// localHandlers maps a worker ID to a LocalHandler registered via
+	// RegisterLocal before Start, so Start can build that worker's
+	// TransportConfig as "local" instead of "uds".
+	localMu       sync.Mutex
+	localHandlers map[string]LocalHandler
+}
+
+// RegisterLocal installs handler as workerID's Transport, short-circuiting
+// the UDS round-trip for Calls routed to that worker - useful for tests, for
+// embedded-Python deployments where the worker runs in the same process,
+// and for isolating protocol/dispatch overhead from transport cost in
+// benchmarks. It must be called before Start, since TransportPool builds its
+// transports once at startup and never replaces them afterward.
+func (p *PoolWithTransport) RegisterLocal(workerID string, handler LocalHandler) error {
+	if p.transportPool != nil {
+		return errors.New("pyproc: RegisterLocal must be called before Start")
+	}
+
+	p.localMu.Lock()
+	defer p.localMu.Unlock()
+	if p.localHandlers == nil {
+		p.localHandlers = make(map[string]LocalHandler)
+	}
+	p.localHandlers[workerID] = handler
+	return nil
+}
+
+// Task is one unit of work for SubmitBatch: a method name and its input,
+// paired with the Future a caller uses to collect the result.
+type Task struct {
+	Method string
+	Input  interface{}
+}
+
+// asyncTask carries a Submit'd call from the submitQueue to an asyncWorker.
+type asyncTask struct {
+	ctx    context.Context
+	method string
+	input  interface{}
+	future *Future
+}
+
+// Future is the handle returned by Submit/SubmitBatch for a call that's
+// running asynchronously. Done closes once the call finishes; Get blocks
+// until then and unmarshals the result into out (nil out just waits for
+// completion and returns any call error).
+type Future struct {
+	done chan struct{}
+	resp *protocol.Response
+	err  error
+}
+
+func newFuture() *Future {
+	return &Future{done: make(chan struct{})}
+}
+
+// resolve records the outcome of the call and unblocks Done/Get. Must only
+// be called once, by the asyncWorker that owns this Future.
+func (f *Future) resolve(resp *protocol.Response, err error) {
+	f.resp, f.err = resp, err
+	close(f.done)
+}
+
+// Done reports when the call backing this Future has finished.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get blocks until the Future resolves, then behaves like Call: a transport
+// or protocol error is returned as-is, an application-level error response
+// is returned via resp.Error(), and otherwise out is populated from the
+// response body (out may be nil to just observe success/failure).
+func (f *Future) Get(out interface{}) error {
+	<-f.done
+	if f.err != nil {
+		return f.err
+	}
+	if !f.resp.OK {
+		return f.resp.Error()
+	}
+	if out == nil {
+		return nil
+	}
+	return f.resp.UnmarshalBody(out)
 }
 
 // NewPoolWithTransport creates a new pool using the Transport interface
@@ -143,10 +315,14 @@ func NewPoolWithTransport(opts PoolOptions, logger *Logger) (*PoolWithTransport,
 	}
 
 	pool := &PoolWithTransport{
-		opts:      opts,
-		logger:    logger,
-		workers:   make([]*Worker, opts.Config.Workers),
-		semaphore: make(chan struct{}, opts.Config.Workers*opts.Config.MaxInFlight),
+		opts:        opts,
+		logger:      logger,
+		workers:     make([]*Worker, opts.Config.Workers),
+		semaphore:   make(chan struct{}, opts.Config.Workers*opts.Config.MaxInFlight),
+		rateLimiter: newRateLimiter(opts.Config.RateLimit),
+		submitQueue: make(chan *asyncTask, opts.Config.Workers*opts.Config.MaxInFlight),
+		asyncDone:   make(chan struct{}),
+		metrics:     newTransportPoolMetrics(),
 	}
 
 	// Create workers (they still manage the Python processes)
@@ -187,6 +363,10 @@ func (p *PoolWithTransport) Start(ctx context.Context) error {
 	// Create transport configurations for each worker
 	configs := make([]TransportConfig, len(p.workers))
 	for i, worker := range p.workers {
+		if handler, ok := p.localHandlers[worker.GetID()]; ok {
+			configs[i] = TransportConfig{Type: "local", LocalHandler: handler}
+			continue
+		}
 		configs[i] = TransportConfig{
 			Type:    "uds",
 			Address: worker.GetSocketPath(),
@@ -195,10 +375,15 @@ func (p *PoolWithTransport) Start(ctx context.Context) error {
 				"idle_timeout": 30 * time.Second,
 			},
 		}
+		if p.opts.Config.CircuitBreaker != nil {
+			cb := *p.opts.Config.CircuitBreaker
+			cb.OnTrip = p.metrics.recordBreakerTrip
+			configs[i].CircuitBreaker = &cb
+		}
 	}
 
 	// Create transport pool
-	transportPool, err := NewTransportPool(configs, p.logger)
+	transportPool, err := NewTransportPool(configs, p.logger, p.opts.TransportPool)
 	if err != nil {
 		// Stop all workers if transport creation fails
 		for _, worker := range p.workers {
@@ -208,6 +393,15 @@ func (p *PoolWithTransport) Start(ctx context.Context) error {
 	}
 	p.transportPool = transportPool
 
+	// Start the async worker pool backing Submit/SubmitBatch - one goroutine
+	// per semaphore slot, so it never admits more concurrent transport calls
+	// than Call's own backpressure limit allows.
+	asyncWorkers := p.opts.Config.Workers * p.opts.Config.MaxInFlight
+	for i := 0; i < asyncWorkers; i++ {
+		p.wg.Add(1)
+		go p.asyncWorker()
+	}
+
 	// Start health monitoring
 	healthCtx, cancel := context.WithCancel(context.Background())
 	p.healthCancel = cancel
@@ -220,33 +414,166 @@ func (p *PoolWithTransport) Start(ctx context.Context) error {
 	return nil
 }
 
-// Call invokes a method using the transport pool
+// Call invokes a method using the transport pool. If opts.Config.RateLimit
+// admits calls more slowly than callers arrive, Call blocks on the rate
+// limiter (honoring ctx) before it ever touches the semaphore, so a
+// thundering herd is shaped at admission rather than just queuing behind it.
 func (p *PoolWithTransport) Call(ctx context.Context, method string, input interface{}, output interface{}) error {
 	if p.shutdown.Load() {
 		return errors.New("pool is shut down")
 	}
 
+	resp, err := p.callThrough(ctx, method, input)
+	if err != nil {
+		return err
+	}
+
+	if !resp.OK {
+		p.metrics.recordAppError(method)
+		return resp.Error()
+	}
+
+	return resp.UnmarshalBody(output)
+}
+
+// callThrough holds the logic shared by Call and asyncWorker: wait on the
+// rate limiter, acquire a semaphore slot for the duration of the call, then
+// drive the request through the transport pool. It returns the raw response
+// so callers can defer unmarshaling (Call does it immediately; asyncWorker
+// defers it to Future.Get). Queue wait (rate limiter + semaphore) and call
+// latency/outcome are both recorded against p.metrics.
+func (p *PoolWithTransport) callThrough(ctx context.Context, method string, input interface{}) (*protocol.Response, error) {
+	queueStart := time.Now()
+
+	if err := p.rateLimiter.wait(ctx, method); err != nil {
+		p.metrics.recordRateLimitRejection()
+		return nil, err
+	}
+
 	// Acquire semaphore for backpressure
 	select {
 	case p.semaphore <- struct{}{}:
 		defer func() { <-p.semaphore }()
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
+	p.metrics.recordQueueWait(time.Since(queueStart))
 
 	// Create request
 	req, err := protocol.NewRequest(0, method, input)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Call through transport pool
+	callStart := time.Now()
+	resp, err := p.transportPool.Call(ctx, req)
+	p.metrics.recordCall(method, time.Since(callStart), err != nil)
+	if err != nil {
+		return nil, fmt.Errorf("transport call failed: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Submit queues method/input to run asynchronously on the async worker pool
+// and returns immediately with a Future for the result, instead of blocking
+// like Call. Submission itself can still block briefly if every async
+// worker is busy (the queue is bounded at Workers*MaxInFlight) - pass a ctx
+// with a deadline to bound that wait.
+func (p *PoolWithTransport) Submit(ctx context.Context, method string, input interface{}) (*Future, error) {
+	if p.shutdown.Load() {
+		return nil, errors.New("pool is shut down")
+	}
+
+	future := newFuture()
+	select {
+	case p.submitQueue <- &asyncTask{ctx: ctx, method: method, input: input, future: future}:
+		return future, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SubmitBatch calls Submit for each task in order, so callers can fan out N
+// inference calls and collect them with WaitAll. If a Submit fails (e.g. ctx
+// is canceled partway through), SubmitBatch returns the Futures obtained so
+// far alongside the error rather than discarding them.
+func (p *PoolWithTransport) SubmitBatch(ctx context.Context, tasks []Task) ([]*Future, error) {
+	futures := make([]*Future, 0, len(tasks))
+	for _, task := range tasks {
+		future, err := p.Submit(ctx, task.Method, task.Input)
+		if err != nil {
+			return futures, err
+		}
+		futures = append(futures, future)
+	}
+	return futures, nil
+}
+
+// WaitAll blocks until every Future in futures has resolved, or ctx is done.
+// It reports completion only, via a single select loop; call Get on each
+// Future afterward to collect individual results and errors.
+func WaitAll(ctx context.Context, futures []*Future) error {
+	for _, future := range futures {
+		select {
+		case <-future.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// asyncWorker drains submitQueue until asyncDone is closed by Shutdown,
+// driving each task through callThrough and resolving its Future.
+func (p *PoolWithTransport) asyncWorker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case task := <-p.submitQueue:
+			resp, err := p.callThrough(task.ctx, task.method, task.input)
+			task.future.resolve(resp, err)
+		case <-p.asyncDone:
+			return
+		}
+	}
+}
+
+// TryCall is Call's non-blocking counterpart: instead of waiting on the rate
+// limiter or a free semaphore slot, it fails immediately with ErrRateLimited
+// or ErrPoolBusy. Use this where shedding load beats queuing behind it.
+func (p *PoolWithTransport) TryCall(ctx context.Context, method string, input interface{}, output interface{}) error {
+	if p.shutdown.Load() {
+		return errors.New("pool is shut down")
+	}
+
+	if !p.rateLimiter.tryAcquire(method) {
+		p.metrics.recordRateLimitRejection()
+		return ErrRateLimited
+	}
+
+	select {
+	case p.semaphore <- struct{}{}:
+	default:
+		return ErrPoolBusy
+	}
+	defer func() { <-p.semaphore }()
+
+	req, err := protocol.NewRequest(0, method, input)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	callStart := time.Now()
 	resp, err := p.transportPool.Call(ctx, req)
+	p.metrics.recordCall(method, time.Since(callStart), err != nil)
 	if err != nil {
 		return fmt.Errorf("transport call failed: %w", err)
 	}
 
 	if !resp.OK {
+		p.metrics.recordAppError(method)
 		return resp.Error()
 	}
 
@@ -273,6 +600,9 @@ func (p *PoolWithTransport) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// Signal asyncWorker goroutines to stop
+	close(p.asyncDone)
+
 	// Stop all workers
 	var errs []error
 	for i, worker := range p.workers {
@@ -312,18 +642,21 @@ func (p *PoolWithTransport) healthMonitor(ctx context.Context) {
 // updateHealthStatus updates the health status
 func (p *PoolWithTransport) updateHealthStatus() {
 	healthy, total := p.transportPool.Health()
+	open, halfOpen := p.transportPool.CircuitStatus()
 
 	p.healthMu.Lock()
 	p.healthStatus = HealthStatus{
 		TotalWorkers:   total,
 		HealthyWorkers: healthy,
 		LastCheck:      time.Now(),
+		Open:           open,
+		HalfOpen:       halfOpen,
 	}
 	p.healthMu.Unlock()
 
 	if healthy < total {
 		p.logger.Warn("some transports are unhealthy",
-			"healthy", healthy, "total", total)
+			"healthy", healthy, "total", total, "open", open, "half_open", halfOpen)
 	}
 }
 
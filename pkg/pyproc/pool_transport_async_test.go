@@ -0,0 +1,130 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+func TestFutureGetBlocksUntilResolve(t *testing.T) {
+	future := newFuture()
+
+	resultCh := make(chan error, 1)
+	go func() {
+		resultCh <- future.Get(nil)
+	}()
+
+	select {
+	case <-resultCh:
+		t.Fatal("expected Get to block before the future resolves")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	resp, _ := protocol.NewResponse(1, map[string]interface{}{"ok": true})
+	future.resolve(resp, nil)
+
+	select {
+	case err := <-resultCh:
+		if err != nil {
+			t.Errorf("Get() error = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Get did not return after the future resolved")
+	}
+}
+
+func TestFutureGetReturnsTransportError(t *testing.T) {
+	future := newFuture()
+	wantErr := errors.New("transport call failed")
+	future.resolve(nil, wantErr)
+
+	if err := future.Get(nil); !errors.Is(err, wantErr) {
+		t.Errorf("Get() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFutureGetReturnsApplicationError(t *testing.T) {
+	future := newFuture()
+	resp := protocol.NewErrorResponse(1, errors.New("bad input"))
+	future.resolve(resp, nil)
+
+	if err := future.Get(nil); err == nil {
+		t.Error("expected Get to surface the response's application error")
+	}
+}
+
+func TestFutureGetUnmarshalsResponseBody(t *testing.T) {
+	future := newFuture()
+	resp, _ := protocol.NewResponse(1, map[string]interface{}{"value": 42})
+	future.resolve(resp, nil)
+
+	var out struct {
+		Value int `json:"value"`
+	}
+	if err := future.Get(&out); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if out.Value != 42 {
+		t.Errorf("out.Value = %d, want 42", out.Value)
+	}
+}
+
+func TestPoolWithTransportSubmitFailsWhenShutdown(t *testing.T) {
+	pool := &PoolWithTransport{submitQueue: make(chan *asyncTask, 1)}
+	pool.shutdown.Store(true)
+
+	if _, err := pool.Submit(context.Background(), "predict", nil); err == nil {
+		t.Error("expected Submit to fail once the pool is shut down")
+	}
+}
+
+func TestPoolWithTransportSubmitRespectsContextCancellation(t *testing.T) {
+	pool := &PoolWithTransport{submitQueue: make(chan *asyncTask)} // unbuffered, nothing draining it
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := pool.Submit(ctx, "predict", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Submit() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestPoolWithTransportSubmitBatchStopsOnFirstError(t *testing.T) {
+	pool := &PoolWithTransport{submitQueue: make(chan *asyncTask)} // unbuffered, nothing draining it
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	futures, err := pool.SubmitBatch(ctx, []Task{{Method: "predict"}, {Method: "predict"}})
+	if err == nil {
+		t.Fatal("expected SubmitBatch to surface the first Submit error")
+	}
+	if len(futures) != 0 {
+		t.Errorf("expected no futures once the very first Submit fails, got %d", len(futures))
+	}
+}
+
+func TestWaitAllReturnsOnceEveryFutureResolves(t *testing.T) {
+	futures := []*Future{newFuture(), newFuture(), newFuture()}
+	for _, f := range futures {
+		f.resolve(&protocol.Response{OK: true}, nil)
+	}
+
+	if err := WaitAll(context.Background(), futures); err != nil {
+		t.Errorf("WaitAll() error = %v, want nil", err)
+	}
+}
+
+func TestWaitAllRespectsContext(t *testing.T) {
+	futures := []*Future{newFuture()} // never resolved
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := WaitAll(ctx, futures); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitAll() error = %v, want context.DeadlineExceeded", err)
+	}
+}
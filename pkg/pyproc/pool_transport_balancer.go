@@ -0,0 +1,215 @@
+package pyproc
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoadBalancer selects which transport should handle the next
+// TransportPool.Call among its current transport set. Unlike Balancer (which
+// operates on *poolWorker and can store per-worker state directly on the
+// struct), Transport is an opaque interface, so load-aware implementations
+// here track per-transport state in a slice keyed by index instead - safe
+// because a TransportPool's transport set is fixed for the pool's lifetime.
+//
+// Pick returns the chosen index and a done func that must be called once the
+// call finishes (err nil on success, latency the observed round-trip time),
+// so load-aware balancers can update their stats. Implementations that don't
+// track state return noopTransportDone. Pick returns idx -1 if no transport
+// is eligible (e.g. none are healthy).
+type LoadBalancer interface {
+	Pick(transports []Transport) (idx int, done func(err error, latency time.Duration))
+}
+
+func noopTransportDone(error, time.Duration) {}
+
+// healthyTransportIndexes returns the indexes of transports that are
+// currently healthy, preserving order.
+func healthyTransportIndexes(transports []Transport) []int {
+	healthy := make([]int, 0, len(transports))
+	for i, t := range transports {
+		if t.IsHealthy() {
+			healthy = append(healthy, i)
+		}
+	}
+	return healthy
+}
+
+// RoundRobinLoadBalancer cycles through healthy transports in order. It's
+// TransportPool's original behavior, preserved as the default LoadBalancer.
+type RoundRobinLoadBalancer struct {
+	next atomic.Uint64
+}
+
+// NewRoundRobinLoadBalancer returns a LoadBalancer that distributes calls
+// evenly across healthy transports in round-robin order.
+func NewRoundRobinLoadBalancer() *RoundRobinLoadBalancer {
+	return &RoundRobinLoadBalancer{}
+}
+
+func (b *RoundRobinLoadBalancer) Pick(transports []Transport) (int, func(error, time.Duration)) {
+	healthy := healthyTransportIndexes(transports)
+	if len(healthy) == 0 {
+		return -1, noopTransportDone
+	}
+	idx := healthy[(b.next.Add(1)-1)%uint64(len(healthy))]
+	return idx, noopTransportDone
+}
+
+// indexedCounters is a lazily-grown, mutex-guarded []int64 keyed by
+// transport index. Growth only happens once in practice, since a
+// TransportPool never resizes its transport set after creation.
+type indexedCounters struct {
+	mu     sync.RWMutex
+	counts []int64
+}
+
+func (c *indexedCounters) slice(n int) []int64 {
+	c.mu.RLock()
+	if len(c.counts) >= n {
+		s := c.counts
+		c.mu.RUnlock()
+		return s
+	}
+	c.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.counts) < n {
+		grown := make([]int64, n)
+		copy(grown, c.counts)
+		c.counts = grown
+	}
+	return c.counts
+}
+
+// LeastInFlightLoadBalancer always picks the healthy transport with the
+// fewest in-flight calls, so one worker stuck on a slow request stops
+// receiving new work until it catches up.
+type LeastInFlightLoadBalancer struct {
+	inFlight indexedCounters
+}
+
+// NewLeastInFlightLoadBalancer returns a LoadBalancer that favors the
+// least-loaded healthy transport.
+func NewLeastInFlightLoadBalancer() *LeastInFlightLoadBalancer {
+	return &LeastInFlightLoadBalancer{}
+}
+
+func (b *LeastInFlightLoadBalancer) Pick(transports []Transport) (int, func(error, time.Duration)) {
+	counts := b.inFlight.slice(len(transports))
+
+	best := -1
+	var bestLoad int64
+	for i, t := range transports {
+		if !t.IsHealthy() {
+			continue
+		}
+		if load := atomic.LoadInt64(&counts[i]); best == -1 || load < bestLoad {
+			best, bestLoad = i, load
+		}
+	}
+	if best == -1 {
+		return -1, noopTransportDone
+	}
+
+	atomic.AddInt64(&counts[best], 1)
+	return best, func(error, time.Duration) {
+		atomic.AddInt64(&counts[best], -1)
+	}
+}
+
+// indexedLatencies is a lazily-grown, mutex-guarded []uint64 keyed by
+// transport index, each slot holding a float64 EWMA (in nanoseconds) via
+// math.Float64bits so it can be read/updated with plain atomics.
+type indexedLatencies struct {
+	mu   sync.RWMutex
+	ewma []uint64
+}
+
+func (l *indexedLatencies) slice(n int) []uint64 {
+	l.mu.RLock()
+	if len(l.ewma) >= n {
+		s := l.ewma
+		l.mu.RUnlock()
+		return s
+	}
+	l.mu.RUnlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.ewma) < n {
+		grown := make([]uint64, n)
+		copy(grown, l.ewma)
+		l.ewma = grown
+	}
+	return l.ewma
+}
+
+func latencyOf(ewma []uint64, idx int) float64 {
+	return math.Float64frombits(atomic.LoadUint64(&ewma[idx]))
+}
+
+// defaultEWMAAlpha weights how quickly P2CEWMALoadBalancer's latency
+// estimate reacts to a new observation versus its history.
+const defaultEWMAAlpha = 0.3
+
+// P2CEWMALoadBalancer samples two distinct random healthy transports and
+// picks the one with the lower EWMA of observed call latency - "power of two
+// choices" applied to latency rather than in-flight count, so it reacts to a
+// worker that's gotten slow (not just busy) without scanning every transport
+// on every Pick.
+type P2CEWMALoadBalancer struct {
+	alpha float64
+	ewma  indexedLatencies
+}
+
+// NewP2CEWMALoadBalancer returns a LoadBalancer that picks the
+// lower-EWMA-latency transport of two randomly sampled healthy ones.
+// alpha <= 0 uses defaultEWMAAlpha.
+func NewP2CEWMALoadBalancer(alpha float64) *P2CEWMALoadBalancer {
+	if alpha <= 0 {
+		alpha = defaultEWMAAlpha
+	}
+	return &P2CEWMALoadBalancer{alpha: alpha}
+}
+
+func (b *P2CEWMALoadBalancer) Pick(transports []Transport) (int, func(error, time.Duration)) {
+	ewma := b.ewma.slice(len(transports))
+
+	healthy := healthyTransportIndexes(transports)
+	switch len(healthy) {
+	case 0:
+		return -1, noopTransportDone
+	case 1:
+		return healthy[0], b.done(ewma, healthy[0])
+	}
+
+	i := rand.Intn(len(healthy))
+	j := rand.Intn(len(healthy) - 1)
+	if j >= i {
+		j++
+	}
+
+	pick := healthy[i]
+	if other := healthy[j]; latencyOf(ewma, other) < latencyOf(ewma, pick) {
+		pick = other
+	}
+
+	return pick, b.done(ewma, pick)
+}
+
+func (b *P2CEWMALoadBalancer) done(ewma []uint64, idx int) func(error, time.Duration) {
+	return func(_ error, latency time.Duration) {
+		for {
+			old := atomic.LoadUint64(&ewma[idx])
+			next := b.alpha*float64(latency) + (1-b.alpha)*math.Float64frombits(old)
+			if atomic.CompareAndSwapUint64(&ewma[idx], old, math.Float64bits(next)) {
+				return
+			}
+		}
+	}
+}
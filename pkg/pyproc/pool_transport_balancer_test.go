@@ -0,0 +1,145 @@
+package pyproc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFakeLBTransports returns n healthy fakeTransport values (defined in
+// transport_chaos_test.go) as a []Transport, for exercising LoadBalancer
+// implementations without a real worker socket.
+func newFakeLBTransports(n int) []Transport {
+	transports := make([]Transport, n)
+	for i := range transports {
+		transports[i] = &fakeTransport{}
+	}
+	return transports
+}
+
+func TestRoundRobinLoadBalancerDistributesEvenly(t *testing.T) {
+	transports := newFakeLBTransports(3)
+	b := NewRoundRobinLoadBalancer()
+
+	counts := make(map[int]int)
+	for i := 0; i < 9; i++ {
+		idx, done := b.Pick(transports)
+		if idx < 0 {
+			t.Fatal("expected a transport")
+		}
+		done(nil, time.Microsecond)
+		counts[idx]++
+	}
+
+	for i := range transports {
+		if counts[i] != 3 {
+			t.Errorf("expected transport %d to be picked 3 times, got %d", i, counts[i])
+		}
+	}
+}
+
+func TestRoundRobinLoadBalancerSkipsUnhealthy(t *testing.T) {
+	transports := newFakeLBTransports(3)
+	transports[1].(*fakeTransport).closed.Store(true)
+	b := NewRoundRobinLoadBalancer()
+
+	for i := 0; i < 6; i++ {
+		idx, done := b.Pick(transports)
+		if idx == 1 {
+			t.Fatal("balancer picked an unhealthy transport")
+		}
+		done(nil, time.Microsecond)
+	}
+}
+
+func TestLoadBalancersReturnNegativeOneWithNoHealthyTransports(t *testing.T) {
+	transports := newFakeLBTransports(2)
+	for _, tr := range transports {
+		tr.(*fakeTransport).closed.Store(true)
+	}
+
+	for _, b := range []LoadBalancer{
+		NewRoundRobinLoadBalancer(),
+		NewLeastInFlightLoadBalancer(),
+		NewP2CEWMALoadBalancer(0),
+	} {
+		if idx, _ := b.Pick(transports); idx != -1 {
+			t.Errorf("%T: expected -1 with no healthy transports, got %d", b, idx)
+		}
+	}
+}
+
+func TestLeastInFlightLoadBalancerPrefersIdleTransport(t *testing.T) {
+	transports := newFakeLBTransports(3)
+	b := NewLeastInFlightLoadBalancer()
+
+	// Load transports 0 and 2 up, leaving 1 idle.
+	counts := b.inFlight.slice(len(transports))
+	atomic.AddInt64(&counts[0], 5)
+	atomic.AddInt64(&counts[2], 2)
+
+	idx, done := b.Pick(transports)
+	if idx != 1 {
+		t.Fatalf("expected the least-loaded transport (1) to be picked, got %d", idx)
+	}
+	done(nil, time.Microsecond)
+	if atomic.LoadInt64(&counts[1]) != 0 {
+		t.Errorf("expected in-flight count to be decremented by done(), got %d", counts[1])
+	}
+}
+
+func TestLeastInFlightLoadBalancerSpreadsConcurrentLoad(t *testing.T) {
+	transports := newFakeLBTransports(4)
+	b := NewLeastInFlightLoadBalancer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			idx, done := b.Pick(transports)
+			defer done(nil, time.Microsecond)
+			time.Sleep(time.Millisecond)
+			_ = idx
+		}()
+	}
+	wg.Wait()
+
+	counts := b.inFlight.slice(len(transports))
+	for i, c := range counts {
+		if c != 0 {
+			t.Errorf("expected in-flight count for transport %d to settle at 0, got %d", i, c)
+		}
+	}
+}
+
+func TestP2CEWMALoadBalancerPrefersLowerLatency(t *testing.T) {
+	transports := newFakeLBTransports(2)
+	b := NewP2CEWMALoadBalancer(0.5)
+
+	// Report transport 0 as slow and transport 1 as fast a few times so
+	// their EWMAs diverge enough for repeated sampling to matter.
+	for i := 0; i < 5; i++ {
+		b.done(b.ewma.slice(2), 0)(nil, 50*time.Millisecond)
+		b.done(b.ewma.slice(2), 1)(nil, time.Microsecond)
+	}
+
+	counts := make(map[int]int)
+	for i := 0; i < 30; i++ {
+		idx, done := b.Pick(transports)
+		done(nil, time.Microsecond)
+		counts[idx]++
+	}
+
+	if counts[1] <= counts[0] {
+		t.Errorf("expected the lower-latency transport to be picked more often, got counts %v", counts)
+	}
+}
+
+func TestP2CEWMALoadBalancerDefaultsAlpha(t *testing.T) {
+	b := NewP2CEWMALoadBalancer(0)
+	if b.alpha != defaultEWMAAlpha {
+		t.Errorf("alpha = %v, want default %v", b.alpha, defaultEWMAAlpha)
+	}
+}
@@ -0,0 +1,245 @@
+package pyproc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// transportPoolMetrics is PoolWithTransport's observability surface: a
+// per-method call latency/error histogram, plus the pool-wide counters
+// Stats and MetricsHandler report. Every method tolerates a nil receiver
+// (same convention as rateLimiter) so PoolWithTransport values built as bare
+// struct literals in tests don't need to wire one up.
+type transportPoolMetrics struct {
+	methodsMu sync.RWMutex
+	methods   map[string]*methodMetrics
+
+	errorsTotal         atomic.Uint64
+	rateLimitRejections atomic.Uint64
+	breakerTrips        atomic.Uint64
+	queueWaitBuckets    []atomic.Uint64
+}
+
+// methodMetrics is one RPC method's call-latency histogram and outcome
+// counts, reusing PoolMetrics' logarithmic-bucket histogram.
+type methodMetrics struct {
+	latencyBuckets []atomic.Uint64
+	calls          atomic.Uint64
+	errors         atomic.Uint64
+}
+
+func newMethodMetrics() *methodMetrics {
+	return &methodMetrics{latencyBuckets: make([]atomic.Uint64, latencyHistBuckets)}
+}
+
+func (m *methodMetrics) record(latency time.Duration, failed bool) {
+	m.latencyBuckets[latencyBucketIndex(latency)].Add(1)
+	m.calls.Add(1)
+	if failed {
+		m.errors.Add(1)
+	}
+}
+
+func newTransportPoolMetrics() *transportPoolMetrics {
+	return &transportPoolMetrics{
+		methods:          make(map[string]*methodMetrics),
+		queueWaitBuckets: make([]atomic.Uint64, latencyHistBuckets),
+	}
+}
+
+func (m *transportPoolMetrics) forMethod(method string) *methodMetrics {
+	m.methodsMu.RLock()
+	mm, ok := m.methods[method]
+	m.methodsMu.RUnlock()
+	if ok {
+		return mm
+	}
+
+	m.methodsMu.Lock()
+	defer m.methodsMu.Unlock()
+	if mm, ok := m.methods[method]; ok {
+		return mm
+	}
+	mm = newMethodMetrics()
+	m.methods[method] = mm
+	return mm
+}
+
+// recordCall records one completed transport call's latency and outcome.
+func (m *transportPoolMetrics) recordCall(method string, latency time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+	m.forMethod(method).record(latency, failed)
+	if failed {
+		m.errorsTotal.Add(1)
+	}
+}
+
+// recordAppError records an application-level failure (resp.OK == false)
+// that callThrough's caller observed after the call had already been timed
+// and recorded as a success by recordCall.
+func (m *transportPoolMetrics) recordAppError(method string) {
+	if m == nil {
+		return
+	}
+	m.forMethod(method).errors.Add(1)
+	m.errorsTotal.Add(1)
+}
+
+func (m *transportPoolMetrics) recordQueueWait(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.queueWaitBuckets[latencyBucketIndex(d)].Add(1)
+}
+
+func (m *transportPoolMetrics) recordRateLimitRejection() {
+	if m == nil {
+		return
+	}
+	m.rateLimitRejections.Add(1)
+}
+
+func (m *transportPoolMetrics) recordBreakerTrip() {
+	if m == nil {
+		return
+	}
+	m.breakerTrips.Add(1)
+}
+
+// MethodStats summarizes one RPC method's call volume, error count, and
+// latency distribution, as reported by PoolWithTransport.Stats.
+type MethodStats struct {
+	Calls  uint64
+	Errors uint64
+	P50    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+	P999   time.Duration
+}
+
+// PoolStats is a point-in-time snapshot of PoolWithTransport's observability
+// surface: per-method latency/error stats, transport health, circuit
+// breaker state, and admission-control rejections.
+type PoolStats struct {
+	Methods map[string]MethodStats
+
+	TotalWorkers     int
+	HealthyWorkers   int
+	OpenBreakers     int
+	HalfOpenBreakers int
+
+	ErrorsTotal         uint64
+	RateLimitRejections uint64
+	BreakerTrips        uint64
+
+	QueueWaitP50 time.Duration
+	QueueWaitP95 time.Duration
+	QueueWaitP99 time.Duration
+}
+
+// Stats returns a snapshot of the pool's current observability surface. Safe
+// to call before Start (TotalWorkers/HealthyWorkers/breaker counts are 0
+// until the transport pool exists).
+func (p *PoolWithTransport) Stats() PoolStats {
+	stats := PoolStats{Methods: p.metrics.snapshotMethods()}
+
+	if p.transportPool != nil {
+		stats.HealthyWorkers, stats.TotalWorkers = p.transportPool.Health()
+		stats.OpenBreakers, stats.HalfOpenBreakers = p.transportPool.CircuitStatus()
+	}
+
+	if p.metrics != nil {
+		stats.ErrorsTotal = p.metrics.errorsTotal.Load()
+		stats.RateLimitRejections = p.metrics.rateLimitRejections.Load()
+		stats.BreakerTrips = p.metrics.breakerTrips.Load()
+		stats.QueueWaitP50 = histogramPercentile(p.metrics.queueWaitBuckets, 50)
+		stats.QueueWaitP95 = histogramPercentile(p.metrics.queueWaitBuckets, 95)
+		stats.QueueWaitP99 = histogramPercentile(p.metrics.queueWaitBuckets, 99)
+	}
+
+	return stats
+}
+
+func (m *transportPoolMetrics) snapshotMethods() map[string]MethodStats {
+	if m == nil {
+		return nil
+	}
+
+	m.methodsMu.RLock()
+	defer m.methodsMu.RUnlock()
+
+	out := make(map[string]MethodStats, len(m.methods))
+	for name, mm := range m.methods {
+		out[name] = MethodStats{
+			Calls:  mm.calls.Load(),
+			Errors: mm.errors.Load(),
+			P50:    histogramPercentile(mm.latencyBuckets, 50),
+			P95:    histogramPercentile(mm.latencyBuckets, 95),
+			P99:    histogramPercentile(mm.latencyBuckets, 99),
+			P999:   histogramPercentile(mm.latencyBuckets, 99.9),
+		}
+	}
+	return out
+}
+
+// MetricsHandler returns an http.Handler serving Stats in Prometheus text
+// exposition format, with no dependency on the Prometheus client library -
+// see pkg/pyproc/metrics_prom for a Collector-based alternative for callers
+// who already depend on it (that package targets PoolWithMetrics, not
+// PoolWithTransport).
+func (p *PoolWithTransport) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeTransportMetrics(w, p.Stats())
+	})
+}
+
+func writeTransportMetrics(w io.Writer, stats PoolStats) {
+	fmt.Fprintf(w, "# TYPE pyproc_call_duration_seconds summary\n")
+	fmt.Fprintf(w, "# HELP pyproc_call_duration_seconds Per-method call latency distribution.\n")
+	for method, ms := range stats.Methods {
+		fmt.Fprintf(w, "pyproc_call_duration_seconds{method=%q,quantile=\"0.5\"} %g\n", method, ms.P50.Seconds())
+		fmt.Fprintf(w, "pyproc_call_duration_seconds{method=%q,quantile=\"0.95\"} %g\n", method, ms.P95.Seconds())
+		fmt.Fprintf(w, "pyproc_call_duration_seconds{method=%q,quantile=\"0.99\"} %g\n", method, ms.P99.Seconds())
+		fmt.Fprintf(w, "pyproc_call_duration_seconds{method=%q,quantile=\"0.999\"} %g\n", method, ms.P999.Seconds())
+		fmt.Fprintf(w, "pyproc_call_duration_seconds_count{method=%q} %d\n", method, ms.Calls)
+
+		fmt.Fprintf(w, "pyproc_call_errors_total{method=%q} %d\n", method, ms.Errors)
+	}
+
+	fmt.Fprintf(w, "# TYPE pyproc_transport_healthy gauge\n")
+	fmt.Fprintf(w, "# HELP pyproc_transport_healthy Number of transports currently reporting healthy.\n")
+	fmt.Fprintf(w, "pyproc_transport_healthy %d\n", stats.HealthyWorkers)
+
+	fmt.Fprintf(w, "# TYPE pyproc_transport_total gauge\n")
+	fmt.Fprintf(w, "# HELP pyproc_transport_total Total number of transports in the pool.\n")
+	fmt.Fprintf(w, "pyproc_transport_total %d\n", stats.TotalWorkers)
+
+	fmt.Fprintf(w, "# TYPE pyproc_circuit_breaker_open gauge\n")
+	fmt.Fprintf(w, "# HELP pyproc_circuit_breaker_open Number of transports whose circuit breaker is currently Open.\n")
+	fmt.Fprintf(w, "pyproc_circuit_breaker_open %d\n", stats.OpenBreakers)
+
+	fmt.Fprintf(w, "# TYPE pyproc_circuit_breaker_half_open gauge\n")
+	fmt.Fprintf(w, "# HELP pyproc_circuit_breaker_half_open Number of transports whose circuit breaker is currently HalfOpen.\n")
+	fmt.Fprintf(w, "pyproc_circuit_breaker_half_open %d\n", stats.HalfOpenBreakers)
+
+	fmt.Fprintf(w, "# TYPE pyproc_circuit_breaker_trips_total counter\n")
+	fmt.Fprintf(w, "# HELP pyproc_circuit_breaker_trips_total Total circuit breaker trips to Open.\n")
+	fmt.Fprintf(w, "pyproc_circuit_breaker_trips_total %d\n", stats.BreakerTrips)
+
+	fmt.Fprintf(w, "# TYPE pyproc_rate_limit_rejections_total counter\n")
+	fmt.Fprintf(w, "# HELP pyproc_rate_limit_rejections_total Total calls rejected by the rate limiter.\n")
+	fmt.Fprintf(w, "pyproc_rate_limit_rejections_total %d\n", stats.RateLimitRejections)
+
+	fmt.Fprintf(w, "# TYPE pyproc_queue_wait_seconds summary\n")
+	fmt.Fprintf(w, "# HELP pyproc_queue_wait_seconds Time spent waiting on the rate limiter and semaphore before a call runs.\n")
+	fmt.Fprintf(w, "pyproc_queue_wait_seconds{quantile=\"0.5\"} %g\n", stats.QueueWaitP50.Seconds())
+	fmt.Fprintf(w, "pyproc_queue_wait_seconds{quantile=\"0.95\"} %g\n", stats.QueueWaitP95.Seconds())
+	fmt.Fprintf(w, "pyproc_queue_wait_seconds{quantile=\"0.99\"} %g\n", stats.QueueWaitP99.Seconds())
+}
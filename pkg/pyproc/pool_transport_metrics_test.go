@@ -0,0 +1,102 @@
+package pyproc
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransportPoolMetricsRecordCallTracksPerMethod(t *testing.T) {
+	m := newTransportPoolMetrics()
+	m.recordCall("predict", 5*time.Millisecond, false)
+	m.recordCall("predict", 10*time.Millisecond, true)
+	m.recordCall("train", time.Millisecond, false)
+
+	stats := m.snapshotMethods()
+	predict, ok := stats["predict"]
+	if !ok {
+		t.Fatal("expected a \"predict\" entry in the snapshot")
+	}
+	if predict.Calls != 2 {
+		t.Errorf("predict.Calls = %d, want 2", predict.Calls)
+	}
+	if predict.Errors != 1 {
+		t.Errorf("predict.Errors = %d, want 1", predict.Errors)
+	}
+	if predict.P50 <= 0 {
+		t.Errorf("predict.P50 = %v, want > 0", predict.P50)
+	}
+
+	if stats["train"].Calls != 1 {
+		t.Errorf("train.Calls = %d, want 1", stats["train"].Calls)
+	}
+	if m.errorsTotal.Load() != 1 {
+		t.Errorf("errorsTotal = %d, want 1", m.errorsTotal.Load())
+	}
+}
+
+func TestTransportPoolMetricsRecordAppErrorDoesNotDoubleCountCalls(t *testing.T) {
+	m := newTransportPoolMetrics()
+	m.recordCall("predict", time.Millisecond, false)
+	m.recordAppError("predict")
+
+	stats := m.snapshotMethods()
+	if stats["predict"].Calls != 1 {
+		t.Errorf("Calls = %d, want 1 (recordAppError shouldn't add a call)", stats["predict"].Calls)
+	}
+	if stats["predict"].Errors != 1 {
+		t.Errorf("Errors = %d, want 1", stats["predict"].Errors)
+	}
+}
+
+func TestTransportPoolMetricsNilReceiverIsNoop(t *testing.T) {
+	var m *transportPoolMetrics
+	m.recordCall("predict", time.Millisecond, true)
+	m.recordAppError("predict")
+	m.recordQueueWait(time.Millisecond)
+	m.recordRateLimitRejection()
+	m.recordBreakerTrip()
+
+	if got := m.snapshotMethods(); got != nil {
+		t.Errorf("snapshotMethods() on nil = %v, want nil", got)
+	}
+}
+
+func TestPoolWithTransportStatsBeforeStart(t *testing.T) {
+	pool := &PoolWithTransport{metrics: newTransportPoolMetrics()}
+	stats := pool.Stats()
+
+	if stats.TotalWorkers != 0 || stats.HealthyWorkers != 0 {
+		t.Errorf("expected zero-value worker counts before Start, got %+v", stats)
+	}
+	if stats.Methods == nil {
+		t.Error("expected a non-nil (if empty) Methods map")
+	}
+}
+
+func TestPoolWithTransportMetricsHandlerServesExpectedMetricNames(t *testing.T) {
+	pool := &PoolWithTransport{metrics: newTransportPoolMetrics()}
+	pool.metrics.recordCall("predict", 2*time.Millisecond, false)
+	pool.metrics.recordRateLimitRejection()
+	pool.metrics.recordBreakerTrip()
+
+	rec := httptest.NewRecorder()
+	pool.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"pyproc_call_duration_seconds",
+		"pyproc_call_errors_total",
+		"pyproc_transport_healthy",
+		"pyproc_transport_total",
+		"pyproc_circuit_breaker_open",
+		"pyproc_circuit_breaker_trips_total",
+		"pyproc_rate_limit_rejections_total",
+		"pyproc_queue_wait_seconds",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics output to contain %q, got:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned by PoolWithTransport.Call/TryCall when a call
+// is rejected by admission control rather than by the caller's own context
+// expiring - check errors.Is(err, ErrRateLimited) to tell the two apart.
+var ErrRateLimited = errors.New("rate limit: call rejected")
+
+// ErrPoolBusy is returned by PoolWithTransport.TryCall when every worker
+// slot is currently in use.
+var ErrPoolBusy = errors.New("pool: no free worker slot")
+
+// MethodLimit configures a per-method token bucket within RateLimitConfig.
+type MethodLimit struct {
+	Rate  rate.Limit `mapstructure:"rate"`
+	Burst int        `mapstructure:"burst"`
+}
+
+// RateLimitConfig configures PoolWithTransport's admission control. Global,
+// if non-zero, token-bucket-limits total Call throughput across every
+// method; PerMethod additionally limits individual methods on top of
+// whatever Global already allows. The zero value (Global == 0, PerMethod
+// empty) disables rate limiting - Call/TryCall then behave exactly as they
+// did before RateLimitConfig existed.
+type RateLimitConfig struct {
+	Global    rate.Limit             `mapstructure:"global"`
+	Burst     int                    `mapstructure:"burst"`
+	PerMethod map[string]MethodLimit `mapstructure:"per_method"`
+}
+
+// rateLimiter owns the limiters built from a RateLimitConfig: one global
+// limiter (nil if RateLimitConfig.Global is zero) and one lazily-built
+// limiter per method name actually seen, so a config with many PerMethod
+// entries doesn't pay for limiters on methods that are never called.
+type rateLimiter struct {
+	cfg    RateLimitConfig
+	global *rate.Limiter
+
+	mu        sync.Mutex
+	perMethod map[string]*rate.Limiter
+}
+
+// newRateLimiter builds a rateLimiter from cfg. A nil *rateLimiter (returned
+// when cfg is the zero value) is valid and always admits.
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	if cfg.Global <= 0 && len(cfg.PerMethod) == 0 {
+		return nil
+	}
+	rl := &rateLimiter{cfg: cfg}
+	if cfg.Global > 0 {
+		rl.global = rate.NewLimiter(cfg.Global, cfg.Burst)
+	}
+	if len(cfg.PerMethod) > 0 {
+		rl.perMethod = make(map[string]*rate.Limiter, len(cfg.PerMethod))
+	}
+	return rl
+}
+
+// limiterFor returns the limiter configured for method, lazily constructing
+// it on first use. It returns nil if method has no PerMethod entry.
+func (rl *rateLimiter) limiterFor(method string) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if l, ok := rl.perMethod[method]; ok {
+		return l
+	}
+	limit, ok := rl.cfg.PerMethod[method]
+	if !ok {
+		return nil
+	}
+	l := rate.NewLimiter(limit.Rate, limit.Burst)
+	rl.perMethod[method] = l
+	return l
+}
+
+// wait blocks until both the global and method-specific limiters admit the
+// call, or ctx is done first. Either limiter refusing - whether because ctx
+// expired while waiting or because the request can never fit within its
+// burst - surfaces as ErrRateLimited (wrapping the underlying error so
+// errors.Is(err, context.DeadlineExceeded) still works), so callers that use
+// a short deadline purely to probe admission can tell "rejected" apart from
+// unrelated cancellation elsewhere in Call.
+func (rl *rateLimiter) wait(ctx context.Context, method string) error {
+	if rl == nil {
+		return nil
+	}
+	reject := func(l *rate.Limiter, err error) error {
+		// rate.Limiter.Wait refuses up front, with an error that doesn't wrap
+		// context.DeadlineExceeded, when the next token would only arrive
+		// after ctx's deadline; add it so errors.Is sees the deadline.
+		if _, ok := ctx.Deadline(); ok && ctx.Err() == nil && l.Burst() > 0 {
+			err = errors.Join(err, context.DeadlineExceeded)
+		}
+		return errors.Join(ErrRateLimited, err)
+	}
+	if rl.global != nil {
+		if err := rl.global.Wait(ctx); err != nil {
+			return reject(rl.global, err)
+		}
+	}
+	if l := rl.limiterFor(method); l != nil {
+		if err := l.Wait(ctx); err != nil {
+			return reject(l, err)
+		}
+	}
+	return nil
+}
+
+// tryAcquire is wait's non-blocking counterpart: it reports whether a token
+// was immediately available from both the global and method-specific
+// limiters, taking one from each if so and taking neither if the global one
+// would have blocked. Note rate.Limiter has no way to "peek" without consuming, so a
+// global token taken here is not refunded if the method-specific check
+// then fails - an acceptable, rare over-debit under this method's own burst.
+func (rl *rateLimiter) tryAcquire(method string) bool {
+	if rl == nil {
+		return true
+	}
+	if rl.global != nil && !rl.global.Allow() {
+		return false
+	}
+	if l := rl.limiterFor(method); l != nil && !l.Allow() {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestNewRateLimiterNilForZeroValue(t *testing.T) {
+	if rl := newRateLimiter(RateLimitConfig{}); rl != nil {
+		t.Errorf("expected nil rateLimiter for zero-value config, got %+v", rl)
+	}
+}
+
+func TestRateLimiterWaitNilIsNoop(t *testing.T) {
+	var rl *rateLimiter
+	if err := rl.wait(context.Background(), "predict"); err != nil {
+		t.Errorf("nil rateLimiter.wait() error = %v, want nil", err)
+	}
+	if !rl.tryAcquire("predict") {
+		t.Error("nil rateLimiter.tryAcquire() = false, want true")
+	}
+}
+
+func TestRateLimiterGlobalBlocksAcrossMethods(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{Global: 1, Burst: 1})
+
+	if !rl.tryAcquire("a") {
+		t.Fatal("first call should be admitted by an empty bucket of burst 1")
+	}
+	if rl.tryAcquire("b") {
+		t.Error("second call should be rejected - global bucket has no tokens left")
+	}
+}
+
+func TestRateLimiterPerMethodIsIndependentOfOtherMethods(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{
+		PerMethod: map[string]MethodLimit{
+			"slow": {Rate: 1, Burst: 1},
+		},
+	})
+
+	if !rl.tryAcquire("slow") {
+		t.Fatal("first call to the limited method should be admitted")
+	}
+	if rl.tryAcquire("slow") {
+		t.Error("second call to the limited method should be rejected")
+	}
+	if !rl.tryAcquire("fast") {
+		t.Error("a method with no PerMethod entry should be unaffected")
+	}
+}
+
+func TestRateLimiterWaitReturnsErrRateLimitedOnShortDeadline(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{Global: rate.Limit(1), Burst: 1})
+
+	// Drain the single token so the next Wait has to block.
+	if !rl.tryAcquire("predict") {
+		t.Fatal("expected the first call to drain the only token")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := rl.wait(ctx, "predict")
+	if err == nil {
+		t.Fatal("expected an error once the deadline is shorter than the refill interval")
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected errors.Is(err, ErrRateLimited), got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected errors.Is(err, context.DeadlineExceeded), got %v", err)
+	}
+}
+
+func TestPoolWithTransportTryCallFailsFastWhenBusy(t *testing.T) {
+	pool := &PoolWithTransport{
+		semaphore: make(chan struct{}, 1),
+	}
+	pool.semaphore <- struct{}{} // occupy the only slot
+
+	err := pool.TryCall(context.Background(), "predict", nil, nil)
+	if !errors.Is(err, ErrPoolBusy) {
+		t.Errorf("expected ErrPoolBusy, got %v", err)
+	}
+}
+
+func TestPoolWithTransportTryCallFailsFastWhenRateLimited(t *testing.T) {
+	pool := &PoolWithTransport{
+		semaphore:   make(chan struct{}, 1),
+		rateLimiter: newRateLimiter(RateLimitConfig{Global: 1, Burst: 1}),
+	}
+	_ = pool.rateLimiter.tryAcquire("predict") // drain the only token
+
+	err := pool.TryCall(context.Background(), "predict", nil, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package pyproc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ReattachEnvVar is the environment variable pyproc reads to attach to
+// already-running Python workers instead of spawning new ones. This lets a
+// developer start `python worker.py` by hand under pdb/VS Code/PyCharm and
+// have the pool connect to that process instead of exec'ing its own,
+// borrowing the idea of Terraform's TF_REATTACH_PROVIDERS.
+const ReattachEnvVar = "PYPROC_REATTACH"
+
+// ReattachConfig describes one externally managed worker to connect to
+// instead of spawning. PID is optional and only used for logging/operator
+// visibility: a reattached Worker never signals or waits on the process, so
+// it doesn't need the PID to do its job. Transport is currently informational
+// (pyproc only reattaches over "uds"); it's carried through so a future
+// transport type can use it.
+type ReattachConfig struct {
+	SocketPath string `json:"socket"`
+	PID        int    `json:"pid,omitempty"`
+	Transport  string `json:"transport,omitempty"`
+}
+
+// ParseReattachEnv decodes the PYPROC_REATTACH JSON format
+// (`{"worker-id": {"socket": "/tmp/x.sock", "pid": 1234, "transport": "uds"}}`)
+// into a map keyed by worker ID. An empty value returns (nil, nil).
+func ParseReattachEnv(value string) (map[string]*ReattachConfig, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	specs := make(map[string]*ReattachConfig)
+	if err := json.Unmarshal([]byte(value), &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ReattachEnvVar, err)
+	}
+	for id, spec := range specs {
+		if spec == nil || spec.SocketPath == "" {
+			return nil, fmt.Errorf("%s: worker %q is missing a socket path", ReattachEnvVar, id)
+		}
+	}
+	return specs, nil
+}
+
+// ReattachSpecsFromEnv reads and parses ReattachEnvVar from the process
+// environment; it returns (nil, nil) if the variable is unset.
+func ReattachSpecsFromEnv() (map[string]*ReattachConfig, error) {
+	return ParseReattachEnv(os.Getenv(ReattachEnvVar))
+}
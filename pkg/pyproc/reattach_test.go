@@ -0,0 +1,51 @@
+package pyproc
+
+import "testing"
+
+func TestParseReattachEnvEmpty(t *testing.T) {
+	specs, err := ParseReattachEnv("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if specs != nil {
+		t.Errorf("expected nil specs for empty input, got %v", specs)
+	}
+}
+
+func TestParseReattachEnvDecodesSpecs(t *testing.T) {
+	specs, err := ParseReattachEnv(`{"worker-0":{"socket":"/tmp/w0.sock","pid":1234,"transport":"uds"}}`)
+	if err != nil {
+		t.Fatalf("ParseReattachEnv failed: %v", err)
+	}
+	spec, ok := specs["worker-0"]
+	if !ok {
+		t.Fatal("expected worker-0 entry")
+	}
+	if spec.SocketPath != "/tmp/w0.sock" || spec.PID != 1234 || spec.Transport != "uds" {
+		t.Errorf("unexpected spec: %+v", spec)
+	}
+}
+
+func TestParseReattachEnvRejectsMissingSocket(t *testing.T) {
+	if _, err := ParseReattachEnv(`{"worker-0":{"pid":1234}}`); err == nil {
+		t.Error("expected an error for a spec missing its socket path")
+	}
+}
+
+func TestParseReattachEnvRejectsInvalidJSON(t *testing.T) {
+	if _, err := ParseReattachEnv("not json"); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestReattachSpecsFromEnv(t *testing.T) {
+	t.Setenv(ReattachEnvVar, `{"worker-0":{"socket":"/tmp/w0.sock"}}`)
+
+	specs, err := ReattachSpecsFromEnv()
+	if err != nil {
+		t.Fatalf("ReattachSpecsFromEnv failed: %v", err)
+	}
+	if specs["worker-0"].SocketPath != "/tmp/w0.sock" {
+		t.Errorf("unexpected specs: %v", specs)
+	}
+}
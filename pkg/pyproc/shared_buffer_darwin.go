@@ -0,0 +1,40 @@
+//go:build darwin
+
+package pyproc
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// NewSharedBuffer creates a size-byte shared buffer backed by an unlinked
+// temp file (darwin has no memfd_create) and mmaps it. See the linux
+// implementation's doc comment for the intended use; callers are
+// responsible for syscall.Munmap(buf) and file.Close() once done.
+func NewSharedBuffer(size int) (*os.File, []byte, error) {
+	file, err := os.CreateTemp("", "pyproc-shared-buffer-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create shared buffer file: %w", err)
+	}
+	// Unlink immediately: the fd (and the worker's dup of it) keeps the
+	// backing storage alive for as long as either stays open, with nothing
+	// left in the filesystem to clean up.
+	if err := os.Remove(file.Name()); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to unlink shared buffer file: %w", err)
+	}
+
+	if err := file.Truncate(int64(size)); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to size shared buffer: %w", err)
+	}
+
+	buf, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to mmap shared buffer: %w", err)
+	}
+
+	return file, buf, nil
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package pyproc
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// NewSharedBuffer creates a size-byte anonymous memfd and mmaps it, for
+// handing a large payload (a tensor, a DataFrame) to a worker as an
+// SCM_RIGHTS attachment (see protocol.Request.Attachments) instead of
+// shipping it through the framed JSON channel. The file and buf reference
+// the same memory - writes through buf are visible to anything else that
+// maps the same fd. Callers are responsible for syscall.Munmap(buf) and
+// file.Close() once done.
+func NewSharedBuffer(size int) (*os.File, []byte, error) {
+	name, err := syscall.BytePtrFromString("pyproc-shared-buffer")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode memfd name: %w", err)
+	}
+
+	fd, _, errno := syscall.Syscall(syscall.SYS_MEMFD_CREATE, uintptr(unsafe.Pointer(name)), 0, 0)
+	if errno != 0 {
+		return nil, nil, fmt.Errorf("memfd_create failed: %w", errno)
+	}
+	file := os.NewFile(fd, "pyproc-shared-buffer")
+
+	if err := file.Truncate(int64(size)); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to size shared buffer: %w", err)
+	}
+
+	buf, err := syscall.Mmap(int(fd), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to mmap shared buffer: %w", err)
+	}
+
+	return file, buf, nil
+}
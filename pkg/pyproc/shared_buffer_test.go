@@ -0,0 +1,117 @@
+package pyproc
+
+import (
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestNewSharedBuffer(t *testing.T) {
+	file, buf, err := NewSharedBuffer(4096)
+	if err != nil {
+		t.Fatalf("NewSharedBuffer failed: %v", err)
+	}
+	defer file.Close()
+	defer syscall.Munmap(buf)
+
+	if len(buf) != 4096 {
+		t.Fatalf("expected a 4096-byte mapping, got %d", len(buf))
+	}
+
+	copy(buf, "hello, shared memory")
+
+	// The mapping and the file descriptor back the same memory; reading
+	// the fd directly (instead of through buf) should see the write.
+	readBack := make([]byte, len("hello, shared memory"))
+	if _, err := file.ReadAt(readBack, 0); err != nil {
+		t.Fatalf("ReadAt failed: %v", err)
+	}
+	if string(readBack) != "hello, shared memory" {
+		t.Errorf("expected file contents to match the mapping, got %q", readBack)
+	}
+}
+
+// TestSharedBufferSCMRightsRoundTrip exercises the same fd-passing mechanics
+// MultiplexedTransport.writeFrameWithAttachments uses, without requiring a
+// Python worker on the other end: it sends a NewSharedBuffer fd across a
+// real socketpair via WriteMsgUnix/SCM_RIGHTS, mutates the mapping through
+// the *received* fd (standing in for the worker), and confirms the
+// original, sending side's mapping observes the same mutation.
+func TestSharedBufferSCMRightsRoundTrip(t *testing.T) {
+	file, buf, err := NewSharedBuffer(64)
+	if err != nil {
+		t.Fatalf("NewSharedBuffer failed: %v", err)
+	}
+	defer file.Close()
+	defer syscall.Munmap(buf)
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatalf("Socketpair failed: %v", err)
+	}
+	senderFile := os.NewFile(uintptr(fds[0]), "sender")
+	receiverFile := os.NewFile(uintptr(fds[1]), "receiver")
+	defer senderFile.Close()
+	defer receiverFile.Close()
+
+	senderConn, err := net.FileConn(senderFile)
+	if err != nil {
+		t.Fatalf("FileConn(sender) failed: %v", err)
+	}
+	defer senderConn.Close()
+	receiverConn, err := net.FileConn(receiverFile)
+	if err != nil {
+		t.Fatalf("FileConn(receiver) failed: %v", err)
+	}
+	defer receiverConn.Close()
+
+	senderUnix := senderConn.(*net.UnixConn)
+	receiverUnix := receiverConn.(*net.UnixConn)
+
+	oob := syscall.UnixRights(int(file.Fd()))
+	if _, _, err := senderUnix.WriteMsgUnix([]byte("x"), oob, nil); err != nil {
+		t.Fatalf("WriteMsgUnix failed: %v", err)
+	}
+
+	msgBuf := make([]byte, 1)
+	oobBuf := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := receiverUnix.ReadMsgUnix(msgBuf, oobBuf)
+	if err != nil {
+		t.Fatalf("ReadMsgUnix failed: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected to read 1 payload byte, got %d", n)
+	}
+
+	cmsgs, err := syscall.ParseSocketControlMessage(oobBuf[:oobn])
+	if err != nil {
+		t.Fatalf("ParseSocketControlMessage failed: %v", err)
+	}
+	if len(cmsgs) != 1 {
+		t.Fatalf("expected 1 control message, got %d", len(cmsgs))
+	}
+	recvFds, err := syscall.ParseUnixRights(&cmsgs[0])
+	if err != nil {
+		t.Fatalf("ParseUnixRights failed: %v", err)
+	}
+	if len(recvFds) != 1 {
+		t.Fatalf("expected 1 received fd, got %d", len(recvFds))
+	}
+
+	recvFile := os.NewFile(uintptr(recvFds[0]), "received-shared-buffer")
+	defer recvFile.Close()
+
+	recvBuf, err := syscall.Mmap(recvFds[0], 0, 64, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		t.Fatalf("Mmap(received fd) failed: %v", err)
+	}
+	defer syscall.Munmap(recvBuf)
+
+	// Simulate the worker mutating the mapping after receiving the fd.
+	copy(recvBuf, "mutated by the other end")
+
+	if got := string(buf[:len("mutated by the other end")]); got != "mutated by the other end" {
+		t.Errorf("expected the original mapping to observe the mutation, got %q", got)
+	}
+}
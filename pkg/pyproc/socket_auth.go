@@ -0,0 +1,236 @@
+package pyproc
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+)
+
+// AuthMechanism identifies a SASL-style authentication mechanism negotiated
+// on connection setup, mirroring AMQP's SASL mechanism negotiation.
+type AuthMechanism string
+
+const (
+	// AuthAnonymous performs no verification; only suitable for trusted,
+	// filesystem-permission-protected sockets.
+	AuthAnonymous AuthMechanism = "ANONYMOUS"
+	// AuthHMACSHA256 is the pre-shared-secret challenge/response mechanism.
+	AuthHMACSHA256 AuthMechanism = "HMAC-SHA256"
+	// AuthSCRAMSHA256 is salted, iterated challenge/response so the secret
+	// itself never crosses the wire, letting operators rotate it without
+	// redeploying binaries that hold the raw secret.
+	AuthSCRAMSHA256 AuthMechanism = "SCRAM-SHA-256"
+	// AuthPeerCred skips the cryptographic handshake entirely, trusting the
+	// kernel-verified SO_PEERCRED/LOCAL_PEERCRED uid/gid of the peer.
+	AuthPeerCred AuthMechanism = "PEERCRED"
+)
+
+// Authenticator implements one SASL mechanism's handshake over a connection
+// that has already agreed on this mechanism during negotiation.
+type Authenticator interface {
+	// Mechanism returns the mechanism name advertised during negotiation.
+	Mechanism() AuthMechanism
+
+	// AuthenticateClient runs the client side of the handshake.
+	AuthenticateClient(conn net.Conn) error
+
+	// AuthenticateServer runs the server side of the handshake.
+	AuthenticateServer(conn net.Conn) error
+}
+
+// negotiationTimeout bounds the mechanism-list exchange, separate from each
+// mechanism's own handshake timeout.
+const negotiationTimeout = 5 * time.Second
+
+// NegotiateClient sends the client's supported mechanisms, waits for the
+// server's choice, then runs that mechanism's handshake. mechanisms must be
+// non-empty and are offered in priority order (most preferred first).
+func NegotiateClient(conn net.Conn, mechanisms []Authenticator) error {
+	if len(mechanisms) == 0 {
+		return fmt.Errorf("no authentication mechanisms configured")
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(negotiationTimeout)); err != nil {
+		return fmt.Errorf("failed to set negotiation deadline: %w", err)
+	}
+	framer := framing.NewFramer(conn)
+
+	offered := make([]string, len(mechanisms))
+	for i, m := range mechanisms {
+		offered[i] = string(m.Mechanism())
+	}
+	if err := framer.WriteMessage([]byte(strings.Join(offered, ","))); err != nil {
+		return fmt.Errorf("failed to send mechanism list: %w", err)
+	}
+
+	chosen, err := framer.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("failed to read server choice: %w", err)
+	}
+
+	var selected Authenticator
+	for _, m := range mechanisms {
+		if string(m.Mechanism()) == string(chosen) {
+			selected = m
+			break
+		}
+	}
+	if selected == nil {
+		return fmt.Errorf("server chose unsupported mechanism %q", chosen)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("failed to clear negotiation deadline: %w", err)
+	}
+	return selected.AuthenticateClient(conn)
+}
+
+// NegotiateServer reads the client's offered mechanisms, picks the first one
+// (in the server's own priority order) that both sides support, then runs
+// that mechanism's handshake.
+func NegotiateServer(conn net.Conn, supported []Authenticator) error {
+	if len(supported) == 0 {
+		return fmt.Errorf("no authentication mechanisms configured")
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(negotiationTimeout)); err != nil {
+		return fmt.Errorf("failed to set negotiation deadline: %w", err)
+	}
+	framer := framing.NewFramer(conn)
+
+	offeredRaw, err := framer.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("failed to read mechanism list: %w", err)
+	}
+	offered := make(map[string]bool)
+	for _, m := range strings.Split(string(offeredRaw), ",") {
+		offered[m] = true
+	}
+
+	var selected Authenticator
+	for _, m := range supported {
+		if offered[string(m.Mechanism())] {
+			selected = m
+			break
+		}
+	}
+	if selected == nil {
+		_ = framer.WriteMessage([]byte(""))
+		return fmt.Errorf("no common authentication mechanism with client")
+	}
+
+	if err := framer.WriteMessage([]byte(selected.Mechanism())); err != nil {
+		return fmt.Errorf("failed to send mechanism choice: %w", err)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("failed to clear negotiation deadline: %w", err)
+	}
+	return selected.AuthenticateServer(conn)
+}
+
+// SortByPreference orders mechanisms by name according to a fixed preference
+// list, strongest-first, so callers building []Authenticator from config
+// (where order is whatever the YAML/map produced) get deterministic negotiation.
+func SortByPreference(mechanisms []Authenticator) {
+	priority := map[AuthMechanism]int{
+		AuthSCRAMSHA256: 0,
+		AuthPeerCred:    1,
+		AuthHMACSHA256:  2,
+		AuthAnonymous:   3,
+	}
+	sort.SliceStable(mechanisms, func(i, j int) bool {
+		return priority[mechanisms[i].Mechanism()] < priority[mechanisms[j].Mechanism()]
+	})
+}
+
+// AnonymousAuthenticator accepts any peer without verification.
+type AnonymousAuthenticator struct{}
+
+func (AnonymousAuthenticator) Mechanism() AuthMechanism { return AuthAnonymous }
+
+func (AnonymousAuthenticator) AuthenticateClient(conn net.Conn) error { return nil }
+
+func (AnonymousAuthenticator) AuthenticateServer(conn net.Conn) error { return nil }
+
+// HMACAuthenticator adapts the existing challenge/response HMACAuth to the
+// Authenticator interface so it can be offered during SASL negotiation.
+type HMACAuthenticator struct {
+	auth *HMACAuth
+}
+
+// NewHMACAuthenticator creates an Authenticator using HMAC-SHA256 with secret.
+func NewHMACAuthenticator(secret []byte) *HMACAuthenticator {
+	return &HMACAuthenticator{auth: NewHMACAuth(secret)}
+}
+
+func (h *HMACAuthenticator) Mechanism() AuthMechanism { return AuthHMACSHA256 }
+
+func (h *HMACAuthenticator) AuthenticateClient(conn net.Conn) error {
+	return h.auth.AuthenticateClient(conn)
+}
+
+func (h *HMACAuthenticator) AuthenticateServer(conn net.Conn) error {
+	return h.auth.AuthenticateServer(conn)
+}
+
+// PeerCredAuthenticator trusts the kernel-verified uid/gid of the peer,
+// skipping any cryptographic handshake. It reuses VerifyPeerCredentials,
+// which already enforces config.AllowedUIDs/AllowedGIDs/RequireSameUser.
+type PeerCredAuthenticator struct {
+	config SocketSecurityConfig
+}
+
+// NewPeerCredAuthenticator creates an Authenticator backed by SO_PEERCRED/LOCAL_PEERCRED.
+func NewPeerCredAuthenticator(config SocketSecurityConfig) *PeerCredAuthenticator {
+	return &PeerCredAuthenticator{config: config}
+}
+
+func (p *PeerCredAuthenticator) Mechanism() AuthMechanism { return AuthPeerCred }
+
+func (p *PeerCredAuthenticator) AuthenticateClient(conn net.Conn) error {
+	// Nothing to send: the server reads credentials off the socket itself.
+	return nil
+}
+
+func (p *PeerCredAuthenticator) AuthenticateServer(conn net.Conn) error {
+	return VerifyPeerCredentials(conn, p.config)
+}
+
+// BuildAuthenticators translates an AuthConfig into the Authenticator list
+// used by NegotiateClient/NegotiateServer, in the order the operator listed
+// them under auth.mechanisms.
+func BuildAuthenticators(cfg AuthConfig) ([]Authenticator, error) {
+	var mechanisms []Authenticator
+	for _, name := range cfg.Mechanisms {
+		switch AuthMechanism(name) {
+		case AuthAnonymous:
+			mechanisms = append(mechanisms, AnonymousAuthenticator{})
+		case AuthHMACSHA256:
+			if cfg.Secret == "" {
+				return nil, fmt.Errorf("auth.secret is required for mechanism %s", name)
+			}
+			mechanisms = append(mechanisms, NewHMACAuthenticator(SecretFromString(cfg.Secret)))
+		case AuthSCRAMSHA256:
+			if cfg.Secret == "" {
+				return nil, fmt.Errorf("auth.secret is required for mechanism %s", name)
+			}
+			mechanisms = append(mechanisms, NewScramAuthenticator([]byte(cfg.Secret)))
+		case AuthPeerCred:
+			secCfg := DefaultSocketSecurityConfig()
+			secCfg.AllowedUIDs = cfg.AllowedUIDs
+			secCfg.AllowedGIDs = cfg.AllowedGIDs
+			mechanisms = append(mechanisms, NewPeerCredAuthenticator(secCfg))
+		default:
+			return nil, fmt.Errorf("unknown auth mechanism %q", name)
+		}
+	}
+	if len(mechanisms) == 0 {
+		mechanisms = append(mechanisms, AnonymousAuthenticator{})
+	}
+	return mechanisms, nil
+}
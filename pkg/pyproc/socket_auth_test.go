@@ -0,0 +1,113 @@
+package pyproc
+
+import (
+	"net"
+	"testing"
+)
+
+func runNegotiation(t *testing.T, client, server []Authenticator) (clientErr, serverErr error) {
+	t.Helper()
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NegotiateServer(serverConn, server)
+	}()
+
+	clientErr = NegotiateClient(clientConn, client)
+	serverErr = <-done
+	return
+}
+
+func TestNegotiateAnonymous(t *testing.T) {
+	client := []Authenticator{AnonymousAuthenticator{}}
+	server := []Authenticator{AnonymousAuthenticator{}}
+
+	clientErr, serverErr := runNegotiation(t, client, server)
+	if clientErr != nil {
+		t.Errorf("client negotiation failed: %v", clientErr)
+	}
+	if serverErr != nil {
+		t.Errorf("server negotiation failed: %v", serverErr)
+	}
+}
+
+func TestNegotiateHMAC(t *testing.T) {
+	secret := SecretFromString("test-secret")
+	client := []Authenticator{NewHMACAuthenticator(secret)}
+	server := []Authenticator{NewHMACAuthenticator(secret)}
+
+	clientErr, serverErr := runNegotiation(t, client, server)
+	if clientErr != nil {
+		t.Errorf("client negotiation failed: %v", clientErr)
+	}
+	if serverErr != nil {
+		t.Errorf("server negotiation failed: %v", serverErr)
+	}
+}
+
+func TestNegotiateScram(t *testing.T) {
+	secret := []byte("rotatable-secret")
+	client := []Authenticator{NewScramAuthenticator(secret)}
+	server := []Authenticator{NewScramAuthenticator(secret)}
+
+	clientErr, serverErr := runNegotiation(t, client, server)
+	if clientErr != nil {
+		t.Errorf("client negotiation failed: %v", clientErr)
+	}
+	if serverErr != nil {
+		t.Errorf("server negotiation failed: %v", serverErr)
+	}
+}
+
+func TestNegotiateScramWrongSecret(t *testing.T) {
+	client := []Authenticator{NewScramAuthenticator([]byte("secret-a"))}
+	server := []Authenticator{NewScramAuthenticator([]byte("secret-b"))}
+
+	clientErr, serverErr := runNegotiation(t, client, server)
+	if clientErr == nil {
+		t.Error("expected client negotiation to fail with mismatched secrets")
+	}
+	if serverErr == nil {
+		t.Error("expected server negotiation to fail with mismatched secrets")
+	}
+}
+
+func TestNegotiateNoCommonMechanism(t *testing.T) {
+	client := []Authenticator{NewHMACAuthenticator(SecretFromString("a"))}
+	server := []Authenticator{NewScramAuthenticator([]byte("b"))}
+
+	_, serverErr := runNegotiation(t, client, server)
+	if serverErr == nil {
+		t.Error("expected negotiation to fail with no common mechanism")
+	}
+}
+
+func TestBuildAuthenticators(t *testing.T) {
+	t.Run("defaults to anonymous", func(t *testing.T) {
+		mechanisms, err := BuildAuthenticators(AuthConfig{})
+		if err != nil {
+			t.Fatalf("BuildAuthenticators failed: %v", err)
+		}
+		if len(mechanisms) != 1 || mechanisms[0].Mechanism() != AuthAnonymous {
+			t.Errorf("expected [ANONYMOUS], got %+v", mechanisms)
+		}
+	})
+
+	t.Run("missing secret errors", func(t *testing.T) {
+		_, err := BuildAuthenticators(AuthConfig{Mechanisms: []string{"HMAC-SHA256"}})
+		if err == nil {
+			t.Error("expected error for HMAC-SHA256 without a secret")
+		}
+	})
+
+	t.Run("unknown mechanism errors", func(t *testing.T) {
+		_, err := BuildAuthenticators(AuthConfig{Mechanisms: []string{"bogus"}})
+		if err == nil {
+			t.Error("expected error for unknown mechanism")
+		}
+	})
+}
@@ -11,9 +11,9 @@ import (
 // getPeerCredentials retrieves the peer credentials using LOCAL_PEERCRED (macOS-specific)
 func getPeerCredentials(fd int) (*PeerCredentials, error) {
 	// On macOS, we use LOCAL_PEERCRED instead of SO_PEERCRED
-	// The structure is different: struct xucred instead of struct ucred
-
-	// Note: macOS doesn't provide PID in peer credentials
+	// The structure is different: struct xucred instead of struct ucred.
+	// xucred has no PID field, so that comes from a separate getsockopt
+	// in getPeerPID below.
 
 	type xucred struct {
 		version uint32
@@ -46,6 +46,51 @@ func getPeerCredentials(fd int) (*PeerCredentials, error) {
 	return &PeerCredentials{
 		UID: cred.uid,
 		GID: cred.groups[0], // Use first group as primary GID
-		PID: 0,              // PID not available on macOS
+		PID: getPeerPID(fd), // Best-effort; 0 if the kernel doesn't support it
 	}, nil
 }
+
+// getPeerPID fills in what xucred can't: the peer's PID. It isn't available
+// on every macOS version, so a failure here is not fatal to
+// getPeerCredentials - callers just get PID=0, same as older Darwin kernels
+// that lack both opcodes entirely.
+func getPeerPID(fd int) int32 {
+	const (
+		solLocal      = 0     // SOL_LOCAL, from sys/socket.h
+		localPeerEPID = 0x003 // LOCAL_PEEREPID, from sys/un.h (effective pid)
+		localPeerPID  = 0x002 // LOCAL_PEERPID, from sys/un.h (older macOS)
+	)
+
+	var pid int32
+	pidLen := uint32(unsafe.Sizeof(pid))
+
+	_, _, errno := syscall.Syscall6(
+		syscall.SYS_GETSOCKOPT,
+		uintptr(fd),
+		uintptr(solLocal),
+		uintptr(localPeerEPID),
+		uintptr(unsafe.Pointer(&pid)),
+		uintptr(unsafe.Pointer(&pidLen)),
+		0,
+	)
+	if errno == 0 {
+		return pid
+	}
+
+	// Older macOS versions only have LOCAL_PEERPID.
+	pid = 0
+	pidLen = uint32(unsafe.Sizeof(pid))
+	_, _, errno = syscall.Syscall6(
+		syscall.SYS_GETSOCKOPT,
+		uintptr(fd),
+		uintptr(solLocal),
+		uintptr(localPeerPID),
+		uintptr(unsafe.Pointer(&pid)),
+		uintptr(unsafe.Pointer(&pidLen)),
+		0,
+	)
+	if errno != 0 {
+		return 0
+	}
+	return pid
+}
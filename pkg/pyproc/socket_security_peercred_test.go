@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package pyproc
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+// TestGetPeerCredentialsSocketpair exercises getPeerCredentials directly
+// (rather than through VerifyPeerCredentials/a real listener) so it runs
+// the same way on both the Linux (SO_PEERCRED) and Darwin (LOCAL_PEERCRED)
+// implementations: a socketpair's peer is this same process, so the
+// returned UID must match os.Geteuid().
+func TestGetPeerCredentialsSocketpair(t *testing.T) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatalf("Socketpair failed: %v", err)
+	}
+	defer syscall.Close(fds[0])
+	defer syscall.Close(fds[1])
+
+	creds, err := getPeerCredentials(fds[0])
+	if err != nil {
+		t.Fatalf("getPeerCredentials failed: %v", err)
+	}
+
+	if creds.UID != uint32(os.Geteuid()) {
+		t.Errorf("expected UID %d, got %d", os.Geteuid(), creds.UID)
+	}
+}
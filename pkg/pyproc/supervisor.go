@@ -0,0 +1,237 @@
+package pyproc
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// WorkerEventType classifies one event emitted on a Supervisor's Events
+// channel.
+type WorkerEventType int
+
+const (
+	// WorkerEventCrashed means the worker process exited with a nonzero
+	// status or was killed by a signal, while the pool still expected it to
+	// be running.
+	WorkerEventCrashed WorkerEventType = iota
+	// WorkerEventExited means the worker process exited with status 0 while
+	// the pool still expected it to be running - not a crash, but still
+	// unexpected since nothing asked it to stop.
+	WorkerEventExited
+	// WorkerEventRestarting means the supervisor is about to attempt a
+	// restart after its backoff delay.
+	WorkerEventRestarting
+	// WorkerEventRestarted means a restart attempt started the process
+	// successfully.
+	WorkerEventRestarted
+	// WorkerEventRestartFailed means a restart attempt itself failed to
+	// start the new process.
+	WorkerEventRestartFailed
+	// WorkerEventDegraded means the worker tripped its restart-rate circuit
+	// breaker (too many restarts within Window) and the supervisor has
+	// given up restarting it.
+	WorkerEventDegraded
+)
+
+// String returns the lowercase event name, e.g. "crashed".
+func (t WorkerEventType) String() string {
+	switch t {
+	case WorkerEventCrashed:
+		return "crashed"
+	case WorkerEventExited:
+		return "exited"
+	case WorkerEventRestarting:
+		return "restarting"
+	case WorkerEventRestarted:
+		return "restarted"
+	case WorkerEventRestartFailed:
+		return "restart_failed"
+	case WorkerEventDegraded:
+		return "degraded"
+	default:
+		return "unknown"
+	}
+}
+
+// WorkerEvent reports one lifecycle transition a Supervisor observed for a
+// worker, for callers that want to log, alert, or feed a metrics system
+// beyond what PoolMetrics' counters and HealthStatus' counts expose.
+type WorkerEvent struct {
+	WorkerID string
+	Type     WorkerEventType
+	Time     time.Time
+
+	// ExitCode, Signaled and Signal describe a Crashed/Exited event's
+	// syscall.WaitStatus; zero values otherwise.
+	ExitCode int
+	Signaled bool
+	Signal   syscall.Signal
+
+	// Err carries the restart attempt's error for RestartFailed, nil
+	// otherwise.
+	Err error
+}
+
+// SupervisorConfig configures a Supervisor's restart-rate circuit breaker
+// and event reporting. It does not configure the restart delay itself -
+// that's still the poolWorker's BackoffStrategy, same as before Supervisor
+// existed.
+type SupervisorConfig struct {
+	// MaxRestarts bounds how many restarts a single worker may undergo
+	// within Window before the Supervisor marks it degraded and refuses any
+	// further restart. <= 0 disables the breaker (unlimited restarts, the
+	// pre-Supervisor behavior).
+	MaxRestarts int
+	// Window is the rolling window MaxRestarts is measured over. <= 0
+	// defaults to 1 minute.
+	Window time.Duration
+	// Events, if non-nil, receives every WorkerEvent the supervisor emits.
+	// Sends never block: an event is dropped rather than stalling
+	// supervision if the channel is full or nobody is reading it.
+	Events chan<- WorkerEvent
+}
+
+// Supervisor classifies a worker's exits as a crash or a clean exit and
+// decides whether Pool.superviseRestart's next restart attempt is allowed,
+// tripping a restart-rate circuit breaker ("max N restarts in window W")
+// when the worker is crash-looping too fast to be useful. NewPool (and
+// Resize, for the workers it adds) constructs one per poolWorker;
+// superviseRestart's existing ticker loop
+// still drives detection and the actual restart call, since Worker.monitor
+// already detects an exit the moment cmd.Wait returns (and a manual SIGCHLD
+// handler would just race with the reaping os/exec already does internally)
+// - Supervisor only adds the policy layer on top.
+type Supervisor struct {
+	workerID string
+	cfg      SupervisorConfig
+
+	mu           sync.Mutex
+	restartTimes []time.Time
+	degraded     bool
+}
+
+// NewSupervisor returns a Supervisor for the worker identified by workerID,
+// applying cfg's restart-rate circuit breaker and event reporting.
+func NewSupervisor(workerID string, cfg SupervisorConfig) *Supervisor {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	return &Supervisor{workerID: workerID, cfg: cfg}
+}
+
+// Degraded reports whether this worker's restart-rate circuit breaker has
+// tripped. A degraded worker is left stopped; Pool.superviseRestart stops
+// attempting to restart it until the pool itself is recreated.
+func (s *Supervisor) Degraded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.degraded
+}
+
+// ClassifyExit inspects waitErr, the error cmd.Wait returned for a worker
+// process (nil for exit status 0), and reports whether it counts as a crash
+// - a nonzero exit or death by signal - along with the syscall.WaitStatus
+// details for a WorkerEvent. A nil waitErr is never a crash.
+func ClassifyExit(waitErr error) (crashed bool, exitCode int, signaled bool, sig syscall.Signal) {
+	if waitErr == nil {
+		return false, 0, false, 0
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(waitErr, &exitErr) {
+		// Not even an ExitError (e.g. the process was never started) - treat
+		// conservatively as a crash since it didn't exit cleanly.
+		return true, -1, false, 0
+	}
+
+	ws, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return true, exitErr.ExitCode(), false, 0
+	}
+	if ws.Signaled() {
+		return true, -1, true, ws.Signal()
+	}
+	return ws.ExitStatus() != 0, ws.ExitStatus(), false, 0
+}
+
+// RecordExit emits a Crashed or Exited event for waitErr and counts it
+// toward the restart-rate window. Call it once per detected exit, before
+// deciding whether to restart.
+func (s *Supervisor) RecordExit(waitErr error) {
+	crashed, exitCode, signaled, sig := ClassifyExit(waitErr)
+
+	typ := WorkerEventExited
+	if crashed {
+		typ = WorkerEventCrashed
+	}
+	s.emit(WorkerEvent{
+		Type: typ, ExitCode: exitCode, Signaled: signaled, Signal: sig,
+	})
+}
+
+// AllowRestart records the current time as a restart attempt and reports
+// whether it's allowed: false once MaxRestarts attempts have happened
+// within the last Window, after which it emits a Degraded event (once) and
+// keeps returning false - the worker stays down until something external
+// resets it.
+func (s *Supervisor) AllowRestart(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.degraded {
+		return false
+	}
+	if s.cfg.MaxRestarts <= 0 {
+		return true
+	}
+
+	cutoff := now.Add(-s.cfg.Window)
+	kept := s.restartTimes[:0]
+	for _, t := range s.restartTimes {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	s.restartTimes = kept
+
+	if len(s.restartTimes) >= s.cfg.MaxRestarts {
+		s.degraded = true
+		s.emitLocked(WorkerEvent{Type: WorkerEventDegraded})
+		return false
+	}
+
+	s.restartTimes = append(s.restartTimes, now)
+	s.emitLocked(WorkerEvent{Type: WorkerEventRestarting})
+	return true
+}
+
+// RecordRestartResult emits a Restarted or RestartFailed event for the
+// error a restart attempt returned (nil on success).
+func (s *Supervisor) RecordRestartResult(err error) {
+	typ := WorkerEventRestarted
+	if err != nil {
+		typ = WorkerEventRestartFailed
+	}
+	s.emit(WorkerEvent{Type: typ, Err: err})
+}
+
+func (s *Supervisor) emit(ev WorkerEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emitLocked(ev)
+}
+
+func (s *Supervisor) emitLocked(ev WorkerEvent) {
+	if s.cfg.Events == nil {
+		return
+	}
+	ev.WorkerID = s.workerID
+	ev.Time = time.Now()
+	select {
+	case s.cfg.Events <- ev:
+	default:
+	}
+}
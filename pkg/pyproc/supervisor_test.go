@@ -0,0 +1,115 @@
+package pyproc
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestClassifyExitCleanStatusZero(t *testing.T) {
+	crashed, _, _, _ := ClassifyExit(nil)
+	if crashed {
+		t.Fatal("nil wait error should never classify as a crash")
+	}
+}
+
+func TestClassifyExitNonzeroStatus(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "exit 7")
+	waitErr := cmd.Run()
+
+	crashed, exitCode, signaled, _ := ClassifyExit(waitErr)
+	if !crashed {
+		t.Fatal("expected a nonzero exit status to classify as a crash")
+	}
+	if signaled {
+		t.Error("expected signaled=false for a plain nonzero exit")
+	}
+	if exitCode != 7 {
+		t.Errorf("expected exit code 7, got %d", exitCode)
+	}
+}
+
+func TestClassifyExitSignaled(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "kill -KILL $$")
+	waitErr := cmd.Run()
+
+	crashed, _, signaled, sig := ClassifyExit(waitErr)
+	if !crashed {
+		t.Fatal("expected death by signal to classify as a crash")
+	}
+	if !signaled {
+		t.Error("expected signaled=true")
+	}
+	if sig != 9 {
+		t.Errorf("expected SIGKILL (9), got %v", sig)
+	}
+}
+
+func TestSupervisorAllowRestartTripsBreakerAfterMaxRestarts(t *testing.T) {
+	s := NewSupervisor("worker-0", SupervisorConfig{MaxRestarts: 2, Window: time.Minute})
+	now := time.Now()
+
+	if !s.AllowRestart(now) {
+		t.Fatal("expected first restart to be allowed")
+	}
+	if !s.AllowRestart(now) {
+		t.Fatal("expected second restart to be allowed")
+	}
+	if s.AllowRestart(now) {
+		t.Fatal("expected third restart within the window to be refused")
+	}
+	if !s.Degraded() {
+		t.Error("expected the breaker to trip and mark the worker degraded")
+	}
+}
+
+func TestSupervisorAllowRestartForgivesOldAttemptsOutsideWindow(t *testing.T) {
+	s := NewSupervisor("worker-0", SupervisorConfig{MaxRestarts: 1, Window: time.Minute})
+	past := time.Now().Add(-2 * time.Minute)
+
+	if !s.AllowRestart(past) {
+		t.Fatal("expected the first restart to be allowed")
+	}
+	if !s.AllowRestart(time.Now()) {
+		t.Fatal("expected a restart outside the window to be allowed even though MaxRestarts is 1")
+	}
+}
+
+func TestSupervisorAllowRestartUnlimitedWhenMaxRestartsDisabled(t *testing.T) {
+	s := NewSupervisor("worker-0", SupervisorConfig{})
+	now := time.Now()
+
+	for i := 0; i < 10; i++ {
+		if !s.AllowRestart(now) {
+			t.Fatalf("expected restart %d to be allowed with no MaxRestarts configured", i)
+		}
+	}
+}
+
+func TestSupervisorEmitsEventsWithoutBlocking(t *testing.T) {
+	events := make(chan WorkerEvent, 1)
+	s := NewSupervisor("worker-0", SupervisorConfig{MaxRestarts: 1, Window: time.Minute, Events: events})
+
+	s.RecordExit(errors.New("boom"))
+	ev := <-events
+	if ev.WorkerID != "worker-0" || ev.Type != WorkerEventCrashed {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+
+	// Fill the channel, then emit more events than it can hold - none of
+	// these calls should block.
+	events <- WorkerEvent{}
+	done := make(chan struct{})
+	go func() {
+		s.RecordRestartResult(nil)
+		s.RecordRestartResult(errors.New("failed"))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("emitting events blocked on a full channel")
+	}
+}
@@ -0,0 +1,115 @@
+package pyproc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// TraceContext is a W3C Trace Context span reference: the (trace ID, span
+// ID) pair carried in a traceparent header, plus its tracestate. It exists
+// so UDSTransport/MultiplexedTransport can propagate a caller's span across
+// the RPC boundary without depending on go.opentelemetry.io/otel, which
+// isn't vendored anywhere in this tree - a real OTel SDK can still
+// interoperate with it, since the wire format (traceparent/tracestate) is
+// the same either way; only the in-process API differs.
+type TraceContext struct {
+	TraceID    [16]byte
+	SpanID     [8]byte
+	Sampled    bool
+	TraceState string
+}
+
+// traceContextKey is the context key WithTraceContext/TraceContextFromContext use.
+type traceContextKey struct{}
+
+// NewTraceContext starts a fresh trace: a random trace ID and span ID,
+// sampled by default (there's no sampler here to decide otherwise).
+func NewTraceContext() TraceContext {
+	var tc TraceContext
+	_, _ = rand.Read(tc.TraceID[:])
+	_, _ = rand.Read(tc.SpanID[:])
+	tc.Sampled = true
+	return tc
+}
+
+// NewChildSpan returns a TraceContext for a new span within the same trace -
+// same TraceID, fresh SpanID - the shape UDSTransport.Call wants for the
+// client span it starts around each RPC.
+func (tc TraceContext) NewChildSpan() TraceContext {
+	child := tc
+	_, _ = rand.Read(child.SpanID[:])
+	return child
+}
+
+// WithTraceContext attaches tc to ctx, for a later Call on the same ctx (or
+// a logger's *Context method) to pick up with TraceContextFromContext.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext retrieves the TraceContext attached by
+// WithTraceContext, if any.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// TraceParent formats tc as a W3C "traceparent" header value:
+// "00-{trace-id}-{span-id}-{flags}".
+func (tc TraceContext) TraceParent() string {
+	flags := byte(0)
+	if tc.Sampled {
+		flags = 1
+	}
+	return fmt.Sprintf("00-%s-%s-%02x", hex.EncodeToString(tc.TraceID[:]), hex.EncodeToString(tc.SpanID[:]), flags)
+}
+
+// TraceIDHex returns tc's trace ID as the 32-character lowercase hex string
+// used both on the wire and in log output.
+func (tc TraceContext) TraceIDHex() string {
+	return hex.EncodeToString(tc.TraceID[:])
+}
+
+// SpanIDHex returns tc's span ID as the 16-character lowercase hex string
+// used both on the wire and in log output.
+func (tc TraceContext) SpanIDHex() string {
+	return hex.EncodeToString(tc.SpanID[:])
+}
+
+// ParseTraceParent parses a W3C "traceparent" header value into a
+// TraceContext. Only version "00" is understood, matching the only version
+// the spec itself defines so far; anything else is rejected rather than
+// guessed at.
+func ParseTraceParent(header string) (TraceContext, error) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 {
+		return TraceContext{}, fmt.Errorf("traceparent: expected 4 dash-separated fields, got %d", len(parts))
+	}
+	if parts[0] != "00" {
+		return TraceContext{}, fmt.Errorf("traceparent: unsupported version %q", parts[0])
+	}
+
+	var tc TraceContext
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != 16 {
+		return TraceContext{}, fmt.Errorf("traceparent: invalid trace-id %q", parts[1])
+	}
+	copy(tc.TraceID[:], traceID)
+
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != 8 {
+		return TraceContext{}, fmt.Errorf("traceparent: invalid parent-id %q", parts[2])
+	}
+	copy(tc.SpanID[:], spanID)
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return TraceContext{}, fmt.Errorf("traceparent: invalid trace-flags %q", parts[3])
+	}
+	tc.Sampled = flags[0]&1 == 1
+
+	return tc, nil
+}
@@ -0,0 +1,67 @@
+package pyproc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTraceParentRoundTrips(t *testing.T) {
+	tc := NewTraceContext()
+
+	parsed, err := ParseTraceParent(tc.TraceParent())
+	if err != nil {
+		t.Fatalf("ParseTraceParent failed: %v", err)
+	}
+	if parsed.TraceIDHex() != tc.TraceIDHex() {
+		t.Errorf("trace ID mismatch: got %s, want %s", parsed.TraceIDHex(), tc.TraceIDHex())
+	}
+	if parsed.SpanIDHex() != tc.SpanIDHex() {
+		t.Errorf("span ID mismatch: got %s, want %s", parsed.SpanIDHex(), tc.SpanIDHex())
+	}
+	if parsed.Sampled != tc.Sampled {
+		t.Errorf("sampled mismatch: got %v, want %v", parsed.Sampled, tc.Sampled)
+	}
+}
+
+func TestParseTraceParentRejectsMalformed(t *testing.T) {
+	cases := []string{
+		"",
+		"00-deadbeef-cafe-01",
+		"01-0000000000000000000000000000000a-00000000000000a0-01",
+		"00-zz00000000000000000000000000000a-00000000000000a0-01",
+	}
+	for _, c := range cases {
+		if _, err := ParseTraceParent(c); err == nil {
+			t.Errorf("ParseTraceParent(%q) = nil error, want an error", c)
+		}
+	}
+}
+
+func TestNewChildSpanKeepsTraceIDFreshSpanID(t *testing.T) {
+	parent := NewTraceContext()
+	child := parent.NewChildSpan()
+
+	if child.TraceIDHex() != parent.TraceIDHex() {
+		t.Error("expected NewChildSpan to keep the same trace ID")
+	}
+	if child.SpanIDHex() == parent.SpanIDHex() {
+		t.Error("expected NewChildSpan to generate a fresh span ID")
+	}
+}
+
+func TestTraceContextRoundTripsThroughContext(t *testing.T) {
+	tc := NewTraceContext()
+	ctx := WithTraceContext(context.Background(), tc)
+
+	got, ok := TraceContextFromContext(ctx)
+	if !ok {
+		t.Fatal("expected TraceContextFromContext to find the attached TraceContext")
+	}
+	if got.TraceIDHex() != tc.TraceIDHex() {
+		t.Errorf("trace ID mismatch: got %s, want %s", got.TraceIDHex(), tc.TraceIDHex())
+	}
+
+	if _, ok := TraceContextFromContext(context.Background()); ok {
+		t.Error("expected TraceContextFromContext to report false on a bare context")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/YuminosukeSato/pyproc/internal/framing"
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
@@ -22,13 +23,87 @@ type Transport interface {
 
 // TransportConfig defines configuration for transport layer
 type TransportConfig struct {
-	Type    string // "uds", "grpc-tcp", "grpc-uds"
+	Type    string // "uds", "multiplexed", "grpc-tcp", "grpc-uds", "grpc-tcp+tls", "json-rpc", "local"
 	Address string // Socket path or network address
+
+	// Options carries backend-specific settings. UDSTransport and
+	// MultiplexedTransport both read "timeout" (time.Duration), "codec"
+	// (one of CodecJSON/CodecMessagePack/CodecProtobuf as a string, default
+	// CodecJSON), and "compression" (a name registered with
+	// RegisterCompressor, e.g. "gzip"; unset disables compression) from here
+	// rather than as dedicated fields, since those apply the same way across
+	// every socket-based Transport. UDSTransport additionally reads
+	// "pool_initial" and "pool_max" (both int) sizing its ConnPool - how many
+	// connections to the worker's socket it keeps open at once - defaulting
+	// to 1 and 4 respectively - and "max_retries" (int, default 3), see
+	// Backoff below.
 	Options map[string]interface{}
+
+	// ReconnectBackoff controls MultiplexedTransport's auto-reconnect on
+	// read/write failure. MaxAttempts <= 0 means retry forever. If Strategy
+	// is unset it defaults to "exponential-jitter", not RestartConfig's own
+	// "exponential" default, since redialing benefits from jitter more than
+	// restarting a worker process does.
+	ReconnectBackoff RestartConfig
+	// OnReconnect, if set, is called by MultiplexedTransport after every
+	// reconnect dial attempt (err is nil on success).
+	OnReconnect func(attempt int, err error)
+
+	// MaxInflight bounds MultiplexedTransport's concurrent in-flight Call
+	// invocations; Call blocks until a slot frees up (honoring ctx.Done())
+	// rather than letting the pending map grow without bound. <= 0 means
+	// unbounded.
+	MaxInflight int
+
+	// Chaos and InnerTransport configure ChaosTransport when Type is
+	// "chaos": InnerTransport is built via NewTransport and wrapped, Chaos
+	// selects which perturbations are applied around it. A nil Chaos means
+	// no perturbation (useful for toggling chaos on/off without branching
+	// on Type elsewhere).
+	Chaos          *ChaosConfig
+	InnerTransport *TransportConfig
+
+	// CircuitBreaker, if non-nil, wraps the transport built from the rest of
+	// this config in a CircuitBreakerTransport. A nil CircuitBreaker means no
+	// breaker - NewTransport returns the underlying transport unwrapped.
+	CircuitBreaker *CircuitBreakerConfig
+
+	// LocalHandler is read when Type is "local": NewTransport returns a
+	// localTransport wrapping it instead of dialing anything. See
+	// PoolWithTransport.RegisterLocal.
+	LocalHandler LocalHandler
+
+	// Backoff controls UDSTransport's retry-with-backoff when acquiring a
+	// connection fails (e.g. the worker is mid-restart). A nil Backoff
+	// defaults to NewConnectionBackoff(). Options["max_retries"] (int,
+	// default 3) bounds how many additional attempts Call makes beyond the
+	// first before giving up.
+	Backoff BackoffStrategy
+
+	// BufferPool overrides the framing.BufferPool UDSTransport and
+	// MultiplexedTransport read/write frames through. Nil uses framing's own
+	// shared default (a bucketed sync.Pool); set it to framing.NopBufferPool{}
+	// to A/B benchmark against the unpooled allocator, or to a custom
+	// BufferPool sized for an unusually skewed payload distribution.
+	BufferPool framing.BufferPool
 }
 
 // NewTransport creates a new transport based on configuration
 func NewTransport(config TransportConfig, logger *Logger) (Transport, error) {
+	transport, err := newTransportByType(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.CircuitBreaker != nil {
+		return NewCircuitBreakerTransport(transport, *config.CircuitBreaker, logger), nil
+	}
+	return transport, nil
+}
+
+// newTransportByType builds the transport named by config.Type, before any
+// CircuitBreaker wrapping NewTransport applies on top.
+func newTransportByType(config TransportConfig, logger *Logger) (Transport, error) {
 	switch config.Type {
 	case "uds", "":
 		// Default to UDS for backward compatibility
@@ -36,8 +111,14 @@ func NewTransport(config TransportConfig, logger *Logger) (Transport, error) {
 	case "multiplexed":
 		// Multiplexed transport with request ID support
 		return NewMultiplexedTransport(config, logger)
-	case "grpc-tcp", "grpc-uds":
+	case "grpc-tcp", "grpc-uds", "grpc-tcp+tls":
 		return NewGRPCTransport(config, logger)
+	case "json-rpc":
+		return NewJSONRPCTransport(config, logger)
+	case "chaos":
+		return newChaosTransportFromConfig(config, logger)
+	case "local":
+		return NewLocalTransport(config.LocalHandler), nil
 	default:
 		return nil, fmt.Errorf("unknown transport type: %s", config.Type)
 	}
@@ -0,0 +1,174 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// ErrChaosInjected is returned by ChaosTransport.Call when ChaosConfig's
+// ErrorProbability fires for that call.
+var ErrChaosInjected = errors.New("chaos transport: injected error")
+
+// ErrChaosDropped is returned by ChaosTransport.Call once ChaosConfig.DropAfter
+// calls have been made; the wrapped transport is closed and every call from
+// then on fails the same way, simulating a worker crash mid-session.
+var ErrChaosDropped = errors.New("chaos transport: connection force-dropped")
+
+// ChaosConfig configures the perturbations ChaosTransport applies around
+// every Call, so a caller's own retry/deadline/circuit-breaker logic can be
+// exercised deterministically against a real worker instead of a flaky
+// network.
+type ChaosConfig struct {
+	// Latency is added before every Call reaches the wrapped transport.
+	// LatencyFunc, if set, is called instead for each Call and takes
+	// precedence over Latency - e.g. to sample from a distribution rather
+	// than use a fixed delay.
+	Latency     time.Duration
+	LatencyFunc func() time.Duration
+
+	// ErrorProbability is the chance, in [0, 1], that a Call fails with
+	// ErrChaosInjected instead of reaching the wrapped transport.
+	ErrorProbability float64
+
+	// DropAfter, if > 0, force-closes the wrapped transport once this many
+	// calls have been made: the next call and every one after it fails with
+	// ErrChaosDropped. 0 means never force a drop.
+	DropAfter int
+
+	// ReadBytesPerSec/WriteBytesPerSec, if > 0, cap the simulated transfer
+	// rate of the response/request payload: Call sleeps for
+	// len(bytes)/rate before continuing, approximating a token-bucket link
+	// (see linkio) without needing access to the wrapped transport's raw
+	// conn, which the Transport interface doesn't expose.
+	ReadBytesPerSec  int64
+	WriteBytesPerSec int64
+}
+
+// ChaosTransport wraps another Transport and perturbs it per ChaosConfig.
+// Construct one directly via NewChaosTransport, or via NewTransport with
+// TransportConfig{Type: "chaos", Chaos: &cfg, InnerTransport: &innerCfg}.
+type ChaosTransport struct {
+	inner  Transport
+	config ChaosConfig
+
+	calls   atomic.Int64
+	dropped atomic.Bool
+}
+
+// NewChaosTransport wraps inner with the perturbations in config.
+func NewChaosTransport(inner Transport, config ChaosConfig) *ChaosTransport {
+	return &ChaosTransport{inner: inner, config: config}
+}
+
+// Call applies config's perturbations, in order (forced drop, random error,
+// latency, bandwidth cap), before and after forwarding to the wrapped
+// transport.
+func (c *ChaosTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	if c.dropped.Load() {
+		return nil, ErrChaosDropped
+	}
+
+	if n := c.calls.Add(1); c.config.DropAfter > 0 && n > int64(c.config.DropAfter) {
+		c.dropped.Store(true)
+		_ = c.inner.Close()
+		return nil, ErrChaosDropped
+	}
+
+	if c.config.ErrorProbability > 0 && rand.Float64() < c.config.ErrorProbability {
+		return nil, ErrChaosInjected
+	}
+
+	delay := c.config.Latency
+	if c.config.LatencyFunc != nil {
+		delay = c.config.LatencyFunc()
+	}
+	if delay > 0 {
+		if err := chaosSleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.config.WriteBytesPerSec > 0 {
+		if err := chaosSleep(ctx, transferDelay(len(req.Body)+len(req.Method), c.config.WriteBytesPerSec)); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.inner.Call(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if c.config.ReadBytesPerSec > 0 {
+		if err := chaosSleep(ctx, transferDelay(len(resp.Body), c.config.ReadBytesPerSec)); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Close closes the wrapped transport. It's safe to call even after a forced
+// drop already closed it.
+func (c *ChaosTransport) Close() error {
+	return c.inner.Close()
+}
+
+// IsHealthy reports the wrapped transport's health, or false once a forced
+// drop has fired.
+func (c *ChaosTransport) IsHealthy() bool {
+	if c.dropped.Load() {
+		return false
+	}
+	return c.inner.IsHealthy()
+}
+
+// transferDelay approximates how long n bytes take to cross a link capped
+// at bytesPerSec.
+func transferDelay(n int, bytesPerSec int64) time.Duration {
+	if n <= 0 || bytesPerSec <= 0 {
+		return 0
+	}
+	return time.Duration(float64(n) / float64(bytesPerSec) * float64(time.Second))
+}
+
+// chaosSleep waits for d, returning ctx.Err() if ctx is done first.
+func chaosSleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newChaosTransportFromConfig builds a ChaosTransport from a TransportConfig
+// with Type "chaos", used by NewTransport.
+func newChaosTransportFromConfig(config TransportConfig, logger *Logger) (*ChaosTransport, error) {
+	if config.InnerTransport == nil {
+		return nil, fmt.Errorf("chaos transport requires InnerTransport")
+	}
+
+	inner, err := NewTransport(*config.InnerTransport, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inner transport for chaos: %w", err)
+	}
+
+	var chaosCfg ChaosConfig
+	if config.Chaos != nil {
+		chaosCfg = *config.Chaos
+	}
+
+	return NewChaosTransport(inner, chaosCfg), nil
+}
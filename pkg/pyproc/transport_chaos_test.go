@@ -0,0 +1,115 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// fakeTransport is a minimal Transport whose Call always succeeds (unless
+// closed), used to exercise ChaosTransport's perturbations in isolation.
+type fakeTransport struct {
+	calls  atomic.Int64
+	closed atomic.Bool
+}
+
+func (f *fakeTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	if f.closed.Load() {
+		return nil, errors.New("fakeTransport: closed")
+	}
+	f.calls.Add(1)
+	return protocol.NewResponse(req.ID, map[string]interface{}{"ok": true})
+}
+
+func (f *fakeTransport) Close() error {
+	f.closed.Store(true)
+	return nil
+}
+
+func (f *fakeTransport) IsHealthy() bool {
+	return !f.closed.Load()
+}
+
+func TestChaosTransportErrorProbability(t *testing.T) {
+	inner := &fakeTransport{}
+	chaos := NewChaosTransport(inner, ChaosConfig{ErrorProbability: 1})
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	_, err := chaos.Call(context.Background(), req)
+	if !errors.Is(err, ErrChaosInjected) {
+		t.Errorf("expected ErrChaosInjected, got %v", err)
+	}
+	if inner.calls.Load() != 0 {
+		t.Errorf("expected the wrapped transport to never be called, got %d calls", inner.calls.Load())
+	}
+}
+
+func TestChaosTransportNoPerturbation(t *testing.T) {
+	inner := &fakeTransport{}
+	chaos := NewChaosTransport(inner, ChaosConfig{})
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	resp, err := chaos.Call(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK {
+		t.Errorf("expected a successful response, got %+v", resp)
+	}
+	if inner.calls.Load() != 1 {
+		t.Errorf("expected exactly 1 call to reach the wrapped transport, got %d", inner.calls.Load())
+	}
+}
+
+func TestChaosTransportDropAfter(t *testing.T) {
+	inner := &fakeTransport{}
+	chaos := NewChaosTransport(inner, ChaosConfig{DropAfter: 2})
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	for i := 0; i < 2; i++ {
+		if _, err := chaos.Call(context.Background(), req); err != nil {
+			t.Fatalf("call %d: expected success before the drop, got %v", i, err)
+		}
+	}
+
+	if _, err := chaos.Call(context.Background(), req); !errors.Is(err, ErrChaosDropped) {
+		t.Errorf("expected ErrChaosDropped on the triggering call, got %v", err)
+	}
+	if chaos.IsHealthy() {
+		t.Error("expected IsHealthy() to be false after a forced drop")
+	}
+	if _, err := chaos.Call(context.Background(), req); !errors.Is(err, ErrChaosDropped) {
+		t.Errorf("expected ErrChaosDropped on every call after the drop, got %v", err)
+	}
+}
+
+func TestChaosTransportLatency(t *testing.T) {
+	inner := &fakeTransport{}
+	chaos := NewChaosTransport(inner, ChaosConfig{Latency: 20 * time.Millisecond})
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	start := time.Now()
+	if _, err := chaos.Call(context.Background(), req); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected Call to take at least the configured latency, took %v", elapsed)
+	}
+}
+
+func TestChaosTransportLatencyRespectsContext(t *testing.T) {
+	inner := &fakeTransport{}
+	chaos := NewChaosTransport(inner, ChaosConfig{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	if _, err := chaos.Call(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// ErrCircuitOpen is returned by CircuitBreakerTransport.Call instead of
+// reaching the wrapped transport while the breaker is Open, or while it's
+// HalfOpen and already has HalfOpenMaxProbes calls in flight.
+var ErrCircuitOpen = errors.New("circuit breaker: transport is open")
+
+// circuitState is a CircuitBreakerTransport's current state.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures CircuitBreakerTransport.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive Call failures, all within
+	// RollingWindow of each other, trip the breaker from Closed to Open.
+	// <= 0 defaults to 1 (trip on the very first failure).
+	FailureThreshold int
+	// RollingWindow bounds how far apart consecutive failures can be and
+	// still count toward FailureThreshold; a failure older than this when
+	// the next one arrives drops off the count instead of accumulating
+	// forever. <= 0 defaults to 1 minute.
+	RollingWindow time.Duration
+	// Cooldown is how long the breaker stays Open before moving to HalfOpen
+	// and admitting up to HalfOpenMaxProbes probes. <= 0 defaults to 30 seconds.
+	Cooldown time.Duration
+	// HalfOpenMaxProbes bounds how many calls may be in flight at once while
+	// HalfOpen. <= 0 defaults to 1.
+	HalfOpenMaxProbes int
+
+	// OnTrip, if set, is called every time the breaker transitions Closed ->
+	// Open or HalfOpen -> Open, mirroring TransportConfig's OnReconnect hook.
+	// PoolWithTransport uses this to feed its breaker-trips metric.
+	OnTrip func()
+}
+
+// CircuitBreakerTransport wraps another Transport and stops sending it calls
+// after a burst of failures, instead of retrying a dead worker on every
+// request. It starts Closed (calls pass straight through); FailureThreshold
+// consecutive failures within RollingWindow trip it to Open, where every
+// Call fails immediately with ErrCircuitOpen until Cooldown elapses; then it
+// moves to HalfOpen and lets up to HalfOpenMaxProbes calls through at once as
+// a probe - any probe failure reopens the breaker immediately, and any probe
+// success returns it to Closed.
+type CircuitBreakerTransport struct {
+	inner  Transport
+	config CircuitBreakerConfig
+	logger *Logger
+
+	mu               sync.Mutex
+	state            circuitState
+	failureTimes     []time.Time
+	openedAt         time.Time
+	halfOpenInFlight int
+}
+
+// NewCircuitBreakerTransport wraps inner with a circuit breaker configured
+// by config. A zero-value config uses the defaults documented on
+// CircuitBreakerConfig's fields.
+func NewCircuitBreakerTransport(inner Transport, config CircuitBreakerConfig, logger *Logger) *CircuitBreakerTransport {
+	return &CircuitBreakerTransport{
+		inner:  inner,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Call forwards to the wrapped transport unless the breaker currently
+// refuses admission (Open, or HalfOpen with no probe slot free).
+func (c *CircuitBreakerTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	if !c.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := c.inner.Call(ctx, req)
+	c.report(err == nil)
+	return resp, err
+}
+
+// allow reports whether a call may proceed right now, transitioning Open ->
+// HalfOpen itself once Cooldown has elapsed and reserving a probe slot if so.
+func (c *CircuitBreakerTransport) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case circuitClosed:
+		return true
+
+	case circuitOpen:
+		if time.Since(c.openedAt) < c.cooldown() {
+			return false
+		}
+		c.transitionTo(circuitHalfOpen)
+		fallthrough
+
+	case circuitHalfOpen:
+		if c.halfOpenInFlight >= c.halfOpenMaxProbes() {
+			return false
+		}
+		c.halfOpenInFlight++
+		return true
+	}
+	return true
+}
+
+// report records a Call outcome and applies any resulting state transition.
+func (c *CircuitBreakerTransport) report(success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasHalfOpen := c.state == circuitHalfOpen
+	if wasHalfOpen {
+		c.halfOpenInFlight--
+	}
+
+	if success {
+		c.failureTimes = nil
+		if c.state != circuitClosed {
+			c.transitionTo(circuitClosed)
+		}
+		return
+	}
+
+	if wasHalfOpen {
+		// A probe failing means the worker isn't actually recovered yet -
+		// reopen immediately rather than letting other probes keep trying.
+		c.trip()
+		return
+	}
+
+	now := time.Now()
+	window := c.rollingWindow()
+	kept := c.failureTimes[:0]
+	for _, t := range c.failureTimes {
+		if now.Sub(t) <= window {
+			kept = append(kept, t)
+		}
+	}
+	c.failureTimes = append(kept, now)
+
+	if len(c.failureTimes) >= c.failureThreshold() {
+		c.trip()
+	}
+}
+
+// trip transitions to Open and resets the failure/success bookkeeping that
+// led to it. Caller must hold c.mu.
+func (c *CircuitBreakerTransport) trip() {
+	c.transitionTo(circuitOpen)
+	c.openedAt = time.Now()
+	c.failureTimes = nil
+	if c.config.OnTrip != nil {
+		c.config.OnTrip()
+	}
+}
+
+// transitionTo changes state and logs the transition. Caller must hold c.mu.
+func (c *CircuitBreakerTransport) transitionTo(next circuitState) {
+	if next == c.state {
+		return
+	}
+	prev := c.state
+	c.state = next
+	if c.logger != nil {
+		c.logger.Info("circuit breaker state change", "from", prev.String(), "to", next.String())
+	}
+}
+
+func (c *CircuitBreakerTransport) failureThreshold() int {
+	if c.config.FailureThreshold <= 0 {
+		return 1
+	}
+	return c.config.FailureThreshold
+}
+
+func (c *CircuitBreakerTransport) rollingWindow() time.Duration {
+	if c.config.RollingWindow <= 0 {
+		return time.Minute
+	}
+	return c.config.RollingWindow
+}
+
+func (c *CircuitBreakerTransport) cooldown() time.Duration {
+	if c.config.Cooldown <= 0 {
+		return 30 * time.Second
+	}
+	return c.config.Cooldown
+}
+
+func (c *CircuitBreakerTransport) halfOpenMaxProbes() int {
+	if c.config.HalfOpenMaxProbes <= 0 {
+		return 1
+	}
+	return c.config.HalfOpenMaxProbes
+}
+
+// Close closes the wrapped transport.
+func (c *CircuitBreakerTransport) Close() error {
+	return c.inner.Close()
+}
+
+// IsHealthy reports the wrapped transport's health AND-ed with the breaker
+// not being Open; HalfOpen still reports healthy so TransportPool.Call is
+// willing to route a probe to it.
+func (c *CircuitBreakerTransport) IsHealthy() bool {
+	c.mu.Lock()
+	open := c.state == circuitOpen
+	c.mu.Unlock()
+	if open {
+		return false
+	}
+	return c.inner.IsHealthy()
+}
+
+// circuitBreakerState reports the breaker's current state, for
+// TransportPool.CircuitStatus to aggregate without needing a type assertion
+// anywhere else in the package.
+func (c *CircuitBreakerTransport) circuitBreakerState() circuitState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
@@ -0,0 +1,184 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// failingTransport always returns an error from Call, to trip
+// CircuitBreakerTransport's failure counting deterministically.
+type failingTransport struct {
+	calls atomic.Int64
+}
+
+var errFailingTransport = errors.New("failingTransport: always fails")
+
+func (f *failingTransport) Call(context.Context, *protocol.Request) (*protocol.Response, error) {
+	f.calls.Add(1)
+	return nil, errFailingTransport
+}
+
+func (f *failingTransport) Close() error    { return nil }
+func (f *failingTransport) IsHealthy() bool { return true }
+
+func TestCircuitBreakerTransportStartsClosed(t *testing.T) {
+	cb := NewCircuitBreakerTransport(&fakeTransport{}, CircuitBreakerConfig{}, nil)
+	if cb.circuitBreakerState() != circuitClosed {
+		t.Errorf("expected a new breaker to start Closed, got %s", cb.circuitBreakerState())
+	}
+	if !cb.IsHealthy() {
+		t.Error("expected a Closed breaker over a healthy transport to report healthy")
+	}
+}
+
+func TestCircuitBreakerTransportTripsAfterThreshold(t *testing.T) {
+	inner := &failingTransport{}
+	cb := NewCircuitBreakerTransport(inner, CircuitBreakerConfig{FailureThreshold: 3}, nil)
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	for i := 0; i < 2; i++ {
+		if _, err := cb.Call(context.Background(), req); !errors.Is(err, errFailingTransport) {
+			t.Fatalf("call %d: expected the underlying failure, got %v", i, err)
+		}
+	}
+	if cb.circuitBreakerState() != circuitClosed {
+		t.Fatalf("expected breaker to stay Closed before threshold, got %s", cb.circuitBreakerState())
+	}
+
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, errFailingTransport) {
+		t.Fatalf("expected the tripping call to still return the underlying failure, got %v", err)
+	}
+	if cb.circuitBreakerState() != circuitOpen {
+		t.Fatalf("expected breaker to be Open after %d consecutive failures, got %s", 3, cb.circuitBreakerState())
+	}
+
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen once tripped, got %v", err)
+	}
+	if inner.calls.Load() != 3 {
+		t.Errorf("expected the wrapped transport to see exactly 3 calls, got %d", inner.calls.Load())
+	}
+}
+
+func TestCircuitBreakerTransportFailuresOutsideWindowDontAccumulate(t *testing.T) {
+	inner := &failingTransport{}
+	cb := NewCircuitBreakerTransport(inner, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		RollingWindow:    10 * time.Millisecond,
+	}, nil)
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, errFailingTransport) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, errFailingTransport) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if cb.circuitBreakerState() != circuitClosed {
+		t.Errorf("expected breaker to stay Closed when failures fall outside RollingWindow, got %s", cb.circuitBreakerState())
+	}
+}
+
+func TestCircuitBreakerTransportHalfOpenProbeSuccessCloses(t *testing.T) {
+	inner := &fakeTransport{}
+	cb := NewCircuitBreakerTransport(inner, CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         10 * time.Millisecond,
+	}, nil)
+	cb.trip()
+
+	time.Sleep(20 * time.Millisecond)
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	if _, err := cb.Call(context.Background(), req); err != nil {
+		t.Fatalf("expected the probe to reach the now-healthy wrapped transport, got %v", err)
+	}
+	if cb.circuitBreakerState() != circuitClosed {
+		t.Errorf("expected a successful HalfOpen probe to close the breaker, got %s", cb.circuitBreakerState())
+	}
+}
+
+func TestCircuitBreakerTransportHalfOpenProbeFailureReopens(t *testing.T) {
+	inner := &failingTransport{}
+	cb := NewCircuitBreakerTransport(inner, CircuitBreakerConfig{
+		FailureThreshold: 1,
+		Cooldown:         10 * time.Millisecond,
+	}, nil)
+	cb.trip()
+
+	time.Sleep(20 * time.Millisecond)
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, errFailingTransport) {
+		t.Fatalf("expected the probe's underlying failure, got %v", err)
+	}
+	if cb.circuitBreakerState() != circuitOpen {
+		t.Errorf("expected a failed HalfOpen probe to reopen the breaker, got %s", cb.circuitBreakerState())
+	}
+
+	if _, err := cb.Call(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen immediately after reopening, got %v", err)
+	}
+}
+
+func TestCircuitBreakerTransportHalfOpenCapsConcurrentProbes(t *testing.T) {
+	cb := NewCircuitBreakerTransport(&fakeTransport{}, CircuitBreakerConfig{
+		Cooldown:          10 * time.Millisecond,
+		HalfOpenMaxProbes: 2,
+	}, nil)
+	cb.trip()
+	time.Sleep(20 * time.Millisecond)
+
+	allowed := 0
+	for i := 0; i < 4; i++ {
+		if cb.allow() {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("expected exactly HalfOpenMaxProbes (2) concurrent probes to be allowed, got %d", allowed)
+	}
+}
+
+func TestCircuitBreakerTransportDefaults(t *testing.T) {
+	cb := NewCircuitBreakerTransport(&fakeTransport{}, CircuitBreakerConfig{}, nil)
+	if got := cb.failureThreshold(); got != 1 {
+		t.Errorf("failureThreshold() = %d, want 1", got)
+	}
+	if got := cb.rollingWindow(); got != time.Minute {
+		t.Errorf("rollingWindow() = %v, want 1m", got)
+	}
+	if got := cb.cooldown(); got != 30*time.Second {
+		t.Errorf("cooldown() = %v, want 30s", got)
+	}
+	if got := cb.halfOpenMaxProbes(); got != 1 {
+		t.Errorf("halfOpenMaxProbes() = %d, want 1", got)
+	}
+}
+
+func TestCircuitBreakerTransportIsHealthyFalseWhileOpen(t *testing.T) {
+	cb := NewCircuitBreakerTransport(&fakeTransport{}, CircuitBreakerConfig{Cooldown: time.Hour}, nil)
+	cb.trip()
+	if cb.IsHealthy() {
+		t.Error("expected IsHealthy() to be false while the breaker is Open")
+	}
+}
+
+func TestCircuitBreakerTransportClosePropagates(t *testing.T) {
+	inner := &fakeTransport{}
+	cb := NewCircuitBreakerTransport(inner, CircuitBreakerConfig{}, nil)
+	if err := cb.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if inner.IsHealthy() {
+		t.Error("expected Close to propagate to the wrapped transport")
+	}
+}
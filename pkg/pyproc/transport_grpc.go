@@ -1,19 +1,49 @@
+//go:build grpc
+
 package pyproc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	pyprocv1 "github.com/YuminosukeSato/pyproc/api/v1"
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
+// GRPCTLSConfig configures transport credentials for the "grpc-tcp+tls"
+// transport type (and any other grpc-* type that sets
+// TransportConfig.Options["tls"] to a *GRPCTLSConfig).
+// An empty GRPCTLSConfig still enables server certificate verification via
+// the host's root CA pool; CAFile only needs to be set for a private CA.
+type GRPCTLSConfig struct {
+	// CAFile is a PEM bundle used to verify the server certificate. Empty
+	// falls back to the system root CA pool.
+	CAFile string
+	// CertFile and KeyFile present a client certificate for mTLS. Leaving
+	// either empty disables client certificate authentication.
+	CertFile string
+	KeyFile  string
+	// ServerNameOverride overrides the server name used for SNI and
+	// certificate verification, e.g. when dialing by IP.
+	ServerNameOverride string
+	// InsecureSkipVerify disables server certificate verification. Only
+	// ever meant for local testing.
+	InsecureSkipVerify bool
+}
+
 // GRPCTransport implements Transport using gRPC
 type GRPCTransport struct {
 	config  TransportConfig
@@ -23,32 +53,80 @@ type GRPCTransport struct {
 	mu      sync.RWMutex
 	closed  bool
 	healthy bool
+
+	maxRetries   int
+	retryBackoff BackoffStrategy
+
+	// reconnectAttempts counts every dial attempt beyond the first made by
+	// connectWithRetry, so operators can see backoff behavior on a flapping
+	// or slow-to-start gRPC server.
+	reconnectAttempts atomic.Uint64
+}
+
+// ReconnectAttempts reports how many retried dial attempts connectWithRetry
+// has made since the transport was created.
+func (t *GRPCTransport) ReconnectAttempts() uint64 {
+	return t.reconnectAttempts.Load()
 }
 
-// NewGRPCTransport creates a new gRPC transport
+// NewGRPCTransport creates a new gRPC transport.
+//
+// config.Options may set:
+//   - "per_rpc_credentials" (credentials.PerRPCCredentials): attached to
+//     every RPC, e.g. BearerTokenCredentials for a static token.
+//   - "max_retries" (int): number of retries for calls that fail with a
+//     retryable gRPC status (Unavailable, DeadlineExceeded, ResourceExhausted).
+//   - "retry_backoff" (BackoffStrategy): delay strategy between retries;
+//     defaults to exponential backoff with full jitter.
 func NewGRPCTransport(config TransportConfig, logger *Logger) (*GRPCTransport, error) {
-	// gRPC transport is not fully implemented yet
-	return nil, fmt.Errorf("gRPC transport is not yet implemented")
-	
-	// Original implementation commented out for future use:
-	/*
 	if config.Address == "" {
 		return nil, fmt.Errorf("address is required for gRPC transport")
 	}
 
+	maxRetries := 0
+	if v, ok := config.Options["max_retries"].(int); ok {
+		maxRetries = v
+	}
+
+	retryBackoff, _ := config.Options["retry_backoff"].(BackoffStrategy)
+	if retryBackoff == nil {
+		retryBackoff = NewBackoffStrategy(BackoffExponentialJitter, RestartConfig{})
+	}
+
 	transport := &GRPCTransport{
-		config:  config,
-		logger:  logger,
-		healthy: false,
+		config:       config,
+		logger:       logger,
+		healthy:      false,
+		maxRetries:   maxRetries,
+		retryBackoff: retryBackoff,
 	}
 
-	// Connect to gRPC server
-	if err := transport.connect(); err != nil {
+	// Connect to gRPC server, retrying with backoff rather than hammering a
+	// server that's still starting up or briefly unreachable.
+	if err := transport.connectWithRetry(); err != nil {
 		return nil, err
 	}
 
 	return transport, nil
-	*/
+}
+
+// connectWithRetry calls connect, retrying up to maxRetries times with
+// retryBackoff between attempts on failure.
+func (t *GRPCTransport) connectWithRetry() error {
+	var lastErr error
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if attempt > 0 {
+			t.reconnectAttempts.Add(1)
+			time.Sleep(t.retryBackoff.NextDelay(attempt - 1))
+		}
+
+		err := t.connect()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return lastErr
 }
 
 // connect establishes the gRPC connection
@@ -61,9 +139,14 @@ func (t *GRPCTransport) connect() error {
 		_ = t.conn.Close()
 	}
 
+	transportCreds, err := t.transportCredentials()
+	if err != nil {
+		return err
+	}
+
 	// Configure gRPC options
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCreds),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                10 * time.Second,
 			Timeout:             3 * time.Second,
@@ -71,10 +154,14 @@ func (t *GRPCTransport) connect() error {
 		}),
 	}
 
+	if perRPC, ok := t.config.Options["per_rpc_credentials"].(credentials.PerRPCCredentials); ok && perRPC != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(perRPC))
+	}
+
 	// Determine target based on transport type
 	var target string
 	switch t.config.Type {
-	case "grpc-tcp":
+	case "grpc-tcp", "grpc-tcp+tls":
 		target = t.config.Address
 	case "grpc-uds":
 		target = "unix://" + t.config.Address
@@ -97,8 +184,76 @@ func (t *GRPCTransport) connect() error {
 	return nil
 }
 
-// Call sends a request and receives a response via gRPC
+// transportCredentials builds the DialOption credentials for t.config:
+// plaintext unless the transport type is "grpc-tcp+tls" or a TLS config was
+// explicitly provided, in which case it builds a *tls.Config from
+// GRPCTLSConfig (loading a client certificate for mTLS if CertFile is set).
+func (t *GRPCTransport) transportCredentials() (credentials.TransportCredentials, error) {
+	tlsCfg, ok := t.config.Options["tls"].(*GRPCTLSConfig)
+	if t.config.Type != "grpc-tcp+tls" && !ok {
+		return insecure.NewCredentials(), nil
+	}
+	if tlsCfg == nil {
+		tlsCfg = &GRPCTLSConfig{}
+	}
+
+	goTLSConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         tlsCfg.ServerNameOverride,
+		InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+	}
+
+	if tlsCfg.CAFile != "" {
+		caCert, err := os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", tlsCfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA bundle %s", tlsCfg.CAFile)
+		}
+		goTLSConfig.RootCAs = pool
+	}
+
+	if tlsCfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate %s/%s: %w", tlsCfg.CertFile, tlsCfg.KeyFile, err)
+		}
+		goTLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(goTLSConfig), nil
+}
+
+// Call sends a request and receives a response via gRPC, retrying up to
+// maxRetries times (configured via TransportConfig.Options["max_retries"])
+// when the failure is a retryable gRPC status.
 func (t *GRPCTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(t.retryBackoff.NextDelay(attempt - 1)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		resp, err := t.callOnce(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryableGRPCError(err) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+// callOnce performs a single gRPC Call attempt without retrying.
+func (t *GRPCTransport) callOnce(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
 	t.mu.RLock()
 	client := t.client
 	closed := t.closed
@@ -143,6 +298,17 @@ func (t *GRPCTransport) Call(ctx context.Context, req *protocol.Request) (*proto
 	return resp, nil
 }
 
+// isRetryableGRPCError reports whether a failed Call is worth retrying:
+// transient conditions rather than a rejection of the request itself.
+func isRetryableGRPCError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
 // Close closes the gRPC connection
 func (t *GRPCTransport) Close() error {
 	t.mu.Lock()
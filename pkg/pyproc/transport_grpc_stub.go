@@ -0,0 +1,15 @@
+//go:build !grpc
+
+package pyproc
+
+import "fmt"
+
+// NewGRPCTransport is a stand-in for the real gRPC transport in
+// transport_grpc.go, built when the "grpc" tag isn't set. The real
+// transport depends on the api/v1 package generated from
+// proto/pyproc.proto, which isn't vendored into every build - rebuild with
+// -tags grpc after generating it to get a working "grpc-tcp"/"grpc-uds"/
+// "grpc-tcp+tls" transport.
+func NewGRPCTransport(config TransportConfig, logger *Logger) (Transport, error) {
+	return nil, fmt.Errorf("pyproc: grpc transport not built into this binary (rebuild with -tags grpc after generating api/v1)")
+}
@@ -0,0 +1,506 @@
+package pyproc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// JSONRPCError is a structured JSON-RPC 2.0 error response (code/message/
+// optional data). JSONRPCTransport returns it as-is from Call/Notify/
+// CallBatch instead of flattening it into a plain errors.New, so callers
+// can recover it with errors.As and branch on Code.
+type JSONRPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// jsonrpcRequest is the wire representation of one JSON-RPC 2.0 call. ID is
+// a nil interface{} for notifications, which omitempty drops entirely -
+// per spec a notification carries no "id" member at all.
+type jsonrpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      interface{} `json:"id,omitempty"`
+}
+
+// jsonrpcResponse is the wire representation of one JSON-RPC 2.0 response.
+type jsonrpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	ID      uint64          `json:"id"`
+}
+
+// BatchRequest is one call within a JSON-RPC 2.0 batch sent by CallBatch.
+type BatchRequest struct {
+	Method string
+	Params interface{}
+}
+
+// BatchResponse is one call's outcome within a CallBatch result, in the same
+// order as the BatchRequest slice passed in. Err is a *JSONRPCError for an
+// application-level failure, nil otherwise.
+type BatchResponse struct {
+	Result json.RawMessage
+	Err    error
+}
+
+// jsonrpcFramer is the minimal message-delimiting behavior JSONRPCTransport
+// needs. framing.Framer (pyproc's usual 4-byte length prefix) and
+// contentLengthFramer (HTTP-style Content-Length headers, the LSP wire
+// convention) both implement it.
+type jsonrpcFramer interface {
+	WriteMessage(data []byte) error
+	ReadMessage() ([]byte, error)
+}
+
+// jsonrpcPending tracks one outstanding JSON-RPC id, whether issued alone or
+// as part of a batch.
+type jsonrpcPending struct {
+	resultCh chan json.RawMessage
+	errCh    chan error
+}
+
+// JSONRPCTransport implements Transport by speaking JSON-RPC 2.0 over a
+// single long-lived UDS connection to a standards-compliant Python worker
+// (e.g. python-jsonrpc-server, jsonrpcserver), rather than pyproc's own
+// framed Request/Response protocol. A dedicated writer goroutine serializes
+// writes and a reader goroutine demultiplexes responses - including batch
+// array responses, whose elements are dispatched the same way as single
+// responses - back to their caller by id, so single calls, notifications,
+// and batches can all be in flight on the connection at once.
+type JSONRPCTransport struct {
+	config TransportConfig
+	logger *Logger
+
+	connMu sync.RWMutex
+	conn   net.Conn
+	framer jsonrpcFramer
+
+	nextID atomic.Uint64
+
+	pendingMu sync.Mutex
+	pending   map[uint64]*jsonrpcPending
+
+	writeCh chan []byte
+
+	closed   atomic.Bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewJSONRPCTransport dials config.Address and starts its writer/reader
+// goroutines. config.Options["framing"] selects the wire framing:
+// "content-length" for HTTP-style Content-Length headers, anything else
+// (including unset) for pyproc's usual 4-byte length prefix.
+func NewJSONRPCTransport(config TransportConfig, logger *Logger) (*JSONRPCTransport, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("address is required for JSON-RPC transport")
+	}
+
+	t := &JSONRPCTransport{
+		config:  config,
+		logger:  logger,
+		pending: make(map[uint64]*jsonrpcPending),
+		writeCh: make(chan []byte, 64),
+		stopCh:  make(chan struct{}),
+	}
+
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+
+	t.wg.Add(2)
+	go t.writeLoop()
+	go t.readLoop()
+
+	return t, nil
+}
+
+func (t *JSONRPCTransport) connect() error {
+	timeout := 5 * time.Second
+	if timeoutVal, ok := t.config.Options["timeout"].(time.Duration); ok {
+		timeout = timeoutVal
+	}
+
+	conn, err := net.DialTimeout("unix", t.config.Address, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", t.config.Address, err)
+	}
+
+	t.connMu.Lock()
+	t.conn = conn
+	if framingName, _ := t.config.Options["framing"].(string); framingName == "content-length" {
+		t.framer = newContentLengthFramer(conn)
+	} else {
+		t.framer = framing.NewFramer(conn)
+	}
+	t.connMu.Unlock()
+
+	t.logger.Debug("JSON-RPC transport connected", "address", t.config.Address, "framing", t.config.Options["framing"])
+	return nil
+}
+
+// Call implements Transport by issuing req as a single JSON-RPC 2.0 call and
+// waiting for its response. A JSON-RPC-level failure is returned as a
+// *JSONRPCError, not folded into the returned protocol.Response.
+func (t *JSONRPCTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	if t.closed.Load() {
+		return nil, fmt.Errorf("transport is closed")
+	}
+
+	id := t.nextID.Add(1)
+	req.ID = id
+
+	var params interface{}
+	if len(req.Body) > 0 {
+		params = req.Body
+	}
+
+	result, err := t.do(ctx, id, req.Method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.Response{ID: id, OK: true, Body: result}, nil
+}
+
+// Notify sends method as a JSON-RPC 2.0 notification (no "id" member) and
+// returns as soon as it has been written - the spec says a server must not
+// reply to a notification, so there is nothing to wait for.
+func (t *JSONRPCTransport) Notify(ctx context.Context, method string, params interface{}) error {
+	if t.closed.Load() {
+		return fmt.Errorf("transport is closed")
+	}
+
+	data, err := json.Marshal(jsonrpcRequest{JSONRPC: "2.0", Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	select {
+	case t.writeCh <- data:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CallBatch sends reqs as a single JSON-RPC 2.0 batch (a top-level JSON
+// array) and returns one BatchResponse per request, in the same order.
+// Responses are demultiplexed by id exactly like single Call()s, so a
+// batch's responses can arrive interleaved with unrelated concurrent calls
+// on the same connection.
+func (t *JSONRPCTransport) CallBatch(ctx context.Context, reqs []BatchRequest) ([]BatchResponse, error) {
+	if t.closed.Load() {
+		return nil, fmt.Errorf("transport is closed")
+	}
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint64, len(reqs))
+	pendings := make([]*jsonrpcPending, len(reqs))
+	batch := make([]jsonrpcRequest, len(reqs))
+
+	for i, req := range reqs {
+		id := t.nextID.Add(1)
+		ids[i] = id
+		pendings[i] = t.register(id)
+		batch[i] = jsonrpcRequest{JSONRPC: "2.0", Method: req.Method, Params: req.Params, ID: id}
+	}
+	defer func() {
+		for _, id := range ids {
+			t.unregister(id)
+		}
+	}()
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	select {
+	case t.writeCh <- data:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	results := make([]BatchResponse, len(reqs))
+	for i, pending := range pendings {
+		select {
+		case result := <-pending.resultCh:
+			results[i] = BatchResponse{Result: result}
+		case err := <-pending.errCh:
+			results[i] = BatchResponse{Err: err}
+		case <-ctx.Done():
+			results[i] = BatchResponse{Err: ctx.Err()}
+		}
+	}
+
+	return results, nil
+}
+
+// do sends one JSON-RPC 2.0 request and waits for its matching response.
+func (t *JSONRPCTransport) do(ctx context.Context, id uint64, method string, params interface{}) (json.RawMessage, error) {
+	pending := t.register(id)
+	defer t.unregister(id)
+
+	data, err := json.Marshal(jsonrpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	select {
+	case t.writeCh <- data:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case result := <-pending.resultCh:
+		return result, nil
+	case err := <-pending.errCh:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *JSONRPCTransport) register(id uint64) *jsonrpcPending {
+	pending := &jsonrpcPending{
+		resultCh: make(chan json.RawMessage, 1),
+		errCh:    make(chan error, 1),
+	}
+	t.pendingMu.Lock()
+	t.pending[id] = pending
+	t.pendingMu.Unlock()
+	return pending
+}
+
+func (t *JSONRPCTransport) unregister(id uint64) {
+	t.pendingMu.Lock()
+	delete(t.pending, id)
+	t.pendingMu.Unlock()
+}
+
+// writeLoop is the sole goroutine that writes to the connection, so
+// concurrent Call()/Notify()/CallBatch() calls never interleave messages.
+func (t *JSONRPCTransport) writeLoop() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case data := <-t.writeCh:
+			t.connMu.RLock()
+			framer := t.framer
+			t.connMu.RUnlock()
+
+			if err := framer.WriteMessage(data); err != nil {
+				t.logger.Error("JSON-RPC transport: write failed", "error", err)
+				t.handleConnError(err)
+				return
+			}
+		}
+	}
+}
+
+// readLoop reads responses - single or batch - and delivers each to its
+// pending caller by id.
+func (t *JSONRPCTransport) readLoop() {
+	defer t.wg.Done()
+
+	for {
+		t.connMu.RLock()
+		framer := t.framer
+		t.connMu.RUnlock()
+
+		data, err := framer.ReadMessage()
+		if err != nil {
+			select {
+			case <-t.stopCh:
+				return
+			default:
+			}
+			t.logger.Error("JSON-RPC transport: read failed", "error", err)
+			t.handleConnError(err)
+			return
+		}
+
+		trimmed := strings.TrimLeft(string(data), " \t\r\n")
+		if strings.HasPrefix(trimmed, "[") {
+			var batch []jsonrpcResponse
+			if err := json.Unmarshal(data, &batch); err != nil {
+				t.logger.Error("JSON-RPC transport: failed to unmarshal batch response", "error", err)
+				continue
+			}
+			for _, resp := range batch {
+				t.deliver(resp)
+			}
+			continue
+		}
+
+		var resp jsonrpcResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			t.logger.Error("JSON-RPC transport: failed to unmarshal response", "error", err)
+			continue
+		}
+		t.deliver(resp)
+	}
+}
+
+func (t *JSONRPCTransport) deliver(resp jsonrpcResponse) {
+	t.pendingMu.Lock()
+	pending, ok := t.pending[resp.ID]
+	t.pendingMu.Unlock()
+
+	if !ok {
+		t.logger.Warn("JSON-RPC transport: response for unknown id", "id", resp.ID)
+		return
+	}
+
+	if resp.Error != nil {
+		pending.errCh <- resp.Error
+		return
+	}
+	pending.resultCh <- resp.Result
+}
+
+// handleConnError fails every pending call and marks the transport
+// unhealthy. Unlike MultiplexedConn, it does not reconnect on its own -
+// TransportPool already routes around an unhealthy transport, and the
+// caller (e.g. Pool) owns worker restart and reconnection policy.
+func (t *JSONRPCTransport) handleConnError(connErr error) {
+	t.pendingMu.Lock()
+	for id, pending := range t.pending {
+		select {
+		case pending.errCh <- fmt.Errorf("JSON-RPC transport: connection error: %w", connErr):
+		default:
+		}
+		delete(t.pending, id)
+	}
+	t.pendingMu.Unlock()
+
+	t.closed.Store(true)
+
+	t.connMu.RLock()
+	conn := t.conn
+	t.connMu.RUnlock()
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// Close shuts down the connection and its goroutines.
+func (t *JSONRPCTransport) Close() error {
+	var closeErr error
+
+	t.stopOnce.Do(func() {
+		t.closed.Store(true)
+		close(t.stopCh)
+
+		t.connMu.RLock()
+		conn := t.conn
+		t.connMu.RUnlock()
+		if conn != nil {
+			closeErr = conn.Close()
+		}
+
+		t.pendingMu.Lock()
+		for id, pending := range t.pending {
+			select {
+			case pending.errCh <- fmt.Errorf("JSON-RPC transport: closed"):
+			default:
+			}
+			delete(t.pending, id)
+		}
+		t.pendingMu.Unlock()
+	})
+
+	t.wg.Wait()
+	return closeErr
+}
+
+// IsHealthy reports whether the transport is usable.
+func (t *JSONRPCTransport) IsHealthy() bool {
+	return !t.closed.Load()
+}
+
+// contentLengthFramer implements jsonrpcFramer using HTTP-style
+// "Content-Length: N\r\n\r\n" headers ahead of each message body - the wire
+// convention used by LSP and some JSON-RPC 2.0 server libraries in place of
+// a raw length prefix.
+type contentLengthFramer struct {
+	rw io.ReadWriter
+	br *bufio.Reader
+}
+
+func newContentLengthFramer(rw io.ReadWriter) *contentLengthFramer {
+	return &contentLengthFramer{rw: rw, br: bufio.NewReader(rw)}
+}
+
+// WriteMessage writes data prefixed with its Content-Length header.
+func (f *contentLengthFramer) WriteMessage(data []byte) error {
+	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(data))
+	if _, err := f.rw.Write([]byte(header)); err != nil {
+		return fmt.Errorf("failed to write Content-Length header: %w", err)
+	}
+	if _, err := f.rw.Write(data); err != nil {
+		return fmt.Errorf("failed to write message body: %w", err)
+	}
+	return nil
+}
+
+// ReadMessage reads the header block up to the blank line separator, then
+// exactly Content-Length bytes of body.
+func (f *contentLengthFramer) ReadMessage() ([]byte, error) {
+	length := -1
+	for {
+		line, err := f.br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read header line: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header %q: %w", value, err)
+			}
+			length = n
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("message is missing a Content-Length header")
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(f.br, data); err != nil {
+		return nil, fmt.Errorf("failed to read message body: %w", err)
+	}
+	return data, nil
+}
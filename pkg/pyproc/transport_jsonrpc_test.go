@@ -0,0 +1,233 @@
+package pyproc
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// serveJSONRPCServer accepts a single length-prefixed connection and answers
+// every request (single or batch) by echoing back its params as the result,
+// unless method is "fail", in which case it replies with a JSON-RPC error.
+// Notifications (no "id" field) get no reply at all, per spec.
+func serveJSONRPCServer(t *testing.T, ln net.Listener) {
+	t.Helper()
+
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	br := bufio.NewReader(conn)
+
+	for {
+		lengthBuf := make([]byte, 4)
+		if _, err := io.ReadFull(br, lengthBuf); err != nil {
+			return
+		}
+		length := binary.BigEndian.Uint32(lengthBuf)
+		data := make([]byte, length)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return
+		}
+
+		var asBatch []jsonrpcRequest
+		if err := json.Unmarshal(data, &asBatch); err == nil && len(data) > 0 && data[0] == '[' {
+			replies := make([]jsonrpcResponse, 0, len(asBatch))
+			for _, req := range asBatch {
+				if resp, ok := buildReply(req); ok {
+					replies = append(replies, resp)
+				}
+			}
+			if len(replies) > 0 {
+				writeLengthPrefixed(conn, mustMarshal(t, replies))
+			}
+			continue
+		}
+
+		var req jsonrpcRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			continue
+		}
+		if resp, ok := buildReply(req); ok {
+			writeLengthPrefixed(conn, mustMarshal(t, resp))
+		}
+	}
+}
+
+func buildReply(req jsonrpcRequest) (jsonrpcResponse, bool) {
+	if req.ID == nil {
+		return jsonrpcResponse{}, false // notification: no reply
+	}
+	id := uint64(req.ID.(float64))
+
+	if req.Method == "fail" {
+		return jsonrpcResponse{JSONRPC: "2.0", ID: id, Error: &JSONRPCError{Code: -32000, Message: "boom"}}, true
+	}
+	result, _ := json.Marshal(req.Params)
+	return jsonrpcResponse{JSONRPC: "2.0", ID: id, Result: result}, true
+}
+
+func writeLengthPrefixed(conn net.Conn, data []byte) {
+	lengthBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lengthBuf, uint32(len(data)))
+	conn.Write(lengthBuf)
+	conn.Write(data)
+}
+
+func newTestJSONRPCTransport(t *testing.T) (*JSONRPCTransport, net.Listener) {
+	t.Helper()
+	socketPath := filepath.Join(t.TempDir(), "jsonrpc.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go serveJSONRPCServer(t, ln)
+
+	transport, err := NewJSONRPCTransport(TransportConfig{Type: "json-rpc", Address: socketPath}, NewLogger(LoggingConfig{Level: "error", Format: "json"}))
+	if err != nil {
+		ln.Close()
+		t.Fatalf("NewJSONRPCTransport failed: %v", err)
+	}
+	return transport, ln
+}
+
+func TestJSONRPCTransportCall(t *testing.T) {
+	transport, ln := newTestJSONRPCTransport(t)
+	defer ln.Close()
+	defer transport.Close()
+
+	req, err := protocol.NewRequest(0, "echo", map[string]interface{}{"value": 42})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	resp, err := transport.Call(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK {
+		t.Fatalf("expected OK response, got error: %s", resp.ErrorMsg)
+	}
+
+	var result map[string]interface{}
+	if err := resp.UnmarshalBody(&result); err != nil {
+		t.Fatalf("UnmarshalBody failed: %v", err)
+	}
+	if result["value"] != float64(42) {
+		t.Errorf("expected value=42, got %v", result["value"])
+	}
+}
+
+func TestJSONRPCTransportCallError(t *testing.T) {
+	transport, ln := newTestJSONRPCTransport(t)
+	defer ln.Close()
+	defer transport.Close()
+
+	req, err := protocol.NewRequest(0, "fail", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	_, err = transport.Call(context.Background(), req)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var rpcErr *JSONRPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("expected *JSONRPCError, got %T: %v", err, err)
+	}
+	if rpcErr.Code != -32000 {
+		t.Errorf("expected code -32000, got %d", rpcErr.Code)
+	}
+}
+
+func TestJSONRPCTransportNotify(t *testing.T) {
+	transport, ln := newTestJSONRPCTransport(t)
+	defer ln.Close()
+	defer transport.Close()
+
+	if err := transport.Notify(context.Background(), "log", map[string]interface{}{"msg": "hi"}); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+}
+
+func TestJSONRPCTransportCallBatch(t *testing.T) {
+	transport, ln := newTestJSONRPCTransport(t)
+	defer ln.Close()
+	defer transport.Close()
+
+	batch := []BatchRequest{
+		{Method: "echo", Params: map[string]interface{}{"n": 1}},
+		{Method: "fail", Params: nil},
+		{Method: "echo", Params: map[string]interface{}{"n": 3}},
+	}
+
+	results, err := transport.CallBatch(context.Background(), batch)
+	if err != nil {
+		t.Fatalf("CallBatch failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if results[0].Err != nil {
+		t.Errorf("result 0: unexpected error: %v", results[0].Err)
+	}
+	var r0 map[string]interface{}
+	if err := json.Unmarshal(results[0].Result, &r0); err != nil || r0["n"] != float64(1) {
+		t.Errorf("result 0: unexpected result %s (err %v)", results[0].Result, err)
+	}
+
+	if results[1].Err == nil {
+		t.Error("result 1: expected an error")
+	}
+
+	if results[2].Err != nil {
+		t.Errorf("result 2: unexpected error: %v", results[2].Err)
+	}
+}
+
+func TestContentLengthFramerRoundTrip(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	serverFramer := newContentLengthFramer(server)
+	clientFramer := newContentLengthFramer(client)
+
+	msg := []byte(`{"jsonrpc":"2.0","method":"ping","id":1}`)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- clientFramer.WriteMessage(msg)
+	}()
+
+	got, err := serverFramer.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+	if string(got) != string(msg) {
+		t.Errorf("expected %s, got %s", msg, got)
+	}
+}
+
+func TestNewTransportJSONRPC(t *testing.T) {
+	logger := NewLogger(LoggingConfig{Level: "error", Format: "text"})
+	_, err := NewTransport(TransportConfig{Type: "json-rpc", Address: "/tmp/does-not-exist.sock"}, logger)
+	if err == nil {
+		t.Error("expected error connecting to a nonexistent socket")
+	}
+}
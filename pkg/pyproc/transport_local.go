@@ -0,0 +1,41 @@
+package pyproc
+
+import (
+	"context"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// LocalHandler answers a Call in-process: method is the RPC method name and
+// req is the request body's raw JSON, mirroring what would otherwise be
+// serialized and sent over a worker's UDS socket. Returning an error fails
+// the Call the same way a transport-level error would; a successful return
+// becomes the response body.
+type LocalHandler func(ctx context.Context, method string, req []byte) ([]byte, error)
+
+// localTransport is a Transport that calls a LocalHandler directly instead
+// of talking to a worker over a socket - see PoolWithTransport.RegisterLocal.
+type localTransport struct {
+	handler LocalHandler
+}
+
+// NewLocalTransport wraps handler as a Transport.
+func NewLocalTransport(handler LocalHandler) Transport {
+	return &localTransport{handler: handler}
+}
+
+func (t *localTransport) Call(ctx context.Context, req *protocol.Request) (*protocol.Response, error) {
+	body, err := t.handler(ctx, req.Method, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &protocol.Response{ID: req.ID, OK: true, Body: body}, nil
+}
+
+func (t *localTransport) Close() error {
+	return nil
+}
+
+func (t *localTransport) IsHealthy() bool {
+	return true
+}
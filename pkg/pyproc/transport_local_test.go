@@ -0,0 +1,91 @@
+package pyproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+func TestLocalTransportCallInvokesHandler(t *testing.T) {
+	var gotMethod string
+	var gotBody []byte
+	transport := NewLocalTransport(func(ctx context.Context, method string, req []byte) ([]byte, error) {
+		gotMethod, gotBody = method, req
+		return []byte(`{"value":42}`), nil
+	})
+
+	req, _ := protocol.NewRequest(7, "predict", map[string]int{"x": 1})
+	resp, err := transport.Call(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK || resp.ID != 7 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if gotMethod != "predict" {
+		t.Errorf("handler saw method %q, want predict", gotMethod)
+	}
+	if string(gotBody) != `{"x":1}` {
+		t.Errorf("handler saw body %s, want {\"x\":1}", gotBody)
+	}
+
+	var out struct {
+		Value int `json:"value"`
+	}
+	if err := resp.UnmarshalBody(&out); err != nil {
+		t.Fatalf("UnmarshalBody failed: %v", err)
+	}
+	if out.Value != 42 {
+		t.Errorf("out.Value = %d, want 42", out.Value)
+	}
+}
+
+func TestLocalTransportCallPropagatesHandlerError(t *testing.T) {
+	wantErr := errors.New("handler failed")
+	transport := NewLocalTransport(func(context.Context, string, []byte) ([]byte, error) {
+		return nil, wantErr
+	})
+
+	req, _ := protocol.NewRequest(1, "predict", nil)
+	if _, err := transport.Call(context.Background(), req); !errors.Is(err, wantErr) {
+		t.Errorf("Call() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestLocalTransportAlwaysHealthy(t *testing.T) {
+	transport := NewLocalTransport(func(context.Context, string, []byte) ([]byte, error) {
+		return nil, nil
+	})
+	if !transport.IsHealthy() {
+		t.Error("expected a localTransport to always report healthy")
+	}
+	if err := transport.Close(); err != nil {
+		t.Errorf("Close() error = %v, want nil", err)
+	}
+}
+
+func TestPoolWithTransportRegisterLocalRejectedAfterStart(t *testing.T) {
+	pool := &PoolWithTransport{transportPool: &TransportPool{}}
+
+	err := pool.RegisterLocal("worker-0", func(context.Context, string, []byte) ([]byte, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Error("expected RegisterLocal to fail once the pool has started")
+	}
+}
+
+func TestPoolWithTransportRegisterLocalStoresHandler(t *testing.T) {
+	pool := &PoolWithTransport{}
+
+	if err := pool.RegisterLocal("worker-0", func(context.Context, string, []byte) ([]byte, error) {
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("RegisterLocal failed: %v", err)
+	}
+	if _, ok := pool.localHandlers["worker-0"]; !ok {
+		t.Error("expected RegisterLocal to record the handler under the given worker ID")
+	}
+}
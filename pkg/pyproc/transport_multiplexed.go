@@ -2,23 +2,218 @@ package pyproc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/YuminosukeSato/pyproc/internal/framing"
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
-// MultiplexedTransport implements Transport with request multiplexing
+// ErrConnectionLost is delivered to a pending Call when its connection fails
+// and the request cannot be safely retried (it wasn't marked Idempotent).
+var ErrConnectionLost = errors.New("multiplexed transport: connection lost")
+
+// ErrNotIdempotent is returned by Call when it is invoked while the
+// transport is reconnecting and the request isn't marked Idempotent - it
+// can't be resent once the connection comes back, so it's rejected instead
+// of being written to a connection that's about to be torn down.
+var ErrNotIdempotent = errors.New("multiplexed transport: request is not idempotent, cannot be retried across a reconnect")
+
+// ErrInflightFull is returned by Call when it was blocked waiting for an
+// in-flight slot (TransportConfig.MaxInflight) and the transport closed
+// before one freed up, so the wait can never succeed.
+var ErrInflightFull = errors.New("multiplexed transport: in-flight window closed while waiting for a slot")
+
+// deadlineWheelResolution bounds how late a request timeout can fire past
+// its deadline, in exchange for a single ticking goroutine instead of one
+// time.Timer per in-flight request.
+const deadlineWheelResolution = 25 * time.Millisecond
+
+// deadlineWheel tracks request deadlines in a map scanned once per tick,
+// rather than allocating a time.Timer per request - the allocation and GC
+// pressure of one timer per in-flight request becomes significant at the
+// concurrency MaxInflight is meant to allow.
+type deadlineWheel struct {
+	mu        sync.Mutex
+	deadlines map[uint64]time.Time
+	ticker    *time.Ticker
+	stopCh    chan struct{}
+	onExpire  func(id uint64)
+}
+
+func newDeadlineWheel(resolution time.Duration, onExpire func(id uint64)) *deadlineWheel {
+	w := &deadlineWheel{
+		deadlines: make(map[uint64]time.Time),
+		ticker:    time.NewTicker(resolution),
+		stopCh:    make(chan struct{}),
+		onExpire:  onExpire,
+	}
+	go w.run()
+	return w
+}
+
+func (w *deadlineWheel) run() {
+	for {
+		select {
+		case <-w.stopCh:
+			w.ticker.Stop()
+			return
+		case now := <-w.ticker.C:
+			w.mu.Lock()
+			var expired []uint64
+			for id, deadline := range w.deadlines {
+				if !now.Before(deadline) {
+					expired = append(expired, id)
+					delete(w.deadlines, id)
+				}
+			}
+			w.mu.Unlock()
+			for _, id := range expired {
+				w.onExpire(id)
+			}
+		}
+	}
+}
+
+// schedule (re)schedules id's deadline. Used both for a fresh request and
+// to re-key an entry onto its new ID when a retryable request is resent
+// after a reconnect - the absolute deadline carries over unchanged.
+func (w *deadlineWheel) schedule(id uint64, deadline time.Time) {
+	w.mu.Lock()
+	w.deadlines[id] = deadline
+	w.mu.Unlock()
+}
+
+func (w *deadlineWheel) cancel(id uint64) {
+	w.mu.Lock()
+	delete(w.deadlines, id)
+	w.mu.Unlock()
+}
+
+func (w *deadlineWheel) stop() {
+	close(w.stopCh)
+}
+
+// codecIDFor returns the framing.CodecID tag matching codec's wire format.
+func codecIDFor(codec Codec) byte {
+	switch codec.Name() {
+	case "msgpack":
+		return framing.CodecIDMsgpack
+	case "protobuf":
+		return framing.CodecIDProtobuf
+	default:
+		return framing.CodecIDJSON
+	}
+}
+
+// codecForID resolves the Codec matching a received frame's CodecID header
+// byte, so readLoop decodes each response with the codec it was actually
+// encoded with rather than assuming the transport's own configured codec.
+func codecForID(id byte) (Codec, error) {
+	switch id {
+	case framing.CodecIDMsgpack:
+		return NewCodec(CodecMessagePack)
+	case framing.CodecIDProtobuf:
+		return NewCodec(CodecProtobuf)
+	default:
+		return NewCodec(CodecJSON)
+	}
+}
+
+// compressionIDFor returns the framing.CompressionID tag matching c, or
+// CompressionIDNone if c is nil. Only the algorithms framing has a constant
+// for can be negotiated this way; a Compressor registered under any other
+// name still works with UDSTransport (which doesn't need a wire tag, see
+// transport_uds.go) but can't be used here.
+func compressionIDFor(c Compressor) byte {
+	if c == nil {
+		return framing.CompressionIDNone
+	}
+	switch c.Name() {
+	case "gzip":
+		return framing.CompressionIDGzip
+	case "snappy":
+		return framing.CompressionIDSnappy
+	case "lz4":
+		return framing.CompressionIDLZ4
+	case "zstd":
+		return framing.CompressionIDZstd
+	default:
+		return framing.CompressionIDNone
+	}
+}
+
+// compressorForID resolves the registered Compressor matching a received
+// frame's CompressionID header byte, by the same name compressionIDFor maps
+// it from. ok is false for CompressionIDNone or an ID whose algorithm isn't
+// registered, either of which means the payload is passed through
+// uncompressed.
+func compressorForID(id byte) (Compressor, bool) {
+	var name string
+	switch id {
+	case framing.CompressionIDGzip:
+		name = "gzip"
+	case framing.CompressionIDSnappy:
+		name = "snappy"
+	case framing.CompressionIDLZ4:
+		name = "lz4"
+	case framing.CompressionIDZstd:
+		name = "zstd"
+	default:
+		return nil, false
+	}
+	return GetCompressor(name)
+}
+
+// MultiplexedTransport implements Transport with request multiplexing. It is
+// strictly Go->Python: Call is the only way to use a connection, and an
+// inbound frame whose ID this transport didn't allocate itself is an error
+// (see readLoop), not something to dispatch elsewhere.
+//
+// This type does not, and will not, grow a symmetric RegisterHandler of its
+// own - that is not a gap left open here, it's out of scope for this type.
+// The bidirectional call_go(method, payload) mechanism a worker needs to
+// call back into Go-registered handlers already exists one layer up, in
+// MultiplexedConn/Pool.Register (added in the commit tagged chunk1-4),
+// which owns the handler registry a bare Transport has no place to hold.
+// Anything short of that - e.g. a framing-level direction bit, or
+// RegisterHandler/@callback living on this type instead of Pool - remains
+// unimplemented.
 type MultiplexedTransport struct {
 	config TransportConfig
 	logger *Logger
 	conn   net.Conn
 	framer *framing.Framer
 
+	// codec encodes/decodes Call's own outgoing requests; codecID is the
+	// matching framing.CodecID tag written into each frame it sends.
+	// readLoop doesn't use these directly - it decodes each response with
+	// the codec named by that response frame's own CodecID byte instead, so
+	// a pool can mix codecs (e.g. JSON for debugging, protobuf for the hot
+	// path) across workers sharing one transport implementation.
+	codec   Codec
+	codecID byte
+
+	// compressor, if non-nil, compresses Call's own outgoing payloads after
+	// codec encodes them, tagged with the matching compressionID in each
+	// frame's CompressionID header; readLoop decompresses an inbound frame
+	// with whatever CompressionID it actually carries, the same split
+	// codec/codecID already uses above.
+	compressor    Compressor
+	compressionID byte
+
+	// writeMu serializes writes to conn. Call() doesn't otherwise
+	// synchronize concurrent writers, but interleaving a request's bytes
+	// with another's would corrupt the framing - and for an
+	// attachments-bearing request, attach its fds to the wrong bytes.
+	writeMu sync.Mutex
+
 	// Request tracking
 	requestID atomic.Uint64
 	pending   map[uint64]*pendingRequest
@@ -29,6 +224,26 @@ type MultiplexedTransport struct {
 	closeOnce sync.Once
 	closeCh   chan struct{}
 
+	// Reconnection. reconnecting guards against read and write failures
+	// both triggering a reconnect loop; reconnectBackoff/maxReconnectAttempts
+	// come from config.ReconnectBackoff, and onReconnect (if set) is notified
+	// after every dial attempt, success or failure.
+	reconnecting         atomic.Bool
+	reconnectBackoff     BackoffStrategy
+	maxReconnectAttempts int
+	onReconnect          func(attempt int, err error)
+
+	// Backpressure. inflightSem is nil when config.MaxInflight <= 0
+	// (unbounded); inflightCount/inflightHighWater are tracked either way
+	// so the pool can read them for adaptive scaling decisions.
+	inflightSem       chan struct{}
+	inflightCount     atomic.Int64
+	inflightHighWater atomic.Int64
+
+	// deadline replaces one time.Timer per pending request with a single
+	// ticking goroutine that scans for expired deadlines.
+	deadline *deadlineWheel
+
 	// Reader goroutine
 	readerWg sync.WaitGroup
 }
@@ -36,9 +251,15 @@ type MultiplexedTransport struct {
 // pendingRequest tracks an in-flight request
 type pendingRequest struct {
 	id         uint64
+	req        *protocol.Request
 	responseCh chan *protocol.Response
 	errCh      chan error
-	timer      *time.Timer
+	// timeout and deadlineAt describe the same deadline two ways: timeout
+	// is kept to format the timeout error message, deadlineAt is the
+	// absolute time passed to deadlineWheel.schedule so it survives being
+	// re-keyed onto a new request ID when resent after a reconnect.
+	timeout    time.Duration
+	deadlineAt time.Time
 }
 
 // NewMultiplexedTransport creates a new multiplexed transport
@@ -47,15 +268,54 @@ func NewMultiplexedTransport(config TransportConfig, logger *Logger) (*Multiplex
 		return nil, fmt.Errorf("address is required for multiplexed transport")
 	}
 
+	strategyType := BackoffStrategyType(config.ReconnectBackoff.Strategy)
+	if strategyType == "" {
+		// Unlike NewBackoffStrategy's own default (BackoffExponential), a
+		// reconnect storm across many transports benefits from jitter by
+		// default so they don't all redial in lockstep.
+		strategyType = BackoffExponentialJitter
+	}
+
+	// Codec defaults to JSON, same as UDSTransport's Options["codec"].
+	codecType := CodecJSON
+	if codecTypeStr, ok := config.Options["codec"].(string); ok {
+		codecType = CodecType(codecTypeStr)
+	}
+	codec, err := NewCodec(codecType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create codec: %w", err)
+	}
+
+	// Compression defaults to off, same as UDSTransport's Options["compression"].
+	var compressor Compressor
+	if name, ok := config.Options["compression"].(string); ok && name != "" {
+		compressor, ok = GetCompressor(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown compressor %q (register it with RegisterCompressor first)", name)
+		}
+	}
+
 	transport := &MultiplexedTransport{
-		config:  config,
-		logger:  logger,
-		pending: make(map[uint64]*pendingRequest),
-		closeCh: make(chan struct{}),
+		config:               config,
+		logger:               logger,
+		codec:                codec,
+		codecID:              codecIDFor(codec),
+		compressor:           compressor,
+		compressionID:        compressionIDFor(compressor),
+		pending:              make(map[uint64]*pendingRequest),
+		closeCh:              make(chan struct{}),
+		reconnectBackoff:     NewBackoffStrategy(strategyType, config.ReconnectBackoff),
+		maxReconnectAttempts: config.ReconnectBackoff.MaxAttempts,
+		onReconnect:          config.OnReconnect,
+	}
+	if config.MaxInflight > 0 {
+		transport.inflightSem = make(chan struct{}, config.MaxInflight)
 	}
+	transport.deadline = newDeadlineWheel(deadlineWheelResolution, transport.expirePending)
 
 	// Connect to the socket
 	if err := transport.connect(); err != nil {
+		transport.deadline.stop()
 		return nil, err
 	}
 
@@ -80,12 +340,43 @@ func (t *MultiplexedTransport) connect() error {
 	}
 
 	t.conn = conn
-	t.framer = framing.NewEnhancedFramer(conn)
+	if t.config.BufferPool != nil {
+		t.framer = framing.NewEnhancedFramerWithPool(conn, framing.DefaultMaxFrameSize, t.config.BufferPool)
+	} else {
+		t.framer = framing.NewEnhancedFramer(conn)
+	}
 
 	t.logger.Debug("multiplexed transport connected", "address", t.config.Address)
 	return nil
 }
 
+// writeFrameWithAttachments writes frame as a single WriteMsgUnix call
+// carrying attachments as an SCM_RIGHTS ancillary message, so the worker's
+// first recvmsg for this frame's bytes receives the fds alongside them.
+// Callers must hold writeMu.
+func (t *MultiplexedTransport) writeFrameWithAttachments(frame *framing.Frame, attachments []*os.File) error {
+	unixConn, ok := t.conn.(*net.UnixConn)
+	if !ok {
+		return fmt.Errorf("attachments require a unix socket connection, got %T", t.conn)
+	}
+
+	fds := make([]int, len(attachments))
+	for i, f := range attachments {
+		fds[i] = int(f.Fd())
+	}
+	oob := syscall.UnixRights(fds...)
+
+	data := frame.Marshal()
+	n, oobn, err := unixConn.WriteMsgUnix(data, oob, nil)
+	if err != nil {
+		return fmt.Errorf("failed to write frame with attachments: %w", err)
+	}
+	if n != len(data) || oobn != len(oob) {
+		return fmt.Errorf("short write: wrote %d/%d payload bytes, %d/%d oob bytes", n, len(data), oobn, len(oob))
+	}
+	return nil
+}
+
 // readLoop continuously reads responses from the connection
 func (t *MultiplexedTransport) readLoop() {
 	defer t.readerWg.Done()
@@ -104,14 +395,44 @@ func (t *MultiplexedTransport) readLoop() {
 				return // Expected on shutdown
 			}
 			t.logger.Error("failed to read frame", "error", err)
-			t.handleReadError(err)
+			t.triggerReconnect(err)
 			return
 		}
 
-		// Parse response
+		if frame.Header.Type != framing.FrameTypeData {
+			// This transport is strictly Go->Python (see the type doc
+			// comment above): a worker has nothing to cancel on its end, so
+			// any non-data frame arriving here is unexpected.
+			t.logger.Warn("unexpected frame type from worker", "type", frame.Header.Type, "id", frame.Header.RequestID)
+			continue
+		}
+
+		// Parse response using the codec the frame was actually encoded
+		// with, not necessarily this transport's configured one.
+		respCodec, err := codecForID(frame.Header.CodecID)
+		if err != nil {
+			t.logger.Error("unknown response codec", "codec_id", frame.Header.CodecID, "error", err)
+			continue
+		}
+
+		payload := frame.Payload
+		if respCompressor, ok := compressorForID(frame.Header.CompressionID); ok {
+			payload, err = decompressBytes(respCompressor, payload)
+			if err != nil {
+				t.logger.Error("failed to decompress response", "compression_id", frame.Header.CompressionID, "error", err)
+				continue
+			}
+		}
+
 		var resp protocol.Response
-		if err := resp.Unmarshal(frame.Payload); err != nil {
-			t.logger.Error("failed to unmarshal response", "error", err)
+		unmarshalErr := respCodec.Unmarshal(payload, &resp)
+		// The decoded resp no longer references frame's bytes (Unmarshal
+		// copies into resp's own fields), so the pooled frame can go back
+		// to the BufferPool immediately rather than waiting on the pending
+		// waiter to consume resp.
+		t.framer.ReleaseFrame(frame)
+		if unmarshalErr != nil {
+			t.logger.Error("failed to unmarshal response", "error", unmarshalErr)
 			continue
 		}
 
@@ -128,40 +449,181 @@ func (t *MultiplexedTransport) readLoop() {
 			continue
 		}
 
-		// Deliver response
-		select {
-		case pending.responseCh <- &resp:
-			// Response delivered
-		case <-pending.timer.C:
-			// Request already timed out
-		}
+		// Deliver response. responseCh is buffered(1) so this never blocks.
+		pending.responseCh <- &resp
 
 		// Clean up pending request
 		t.mu.Lock()
 		delete(t.pending, resp.ID)
 		t.mu.Unlock()
-		pending.timer.Stop()
+		t.deadline.cancel(resp.ID)
 	}
 }
 
-// handleReadError handles errors from the read loop
-func (t *MultiplexedTransport) handleReadError(err error) {
+// expirePending is deadlineWheel's onExpire callback: it delivers a timeout
+// error to the request's errCh if it's still pending (it may have already
+// been delivered a response or moved elsewhere by a reconnect).
+func (t *MultiplexedTransport) expirePending(id uint64) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	pending, ok := t.pending[id]
+	if ok {
+		delete(t.pending, id)
+	}
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case pending.errCh <- fmt.Errorf("request timeout after %v", pending.timeout):
+	default:
+	}
+}
+
+// triggerReconnect handles a read or write failure: it drains the pending
+// map, immediately fails everything that isn't marked Idempotent with
+// ErrConnectionLost, and hands the rest off to reconnectLoop to resend once
+// a new connection is up. Concurrent read and write failures both call this;
+// the reconnecting CAS guard makes sure only one reconnect loop runs.
+func (t *MultiplexedTransport) triggerReconnect(err error) {
+	if t.closed.Load() {
+		return
+	}
+	if !t.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+
+	t.logger.Warn("multiplexed transport: connection error, reconnecting", "error", err)
+
+	if t.conn != nil {
+		_ = t.conn.Close()
+	}
+
+	t.mu.Lock()
+	oldPending := t.pending
+	t.pending = make(map[uint64]*pendingRequest)
+	t.mu.Unlock()
 
-	// Notify all pending requests of the error
-	for id, pending := range t.pending {
+	retryable := make([]*pendingRequest, 0, len(oldPending))
+	for _, pending := range oldPending {
+		if pending.req != nil && pending.req.Idempotent {
+			retryable = append(retryable, pending)
+			continue
+		}
+		t.deadline.cancel(pending.id)
 		select {
-		case pending.errCh <- fmt.Errorf("connection error: %w", err):
+		case pending.errCh <- ErrConnectionLost:
 		default:
 		}
-		pending.timer.Stop()
-		delete(t.pending, id)
 	}
 
-	// Close the transport
-	t.closed.Store(true)
-	close(t.closeCh)
+	go t.reconnectLoop(err, retryable)
+}
+
+// reconnectLoop redials with backoff until it succeeds, the transport is
+// closed, or maxReconnectAttempts is exceeded. On success it restarts
+// readLoop and resends retryable; on giving up, it fails retryable and
+// closes the transport.
+func (t *MultiplexedTransport) reconnectLoop(firstErr error, retryable []*pendingRequest) {
+	defer t.reconnecting.Store(false)
+
+	lastErr := firstErr
+	for attempt := 1; ; attempt++ {
+		if t.maxReconnectAttempts > 0 && attempt > t.maxReconnectAttempts {
+			t.logger.Error("multiplexed transport: giving up reconnecting", "attempts", attempt-1, "error", lastErr)
+			t.failRetryable(retryable, fmt.Errorf("multiplexed transport: giving up reconnecting after %d attempts: %w", attempt-1, lastErr))
+			_ = t.Close()
+			return
+		}
+
+		select {
+		case <-t.closeCh:
+			t.failRetryable(retryable, ErrConnectionLost)
+			return
+		case <-time.After(t.reconnectBackoff.NextDelay(attempt - 1)):
+		}
+
+		dialErr := t.connect()
+		if t.onReconnect != nil {
+			t.onReconnect(attempt, dialErr)
+		}
+		if dialErr != nil {
+			lastErr = dialErr
+			t.logger.Warn("multiplexed transport: reconnect attempt failed", "attempt", attempt, "error", dialErr)
+			continue
+		}
+
+		t.logger.Info("multiplexed transport: reconnected", "attempt", attempt)
+		t.readerWg.Add(1)
+		go t.readLoop()
+		t.resendRetryable(retryable)
+		return
+	}
+}
+
+// failRetryable delivers err to every pending request that was waiting on a
+// reconnect, since it either gave up or was closed out from under them.
+func (t *MultiplexedTransport) failRetryable(retryable []*pendingRequest, err error) {
+	for _, pending := range retryable {
+		t.deadline.cancel(pending.id)
+		select {
+		case pending.errCh <- err:
+		default:
+		}
+	}
+}
+
+// resendRetryable re-marshals and rewrites each retryable request under a
+// fresh request ID, reusing its original responseCh/errCh so the Call goroutine
+// still blocked on it receives the eventual result.
+func (t *MultiplexedTransport) resendRetryable(retryable []*pendingRequest) {
+	for _, pending := range retryable {
+		oldID := pending.id
+		newID := t.requestID.Add(1)
+		pending.req.ID = newID
+		pending.id = newID
+
+		t.mu.Lock()
+		t.pending[newID] = pending
+		t.mu.Unlock()
+		t.deadline.cancel(oldID)
+		t.deadline.schedule(newID, pending.deadlineAt)
+
+		reqData, err := t.codec.Marshal(pending.req)
+		if err != nil {
+			t.failResend(pending, newID, fmt.Errorf("multiplexed transport: failed to re-marshal request on reconnect: %w", err))
+			continue
+		}
+		if t.compressor != nil {
+			if reqData, err = compressBytes(t.compressor, reqData); err != nil {
+				t.failResend(pending, newID, fmt.Errorf("multiplexed transport: failed to compress request on reconnect: %w", err))
+				continue
+			}
+		}
+
+		frame := framing.NewFrameWithCodecAndCompression(newID, reqData, t.codecID, t.compressionID)
+		t.writeMu.Lock()
+		if len(pending.req.Attachments) > 0 {
+			err = t.writeFrameWithAttachments(frame, pending.req.Attachments)
+		} else {
+			err = t.framer.WriteFrame(frame)
+		}
+		t.writeMu.Unlock()
+		if err != nil {
+			t.failResend(pending, newID, fmt.Errorf("multiplexed transport: failed to resend request after reconnect: %w", err))
+		}
+	}
+}
+
+// failResend delivers err for a request that failed to resend and removes
+// its now-stale entry from pending.
+func (t *MultiplexedTransport) failResend(pending *pendingRequest, id uint64, err error) {
+	select {
+	case pending.errCh <- err:
+	default:
+	}
+	t.mu.Lock()
+	delete(t.pending, id)
+	t.mu.Unlock()
 }
 
 // Call sends a request and receives a response
@@ -169,63 +631,150 @@ func (t *MultiplexedTransport) Call(ctx context.Context, req *protocol.Request)
 	if t.closed.Load() {
 		return nil, fmt.Errorf("transport is closed")
 	}
+	if t.reconnecting.Load() && !req.Idempotent {
+		return nil, ErrNotIdempotent
+	}
+
+	// Acquire an in-flight slot before registering anything, so a slow
+	// worker can't accumulate an unbounded number of pending requests (and
+	// their goroutines/timers) under sustained overload.
+	if t.inflightSem != nil {
+		select {
+		case t.inflightSem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.closeCh:
+			return nil, ErrInflightFull
+		}
+		defer func() { <-t.inflightSem }()
+	}
+	count := t.inflightCount.Add(1)
+	defer t.inflightCount.Add(-1)
+	for {
+		hw := t.inflightHighWater.Load()
+		if count <= hw || t.inflightHighWater.CompareAndSwap(hw, count) {
+			break
+		}
+	}
 
 	// Generate request ID
 	requestID := t.requestID.Add(1)
 	req.ID = requestID
 
+	// Set timeout
+	timeout := 30 * time.Second
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	deadlineAt := time.Now().Add(timeout)
+
 	// Create pending request
 	pending := &pendingRequest{
 		id:         requestID,
+		req:        req,
 		responseCh: make(chan *protocol.Response, 1),
 		errCh:      make(chan error, 1),
+		timeout:    timeout,
+		deadlineAt: deadlineAt,
 	}
 
-	// Set timeout
-	timeout := 30 * time.Second
-	if deadline, ok := ctx.Deadline(); ok {
-		timeout = time.Until(deadline)
-	}
-	pending.timer = time.NewTimer(timeout)
-
 	// Register pending request
 	t.mu.Lock()
 	t.pending[requestID] = pending
 	t.mu.Unlock()
+	t.deadline.schedule(requestID, deadlineAt)
 
 	// Clean up on exit
 	defer func() {
-		pending.timer.Stop()
+		t.deadline.cancel(requestID)
 		t.mu.Lock()
 		delete(t.pending, requestID)
 		t.mu.Unlock()
 	}()
 
-	// Marshal request
-	reqData, err := req.Marshal()
+	// Marshal request with the transport's configured codec (defaults to JSON)
+	reqData, err := t.codec.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
+	if t.compressor != nil {
+		if reqData, err = compressBytes(t.compressor, reqData); err != nil {
+			return nil, fmt.Errorf("failed to compress request: %w", err)
+		}
+	}
 
-	// Create and write frame
-	frame := framing.NewFrame(requestID, reqData)
-	if err := t.framer.WriteFrame(frame); err != nil {
+	// Create and write frame, tagged with the codec and compression it was encoded with
+	frame := framing.NewFrameWithCodecAndCompression(requestID, reqData, t.codecID, t.compressionID)
+	t.writeMu.Lock()
+	if len(req.Attachments) > 0 {
+		err = t.writeFrameWithAttachments(frame, req.Attachments)
+	} else {
+		err = t.framer.WriteFrame(frame)
+	}
+	t.writeMu.Unlock()
+	if err != nil {
+		go t.triggerReconnect(err)
 		return nil, fmt.Errorf("failed to write frame: %w", err)
 	}
 
-	// Wait for response
+	// Wait for response. A timeout surfaces here as an error from errCh,
+	// delivered by deadlineWheel's onExpire callback (expirePending).
+	start := time.Now()
 	select {
 	case resp := <-pending.responseCh:
+		t.emitCallEvent(req, start, nil)
 		return resp, nil
 	case err := <-pending.errCh:
+		t.emitCallEvent(req, start, err)
 		return nil, err
-	case <-pending.timer.C:
-		return nil, fmt.Errorf("request timeout after %v", timeout)
 	case <-ctx.Done():
+		// The request is already on the wire and the worker is presumably
+		// still working on it; tell it to stop rather than silently
+		// abandoning requestID, whose eventual response will otherwise
+		// arrive for an ID nobody is listening for anymore (the deferred
+		// cleanup above removes it from pending as soon as Call returns).
+		t.sendCancel(requestID, ctx.Err())
+		t.emitCallEvent(req, start, ctx.Err())
 		return nil, ctx.Err()
 	}
 }
 
+// sendCancel best-effort notifies the worker that requestID should stop.
+// Unlike a normal Call frame this never triggers a reconnect on failure -
+// the caller is already returning ctx.Err() regardless, so a cancellation
+// that didn't make it to the wire just means the worker runs the request to
+// completion, same as before FrameTypeCancel existed.
+func (t *MultiplexedTransport) sendCancel(requestID uint64, reason error) {
+	frame := framing.NewCancelFrame(requestID, reason.Error())
+	t.writeMu.Lock()
+	err := t.framer.WriteFrame(frame)
+	t.writeMu.Unlock()
+	if err != nil {
+		t.logger.Warn("multiplexed transport: failed to send cancellation", "id", requestID, "error", err)
+	}
+}
+
+// emitCallEvent reports a completed Call as a LogEvent, giving hooks
+// configured via LoggingConfig.Hooks (see log_event.go) a request-end event
+// to observe without scraping text logs.
+func (t *MultiplexedTransport) emitCallEvent(req *protocol.Request, start time.Time, err error) {
+	if t.logger == nil {
+		return
+	}
+	event := LogEvent{
+		Level:     "info",
+		RequestID: req.ID,
+		Method:    req.Method,
+		LatencyUs: time.Since(start).Microseconds(),
+		OK:        err == nil,
+	}
+	if err != nil {
+		event.Level = "error"
+		event.Err = err.Error()
+	}
+	t.logger.EmitEvent(event)
+}
+
 // Close closes the transport
 func (t *MultiplexedTransport) Close() error {
 	var closeErr error
@@ -249,10 +798,11 @@ func (t *MultiplexedTransport) Close() error {
 			case pending.errCh <- fmt.Errorf("transport closed"):
 			default:
 			}
-			pending.timer.Stop()
 			delete(t.pending, id)
 		}
 		t.mu.Unlock()
+
+		t.deadline.stop()
 	})
 
 	return closeErr
@@ -262,3 +812,15 @@ func (t *MultiplexedTransport) Close() error {
 func (t *MultiplexedTransport) IsHealthy() bool {
 	return !t.closed.Load() && t.conn != nil
 }
+
+// InflightCount returns the current number of in-flight Call invocations,
+// for the pool to feed into adaptive worker scaling decisions.
+func (t *MultiplexedTransport) InflightCount() int64 {
+	return t.inflightCount.Load()
+}
+
+// InflightHighWaterMark returns the highest InflightCount has reached over
+// the transport's lifetime.
+func (t *MultiplexedTransport) InflightHighWaterMark() int64 {
+	return t.inflightHighWater.Load()
+}
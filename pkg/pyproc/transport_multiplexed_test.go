@@ -2,11 +2,15 @@ package pyproc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/YuminosukeSato/pyproc/internal/framing"
 	"github.com/YuminosukeSato/pyproc/internal/protocol"
 )
 
@@ -188,6 +192,436 @@ func TestMultiplexedTransport(t *testing.T) {
 	})
 }
 
+// TestMultiplexedTransportReconnect exercises the reconnect path against a
+// bare net.Listener standing in for the worker (no Python needed): it drops
+// the first connection while two requests are in flight, then accepts a
+// second connection and answers the resent one. An Idempotent request
+// should be transparently resent and succeed; a non-idempotent one should
+// fail immediately with ErrConnectionLost.
+func TestMultiplexedTransportReconnect(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "reconnect.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		// First connection: read both requests, then drop the
+		// connection without responding to simulate a crash.
+		first, err := ln.Accept()
+		if err != nil {
+			serverErrs <- fmt.Errorf("first accept: %w", err)
+			return
+		}
+		firstFramer := framing.NewEnhancedFramer(first)
+		if _, err := firstFramer.ReadFrame(); err != nil {
+			serverErrs <- fmt.Errorf("first read: %w", err)
+			return
+		}
+		if _, err := firstFramer.ReadFrame(); err != nil {
+			serverErrs <- fmt.Errorf("second read: %w", err)
+			return
+		}
+		first.Close()
+
+		// Second connection: answer whatever gets resent.
+		second, err := ln.Accept()
+		if err != nil {
+			serverErrs <- fmt.Errorf("second accept: %w", err)
+			return
+		}
+		defer second.Close()
+		secondFramer := framing.NewEnhancedFramer(second)
+		frame, err := secondFramer.ReadFrame()
+		if err != nil {
+			serverErrs <- fmt.Errorf("resend read: %w", err)
+			return
+		}
+		resp, err := protocol.NewResponse(frame.Header.RequestID, map[string]interface{}{"ok": true})
+		if err != nil {
+			serverErrs <- fmt.Errorf("build response: %w", err)
+			return
+		}
+		respData, err := resp.Marshal()
+		if err != nil {
+			serverErrs <- fmt.Errorf("marshal response: %w", err)
+			return
+		}
+		if err := secondFramer.WriteFrame(framing.NewFrame(frame.Header.RequestID, respData)); err != nil {
+			serverErrs <- fmt.Errorf("write response: %w", err)
+			return
+		}
+		serverErrs <- nil
+	}()
+
+	transportConfig := TransportConfig{
+		Type:    "multiplexed",
+		Address: sockPath,
+		ReconnectBackoff: RestartConfig{
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+			Multiplier:     2,
+			MaxAttempts:    5,
+		},
+	}
+
+	logger := NewLogger(LoggingConfig{Level: "error"})
+	transport, err := NewMultiplexedTransport(transportConfig, logger)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var idempotentErr error
+	var idempotentResp *protocol.Response
+	go func() {
+		defer wg.Done()
+		req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 1})
+		req.Idempotent = true
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		idempotentResp, idempotentErr = transport.Call(ctx, req)
+	}()
+
+	var notIdempotentErr error
+	go func() {
+		defer wg.Done()
+		req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 2})
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_, notIdempotentErr = transport.Call(ctx, req)
+	}()
+
+	wg.Wait()
+
+	if err := <-serverErrs; err != nil {
+		t.Fatalf("server goroutine failed: %v", err)
+	}
+
+	if idempotentErr != nil {
+		t.Errorf("expected idempotent request to succeed after reconnect, got error: %v", idempotentErr)
+	} else if idempotentResp == nil || !idempotentResp.OK {
+		t.Errorf("expected a successful response for the idempotent request, got %+v", idempotentResp)
+	}
+
+	if !errors.Is(notIdempotentErr, ErrConnectionLost) {
+		t.Errorf("expected non-idempotent request to fail with ErrConnectionLost, got: %v", notIdempotentErr)
+	}
+}
+
+// TestMultiplexedTransportCodec checks that a non-default codec chosen via
+// Options["codec"] is used to encode Call's request, tagged with the
+// matching framing.CodecID, and that the response is decoded using whatever
+// codec its own frame is tagged with (not necessarily the transport's).
+func TestMultiplexedTransportCodec(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "codec.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrs <- fmt.Errorf("accept: %w", err)
+			return
+		}
+		defer conn.Close()
+		framer := framing.NewEnhancedFramer(conn)
+
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			serverErrs <- fmt.Errorf("read: %w", err)
+			return
+		}
+		if frame.Header.CodecID != framing.CodecIDMsgpack {
+			serverErrs <- fmt.Errorf("expected request tagged CodecIDMsgpack, got %d", frame.Header.CodecID)
+			return
+		}
+
+		msgpackCodec := &MessagePackCodec{}
+		var req protocol.Request
+		if err := msgpackCodec.Unmarshal(frame.Payload, &req); err != nil {
+			serverErrs <- fmt.Errorf("decode request: %w", err)
+			return
+		}
+
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"echoed": req.Method})
+		if err != nil {
+			serverErrs <- fmt.Errorf("build response: %w", err)
+			return
+		}
+		respData, err := msgpackCodec.Marshal(resp)
+		if err != nil {
+			serverErrs <- fmt.Errorf("encode response: %w", err)
+			return
+		}
+		if err := framer.WriteFrame(framing.NewFrameWithCodec(req.ID, respData, framing.CodecIDMsgpack)); err != nil {
+			serverErrs <- fmt.Errorf("write response: %w", err)
+			return
+		}
+		serverErrs <- nil
+	}()
+
+	transportConfig := TransportConfig{
+		Type:    "multiplexed",
+		Address: sockPath,
+		Options: map[string]interface{}{
+			"codec": "msgpack",
+		},
+	}
+
+	logger := NewLogger(LoggingConfig{Level: "error"})
+	transport, err := NewMultiplexedTransport(transportConfig, logger)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	resp, err := transport.Call(ctx, req)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if !resp.OK {
+		t.Errorf("expected a successful response, got %+v", resp)
+	}
+
+	if err := <-serverErrs; err != nil {
+		t.Fatalf("server goroutine failed: %v", err)
+	}
+}
+
+// TestMultiplexedTransportMaxInflight checks that Call blocks once
+// MaxInflight slots are taken and gives up with the caller's own context
+// error rather than piling on an unbounded number of pending requests.
+func TestMultiplexedTransportMaxInflight(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "inflight.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		framer := framing.NewEnhancedFramer(conn)
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			return
+		}
+		// Hold the request's slot open long enough for the second Call,
+		// issued with a short deadline, to observe the in-flight window
+		// as full - it never gets this far, since it gives up before
+		// acquiring a slot and so never writes a second frame.
+		time.Sleep(150 * time.Millisecond)
+		resp, err := protocol.NewResponse(frame.Header.RequestID, map[string]interface{}{"ok": true})
+		if err != nil {
+			return
+		}
+		respData, err := resp.Marshal()
+		if err != nil {
+			return
+		}
+		_ = framer.WriteFrame(framing.NewFrame(frame.Header.RequestID, respData))
+	}()
+
+	transportConfig := TransportConfig{
+		Type:        "multiplexed",
+		Address:     sockPath,
+		MaxInflight: 1,
+	}
+	logger := NewLogger(LoggingConfig{Level: "error"})
+	transport, err := NewMultiplexedTransport(transportConfig, logger)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	firstDone := make(chan error, 1)
+	go func() {
+		req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 1})
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err := transport.Call(ctx, req)
+		firstDone <- err
+	}()
+
+	// Give the first call a head start so it reliably grabs the only slot.
+	time.Sleep(20 * time.Millisecond)
+
+	req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = transport.Call(ctx, req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected second call to be blocked by the full in-flight window and hit its own deadline, got: %v", err)
+	}
+
+	if err := <-firstDone; err != nil {
+		t.Errorf("expected first call to succeed, got: %v", err)
+	}
+
+	if hw := transport.InflightHighWaterMark(); hw != 1 {
+		t.Errorf("expected high-water mark of 1, got %d", hw)
+	}
+}
+
+// TestMultiplexedTransportSendsCancelFrame checks that Call writes a
+// FrameTypeCancel frame for the in-flight request ID once ctx is cancelled,
+// rather than silently abandoning it.
+func TestMultiplexedTransportSendsCancelFrame(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "cancel.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	cancelFrames := make(chan *framing.Frame, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		framer := framing.NewEnhancedFramer(conn)
+
+		// The request frame; never answered, so Call has nothing left to do
+		// but wait out its deadline.
+		if _, err := framer.ReadFrame(); err != nil {
+			return
+		}
+
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			return
+		}
+		cancelFrames <- frame
+	}()
+
+	transportConfig := TransportConfig{
+		Type:    "multiplexed",
+		Address: sockPath,
+	}
+	logger := NewLogger(LoggingConfig{Level: "error"})
+	transport, err := NewMultiplexedTransport(transportConfig, logger)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := transport.Call(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Call to return context.DeadlineExceeded, got: %v", err)
+	}
+
+	select {
+	case frame := <-cancelFrames:
+		if frame.Header.Type != framing.FrameTypeCancel {
+			t.Errorf("expected FrameTypeCancel, got %d", frame.Header.Type)
+		}
+		if frame.Header.RequestID != req.ID {
+			t.Errorf("expected cancel frame for request %d, got %d", req.ID, frame.Header.RequestID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never received a cancel frame")
+	}
+}
+
+// BenchmarkMultiplexedTransportBoundedInflight drives sustained overload
+// (more concurrent callers than MaxInflight allows) against a bare echo
+// server, demonstrating that Call's semaphore keeps InflightHighWaterMark -
+// and so the size of the pending map - bounded instead of growing with the
+// offered load.
+func BenchmarkMultiplexedTransportBoundedInflight(b *testing.B) {
+	const maxInflight = 16
+
+	sockPath := filepath.Join(b.TempDir(), "bounded.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		b.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		framer := framing.NewEnhancedFramer(conn)
+		for {
+			frame, err := framer.ReadFrame()
+			if err != nil {
+				return
+			}
+			resp, err := protocol.NewResponse(frame.Header.RequestID, map[string]interface{}{"ok": true})
+			if err != nil {
+				return
+			}
+			respData, err := resp.Marshal()
+			if err != nil {
+				return
+			}
+			if err := framer.WriteFrame(framing.NewFrame(frame.Header.RequestID, respData)); err != nil {
+				return
+			}
+		}
+	}()
+
+	transportConfig := TransportConfig{
+		Type:        "multiplexed",
+		Address:     sockPath,
+		MaxInflight: maxInflight,
+	}
+	logger := NewLogger(LoggingConfig{Level: "error"})
+	transport, err := NewMultiplexedTransport(transportConfig, logger)
+	if err != nil {
+		b.Fatalf("Failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			req, _ := protocol.NewRequest(0, "predict", map[string]interface{}{"value": 1})
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_, err := transport.Call(ctx, req)
+			cancel()
+			if err != nil {
+				b.Errorf("Call failed: %v", err)
+			}
+		}
+	})
+
+	if hw := transport.InflightHighWaterMark(); hw > maxInflight {
+		b.Errorf("expected high-water mark to stay at or below MaxInflight=%d, got %d", maxInflight, hw)
+	}
+}
+
 func BenchmarkMultiplexedTransport(b *testing.B) {
 	// Start a test worker
 	cfg := WorkerConfig{
@@ -99,7 +99,7 @@ func TestTransportPool(t *testing.T) {
 	logger := NewLogger(LoggingConfig{Level: "error", Format: "text"})
 
 	t.Run("Create pool with no configs", func(t *testing.T) {
-		_, err := NewTransportPool([]TransportConfig{}, logger)
+		_, err := NewTransportPool([]TransportConfig{}, logger, TransportPoolOptions{})
 		if err == nil {
 			t.Error("Expected error for empty configs")
 		}
@@ -113,7 +113,8 @@ func TestTransportPool(t *testing.T) {
 				&MockTransport{healthy: false},
 				&MockTransport{healthy: true},
 			},
-			logger: logger,
+			balancer: NewRoundRobinLoadBalancer(),
+			logger:   logger,
 		}
 
 		// Test Call with healthy transports
@@ -162,7 +163,8 @@ func TestTransportPool(t *testing.T) {
 				&MockTransport{healthy: false},
 				&MockTransport{healthy: false},
 			},
-			logger: logger,
+			balancer: NewRoundRobinLoadBalancer(),
+			logger:   logger,
 		}
 
 		ctx := context.Background()
@@ -199,7 +201,8 @@ func TestTransportPool(t *testing.T) {
 					},
 				},
 			},
-			logger: logger,
+			balancer: NewRoundRobinLoadBalancer(),
+			logger:   logger,
 		}
 
 		ctx := context.Background()
@@ -0,0 +1,281 @@
+package pyproc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YuminosukeSato/pyproc/internal/framing"
+	"github.com/YuminosukeSato/pyproc/internal/protocol"
+)
+
+// countingBackoff records every NextDelay call and returns an effectively
+// zero delay, so retry tests don't actually wait out real backoff timings.
+type countingBackoff struct {
+	calls atomic.Int32
+}
+
+func (b *countingBackoff) NextDelay(retries int) time.Duration {
+	b.calls.Add(1)
+	return time.Millisecond
+}
+
+var errDialRefused = errors.New("connection refused")
+
+func TestUDSTransportGetConnRetriesThenSucceeds(t *testing.T) {
+	var attempts atomic.Int32
+	pool, err := NewConnPool(func() (net.Conn, error) {
+		if attempts.Add(1) <= 2 {
+			return nil, errDialRefused
+		}
+		server, client := net.Pipe()
+		_ = server
+		return client, nil
+	}, 0, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool: %v", err)
+	}
+
+	backoff := &countingBackoff{}
+	tr := &UDSTransport{pool: pool, backoff: backoff, maxRetries: 3}
+
+	conn, err := tr.getConn(context.Background())
+	if err != nil {
+		t.Fatalf("getConn: unexpected error %v", err)
+	}
+	defer conn.Close()
+
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 dial attempts, got %d", attempts.Load())
+	}
+	if backoff.calls.Load() != 2 {
+		t.Errorf("expected 2 backoff sleeps, got %d", backoff.calls.Load())
+	}
+}
+
+func TestUDSTransportGetConnExhaustsRetries(t *testing.T) {
+	pool, err := NewConnPool(func() (net.Conn, error) {
+		return nil, errDialRefused
+	}, 0, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool: %v", err)
+	}
+
+	tr := &UDSTransport{pool: pool, backoff: &countingBackoff{}, maxRetries: 2}
+
+	_, err = tr.getConn(context.Background())
+	if err == nil {
+		t.Fatal("expected getConn to fail after exhausting retries")
+	}
+	if !errors.Is(err, errDialRefused) {
+		t.Errorf("expected the error to wrap the last dial failure, got %v", err)
+	}
+}
+
+func TestUDSTransportGetConnReturnsOnContextDone(t *testing.T) {
+	pool, err := NewConnPool(func() (net.Conn, error) {
+		return nil, errDialRefused
+	}, 0, 1)
+	if err != nil {
+		t.Fatalf("NewConnPool: %v", err)
+	}
+
+	tr := &UDSTransport{pool: pool, backoff: &ConnectionBackoff{BaseDelay: time.Hour, Multiplier: 1, MaxDelay: time.Hour}, maxRetries: 5}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := tr.getConn(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("getConn did not return promptly after context cancellation")
+	}
+}
+
+// TestUDSTransportCallPropagatesTraceParent exercises Call's trace
+// propagation against a bare net.Listener standing in for the worker: it
+// asserts req.Trace, decoded off the wire, is a valid traceparent naming the
+// same trace ID as the TraceContext attached to the calling ctx.
+func TestUDSTransportCallPropagatesTraceParent(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "trace.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan protocol.Request, 1)
+	serverErrs := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		defer conn.Close()
+
+		serverFramer := framing.NewFramer(conn)
+		reqData, err := serverFramer.ReadMessage()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		var req protocol.Request
+		if err := json.Unmarshal(reqData, &req); err != nil {
+			serverErrs <- err
+			return
+		}
+		received <- req
+
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"ok": true})
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		respData, err := resp.Marshal()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		serverErrs <- serverFramer.WriteMessage(respData)
+	}()
+
+	transport, err := NewUDSTransport(TransportConfig{Address: sockPath}, NewLogger(LoggingConfig{Level: "error"}))
+	if err != nil {
+		t.Fatalf("NewUDSTransport: %v", err)
+	}
+	defer transport.Close()
+
+	parent := NewTraceContext()
+	ctx := WithTraceContext(context.Background(), parent)
+
+	req, err := protocol.NewRequest(1, "echo", map[string]string{"hello": "world"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if _, err := transport.Call(ctx, req); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if err := <-serverErrs; err != nil {
+		t.Fatalf("server: %v", err)
+	}
+
+	var got protocol.Request
+	select {
+	case got = <-received:
+	case <-time.After(time.Second):
+		t.Fatal("server never received a request")
+	}
+
+	tc, err := ParseTraceParent(got.Trace)
+	if err != nil {
+		t.Fatalf("ParseTraceParent(%q): %v", got.Trace, err)
+	}
+	if tc.TraceIDHex() != parent.TraceIDHex() {
+		t.Errorf("trace ID mismatch: got %s, want %s", tc.TraceIDHex(), parent.TraceIDHex())
+	}
+	if tc.SpanIDHex() == parent.SpanIDHex() {
+		t.Error("expected Call to start a child span with a fresh span ID, not reuse the parent's")
+	}
+}
+
+// TestUDSTransportCallUsesConfiguredBufferPool exercises the
+// TransportConfig.BufferPool override end to end, confirming Call actually
+// reads/writes through it rather than framing's shared default.
+func TestUDSTransportCallUsesConfiguredBufferPool(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "bufferpool.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer ln.Close()
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		defer conn.Close()
+
+		serverFramer := framing.NewFramer(conn)
+		reqData, err := serverFramer.ReadMessage()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		var req protocol.Request
+		if err := json.Unmarshal(reqData, &req); err != nil {
+			serverErrs <- err
+			return
+		}
+
+		resp, err := protocol.NewResponse(req.ID, map[string]interface{}{"ok": true})
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		respData, err := resp.Marshal()
+		if err != nil {
+			serverErrs <- err
+			return
+		}
+		serverErrs <- serverFramer.WriteMessage(respData)
+	}()
+
+	pool := &countingTestBufferPool{BufferPool: framing.NewBufferPool()}
+	transport, err := NewUDSTransport(TransportConfig{
+		Address:    sockPath,
+		BufferPool: pool,
+	}, NewLogger(LoggingConfig{Level: "error"}))
+	if err != nil {
+		t.Fatalf("NewUDSTransport: %v", err)
+	}
+	defer transport.Close()
+
+	req, err := protocol.NewRequest(1, "echo", map[string]string{"hello": "world"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if _, err := transport.Call(context.Background(), req); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if err := <-serverErrs; err != nil {
+		t.Fatalf("server: %v", err)
+	}
+
+	if pool.gets.Load() == 0 {
+		t.Error("expected Call to read its response through the configured BufferPool")
+	}
+}
+
+// countingTestBufferPool wraps a framing.BufferPool and counts Get calls, so
+// a test can confirm a given BufferPool was actually exercised.
+type countingTestBufferPool struct {
+	framing.BufferPool
+	gets atomic.Int32
+}
+
+func (p *countingTestBufferPool) Get(length int) *[]byte {
+	p.gets.Add(1)
+	return p.BufferPool.Get(length)
+}
@@ -8,9 +8,41 @@ import (
 	"os/exec"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+// TransportMode selects how Worker.Start connects to the Python process.
+const (
+	// TransportModeSocketPath has the worker listen on cfg.SocketPath and
+	// waits for its ready byte on a status pipe (see statusFDEnvVar);
+	// callers then dial the socket. This is the default.
+	TransportModeSocketPath = "socketpath"
+	// TransportModeSocketPair creates a syscall.Socketpair before forking,
+	// hands one end to the child via cmd.ExtraFiles, and wraps the other
+	// with net.FileConn - no socket file, no readiness poll.
+	TransportModeSocketPair = "socketpair"
+)
+
+// inheritedFDEnvVar tells the Python worker template which fd (always 3,
+// since ExtraFiles[0] lands right after stdin/stdout/stderr) to wrap as its
+// inherited socketpair connection in TransportModeSocketPair mode.
+const inheritedFDEnvVar = "PYPROC_INHERITED_FD"
+
+// statusFDEnvVar tells the worker template which fd (always 3) to write its
+// single ready/failed status byte to once it has bound its listener and run
+// any user init hooks. See statusByteReady/statusByteFailed.
+const statusFDEnvVar = "PYPROC_STATUS_FD"
+
+const (
+	// statusByteReady means the worker finished initializing and is
+	// listening for requests.
+	statusByteReady = 0x01
+	// statusByteFailed means the worker hit an error during initialization
+	// and is about to exit.
+	statusByteFailed = 0x02
+)
+
 // WorkerState represents the state of a worker
 type WorkerState int32
 
@@ -33,6 +65,22 @@ type WorkerConfig struct {
 	WorkerScript string
 	Env          map[string]string
 	StartTimeout time.Duration
+
+	// TransportMode selects how Start connects to the worker process: one
+	// of TransportModeSocketPath (default) or TransportModeSocketPair.
+	TransportMode string
+
+	// Reattach, if set, makes Start connect to an already-running worker at
+	// Reattach.SocketPath instead of exec'ing PythonExec/WorkerScript. See
+	// ReattachConfig.
+	Reattach *ReattachConfig
+
+	// LameDuckTimeout bounds how long Stop waits after asking the process to
+	// exit gracefully (os.Interrupt) before escalating to SIGKILL, giving
+	// whatever request the process is still finishing time to complete. 0
+	// defaults to 5 seconds. Pool copies its own Config.LameDuckTimeout down
+	// into this field for the workers it spawns.
+	LameDuckTimeout time.Duration
 }
 
 // Worker represents a single Python worker process
@@ -47,6 +95,16 @@ type Worker struct {
 	state    atomic.Int32
 	pid      atomic.Int32
 
+	// conn is the parent-side connection established in
+	// TransportModeSocketPair mode; nil otherwise. Guarded by connMu since
+	// Conn() may be called concurrently with Start().
+	connMu sync.RWMutex
+	conn   net.Conn
+
+	// externallyOwned is set once Start reattaches instead of spawning, so
+	// Stop knows never to signal or kill a process it doesn't own.
+	externallyOwned atomic.Bool
+
 	stopCh chan struct{}
 	doneCh chan struct{}
 }
@@ -65,12 +123,21 @@ func NewWorker(cfg WorkerConfig, logger *Logger) *Worker {
 	}
 }
 
-// Start starts the worker process
+// Start starts the worker process, or reattaches to an already-running one
+// if cfg.Reattach is set.
 func (w *Worker) Start(ctx context.Context) error {
 	if !w.state.CompareAndSwap(int32(WorkerStateStopped), int32(WorkerStateStarting)) {
 		return fmt.Errorf("worker already started or starting")
 	}
 
+	if w.cfg.Reattach != nil {
+		return w.startReattached(ctx)
+	}
+
+	if w.cfg.TransportMode == TransportModeSocketPair {
+		return w.startSocketpair(ctx)
+	}
+
 	w.logger.InfoContext(ctx, "Starting worker",
 		"socket_path", w.cfg.SocketPath,
 		"script", w.cfg.WorkerScript)
@@ -87,6 +154,16 @@ func (w *Worker) Start(ctx context.Context) error {
 			"error", err)
 	}
 
+	// A status pipe tells us exactly when the worker has bound its
+	// listener and finished initializing, instead of polling for the
+	// socket file to appear - which can't distinguish "not listening yet"
+	// from "listening, but still importing dependencies".
+	statusRead, statusWrite, err := os.Pipe()
+	if err != nil {
+		w.state.Store(int32(WorkerStateStopped))
+		return fmt.Errorf("failed to create status pipe: %w", err)
+	}
+
 	// Create the command
 	cmd := exec.CommandContext(ctx, w.cfg.PythonExec, w.cfg.WorkerScript)
 
@@ -96,6 +173,9 @@ func (w *Worker) Start(ctx context.Context) error {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
 	}
 	cmd.Env = append(cmd.Env, fmt.Sprintf("PYPROC_SOCKET_PATH=%s", w.cfg.SocketPath))
+	// ExtraFiles[0] lands at fd 3 in the child (0/1/2 are stdio).
+	cmd.ExtraFiles = []*os.File{statusWrite}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=3", statusFDEnvVar))
 
 	// Capture output for debugging
 	cmd.Stdout = os.Stdout
@@ -103,9 +183,14 @@ func (w *Worker) Start(ctx context.Context) error {
 
 	// Start the process
 	if err := cmd.Start(); err != nil {
+		_ = statusRead.Close()
+		_ = statusWrite.Close()
 		w.state.Store(int32(WorkerStateStopped))
 		return fmt.Errorf("failed to start worker process: %w", err)
 	}
+	// The child now holds the write end; closing our copy means we see
+	// EOF on statusRead if the child exits without ever writing a byte.
+	_ = statusWrite.Close()
 
 	w.cmdMu.Lock()
 	w.cmd = cmd
@@ -114,51 +199,195 @@ func (w *Worker) Start(ctx context.Context) error {
 	w.pid.Store(int32(cmd.Process.Pid))
 	w.logger.InfoContext(ctx, "Worker process started", "pid", cmd.Process.Pid)
 
-	// Wait for the socket to be available
-	socketReady := make(chan error, 1)
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		timeout := time.After(w.cfg.StartTimeout)
-		for {
-			select {
-			case <-ticker.C:
-				// Try to connect to the socket
-				conn, err := net.Dial("unix", w.cfg.SocketPath)
-				if err == nil {
-					_ = conn.Close()
-					socketReady <- nil
-					return
-				}
-			case <-timeout:
-				socketReady <- fmt.Errorf("worker start timeout after %v", w.cfg.StartTimeout)
-				return
-			case <-ctx.Done():
-				socketReady <- ctx.Err()
-				return
-			}
-		}
-	}()
+	// Wait for the worker to signal readiness over the status pipe
+	readyErr := w.waitForReady(ctx, statusRead)
+	_ = statusRead.Close()
 
 	// Start monitoring goroutine
 	go w.monitor()
 
-	// Wait for socket to be ready
-	if err := <-socketReady; err != nil {
+	if readyErr != nil {
 		if err := w.Stop(); err != nil {
-			w.logger.Error("failed to stop worker after socket error", "error", err)
+			w.logger.Error("failed to stop worker after ready-handshake error", "error", err)
 		}
+		return readyErr
+	}
+
+	w.state.Store(int32(WorkerStateRunning))
+	w.logger.InfoContext(ctx, "Worker ready")
+
+	return nil
+}
+
+// waitForReady blocks until the worker writes a single status byte to
+// statusRead (statusByteReady on success, statusByteFailed on
+// initialization error), StartTimeout elapses, or ctx is cancelled -
+// whichever happens first.
+func (w *Worker) waitForReady(ctx context.Context, statusRead *os.File) error {
+	readyCh := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		n, err := statusRead.Read(buf)
+		if err != nil {
+			readyCh <- fmt.Errorf("worker exited before signaling readiness: %w", err)
+			return
+		}
+		if n == 0 {
+			readyCh <- fmt.Errorf("worker closed the status pipe without signaling readiness")
+			return
+		}
+		switch buf[0] {
+		case statusByteReady:
+			readyCh <- nil
+		case statusByteFailed:
+			readyCh <- fmt.Errorf("worker reported an initialization failure")
+		default:
+			readyCh <- fmt.Errorf("worker sent unexpected status byte %#x", buf[0])
+		}
+	}()
+
+	timeout := time.NewTimer(w.cfg.StartTimeout)
+	defer timeout.Stop()
+
+	select {
+	case err := <-readyCh:
 		return err
+	case <-timeout.C:
+		return fmt.Errorf("worker start timeout after %v", w.cfg.StartTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startSocketpair spawns the worker process with one end of a
+// syscall.Socketpair already wired up, skipping socket-file cleanup,
+// permission handling, and the readiness poll entirely: the connection
+// exists before the child process starts running, so there's nothing to
+// wait for.
+func (w *Worker) startSocketpair(ctx context.Context) error {
+	w.logger.InfoContext(ctx, "Starting worker",
+		"transport_mode", TransportModeSocketPair,
+		"script", w.cfg.WorkerScript)
+
+	w.cmdMu.Lock()
+	w.waitOnce = sync.Once{}
+	w.waitErr = nil
+	w.cmdMu.Unlock()
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		w.state.Store(int32(WorkerStateStopped))
+		return fmt.Errorf("failed to create socketpair: %w", err)
+	}
+	parentFile := os.NewFile(uintptr(fds[0]), "pyproc-worker-parent")
+	childFile := os.NewFile(uintptr(fds[1]), "pyproc-worker-child")
+
+	cmd := exec.CommandContext(ctx, w.cfg.PythonExec, w.cfg.WorkerScript)
+
+	cmd.Env = os.Environ()
+	for k, v := range w.cfg.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	// ExtraFiles[0] lands at fd 3 in the child (0/1/2 are stdio).
+	cmd.ExtraFiles = []*os.File{childFile}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=3", inheritedFDEnvVar))
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		_ = parentFile.Close()
+		_ = childFile.Close()
+		w.state.Store(int32(WorkerStateStopped))
+		return fmt.Errorf("failed to start worker process: %w", err)
+	}
+	// The child now has its own copy of the fd; the parent's copy of the
+	// child's end just pins the file descriptor table entry open.
+	_ = childFile.Close()
+
+	conn, err := net.FileConn(parentFile)
+	_ = parentFile.Close() // FileConn dup()s the fd; the os.File is no longer needed.
+	if err != nil {
+		_ = cmd.Process.Kill()
+		w.state.Store(int32(WorkerStateStopped))
+		return fmt.Errorf("failed to wrap socketpair fd: %w", err)
 	}
 
+	w.cmdMu.Lock()
+	w.cmd = cmd
+	w.cmdMu.Unlock()
+
+	w.connMu.Lock()
+	w.conn = conn
+	w.connMu.Unlock()
+
+	w.pid.Store(int32(cmd.Process.Pid))
+	w.logger.InfoContext(ctx, "Worker process started", "pid", cmd.Process.Pid)
+
+	go w.monitor()
+
 	w.state.Store(int32(WorkerStateRunning))
 	w.logger.InfoContext(ctx, "Worker ready")
 
 	return nil
 }
 
-// Stop stops the worker process
+// Conn returns the parent-side connection established in
+// TransportModeSocketPair mode, or nil in TransportModeSocketPath mode.
+// Callers build a transport around it (e.g. NewMultiplexedConnFromConn)
+// instead of dialing GetSocketPath().
+func (w *Worker) Conn() net.Conn {
+	w.connMu.RLock()
+	defer w.connMu.RUnlock()
+	return w.conn
+}
+
+// ID returns the worker's configured ID (see WorkerConfig.ID), e.g. for
+// Pool.Mount to find the worker a caller meant by name.
+func (w *Worker) ID() string {
+	return w.cfg.ID
+}
+
+// startReattached connects to an externally managed worker instead of
+// spawning one: it skips exec and socket cleanup entirely, verifies the
+// socket is reachable, and marks the worker as externally owned so Stop
+// never signals or kills the process.
+func (w *Worker) startReattached(ctx context.Context) error {
+	reattach := w.cfg.Reattach
+	w.logger.InfoContext(ctx, "Reattaching to externally managed worker",
+		"socket_path", reattach.SocketPath, "pid", reattach.PID)
+
+	// Requests and health checks dial w.cfg.SocketPath, so route them at the
+	// reattach target regardless of what the caller set it to.
+	w.cfg.SocketPath = reattach.SocketPath
+
+	timeout := w.cfg.StartTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	conn, err := ConnectToWorker(reattach.SocketPath, timeout)
+	if err != nil {
+		w.state.Store(int32(WorkerStateStopped))
+		return fmt.Errorf("failed to reattach to worker at %s: %w", reattach.SocketPath, err)
+	}
+	_ = conn.Close()
+
+	if reattach.PID > 0 {
+		w.pid.Store(int32(reattach.PID))
+	}
+	w.externallyOwned.Store(true)
+
+	// No *exec.Cmd to wait on; monitor returns immediately but still gives
+	// Stop a doneCh to wait on, same as the spawned-process path.
+	go w.monitor()
+
+	w.state.Store(int32(WorkerStateRunning))
+	w.logger.InfoContext(ctx, "Reattached to worker")
+	return nil
+}
+
+// Stop stops the worker process, or — for a reattached worker — simply
+// detaches from it without signaling or killing anything.
 func (w *Worker) Stop() error {
 	if !w.state.CompareAndSwap(int32(WorkerStateRunning), int32(WorkerStateStopping)) {
 		// Also try from starting state
@@ -172,6 +401,14 @@ func (w *Worker) Stop() error {
 	// Signal stop
 	close(w.stopCh)
 
+	if w.externallyOwned.Load() {
+		<-w.doneCh
+		w.state.Store(int32(WorkerStateStopped))
+		w.pid.Store(0)
+		w.logger.Info("Detached from externally managed worker")
+		return nil
+	}
+
 	// Get the command
 	w.cmdMu.RLock()
 	cmd := w.cmd
@@ -183,6 +420,11 @@ func (w *Worker) Stop() error {
 			w.logger.Warn("Failed to send interrupt signal", "error", err)
 		}
 
+		lameDuck := w.cfg.LameDuckTimeout
+		if lameDuck <= 0 {
+			lameDuck = 5 * time.Second
+		}
+
 		// Wait for process to exit with timeout
 		done := make(chan error, 1)
 		go func() {
@@ -192,8 +434,8 @@ func (w *Worker) Stop() error {
 		select {
 		case <-done:
 			// Process exited gracefully
-		case <-time.After(5 * time.Second):
-			// Force kill after timeout
+		case <-time.After(lameDuck):
+			// Force kill after the lame duck period elapses
 			w.logger.Warn("Worker did not exit gracefully, forcing kill")
 			if err := cmd.Process.Kill(); err != nil {
 				w.logger.Error("Failed to kill worker process", "error", err)
@@ -202,8 +444,15 @@ func (w *Worker) Stop() error {
 		}
 	}
 
-	// Clean up socket file
-	if err := os.Remove(w.cfg.SocketPath); err != nil && !os.IsNotExist(err) {
+	if w.cfg.TransportMode == TransportModeSocketPair {
+		w.connMu.Lock()
+		if w.conn != nil {
+			_ = w.conn.Close()
+			w.conn = nil
+		}
+		w.connMu.Unlock()
+	} else if err := os.Remove(w.cfg.SocketPath); err != nil && !os.IsNotExist(err) {
+		// Clean up socket file
 		w.logger.Warn("Failed to remove socket file", "error", err)
 	}
 
@@ -238,6 +487,16 @@ func (w *Worker) wait() error {
 	return err
 }
 
+// ExitError returns the error from the most recent process exit (nil for a
+// clean exit, or if the worker has never exited yet), as observed by
+// monitor's call to wait(). Supervisor uses it to classify a stopped
+// worker's exit as a crash or not via ClassifyExit.
+func (w *Worker) ExitError() error {
+	w.cmdMu.RLock()
+	defer w.cmdMu.RUnlock()
+	return w.waitErr
+}
+
 // Restart restarts the worker process
 func (w *Worker) Restart(ctx context.Context) error {
 	w.logger.InfoContext(ctx, "Restarting worker")
@@ -319,3 +578,10 @@ func (w *Worker) GetID() string {
 func (w *Worker) GetSocketPath() string {
 	return w.cfg.SocketPath
 }
+
+// IsExternallyOwned reports whether this worker was reattached to an
+// already-running process rather than spawned, i.e. whether Stop will signal
+// or kill anything.
+func (w *Worker) IsExternallyOwned() bool {
+	return w.externallyOwned.Load()
+}
@@ -269,6 +269,135 @@ run_worker("` + socketPath + `")
 	}
 }
 
+func TestWorker_Reattach(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "external.sock")
+
+	// Stand in for a worker started by hand under a debugger: just a
+	// listening socket, no process for pyproc to own.
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %v", socketPath, err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	cfg := WorkerConfig{
+		ID:           "reattached",
+		StartTimeout: 2 * time.Second,
+		Reattach: &ReattachConfig{
+			SocketPath: socketPath,
+			PID:        99999,
+			Transport:  "uds",
+		},
+	}
+
+	worker := NewWorker(cfg, nil)
+	ctx := context.Background()
+
+	if err := worker.Start(ctx); err != nil {
+		t.Fatalf("Failed to reattach: %v", err)
+	}
+
+	if !worker.IsRunning() {
+		t.Error("Reattached worker should be running")
+	}
+	if !worker.IsExternallyOwned() {
+		t.Error("Reattached worker should report externally owned")
+	}
+	if worker.GetPID() != 99999 {
+		t.Errorf("Expected reattach PID to be surfaced, got %d", worker.GetPID())
+	}
+	if worker.GetSocketPath() != socketPath {
+		t.Errorf("Expected socket path %s, got %s", socketPath, worker.GetSocketPath())
+	}
+
+	if err := worker.Stop(); err != nil {
+		t.Errorf("Stop failed: %v", err)
+	}
+	if worker.IsRunning() {
+		t.Error("Worker should not be running after Stop")
+	}
+
+	// Stop must never remove a socket pyproc doesn't own.
+	if _, err := os.Stat(socketPath); err != nil {
+		t.Errorf("Reattach Stop should not remove the external socket: %v", err)
+	}
+}
+
+func TestWorker_ReattachUnreachable(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "nothing-listening.sock")
+
+	cfg := WorkerConfig{
+		ID:           "reattached",
+		StartTimeout: 200 * time.Millisecond,
+		Reattach:     &ReattachConfig{SocketPath: socketPath},
+	}
+
+	worker := NewWorker(cfg, nil)
+	if err := worker.Start(context.Background()); err == nil {
+		t.Fatal("Expected Start to fail when nothing is listening on the reattach socket")
+	}
+	if worker.IsRunning() {
+		t.Error("Worker should not be running after a failed reattach")
+	}
+}
+
+func TestWorker_TransportModeSocketPair(t *testing.T) {
+	cfg := WorkerConfig{
+		ID: "socketpair-worker",
+		// "sleep" stands in for a real worker process: it doesn't touch
+		// fd 3, but it's a real, long-lived child that really does inherit
+		// it, which is what Start/Stop need to exercise here.
+		PythonExec:    "sleep",
+		WorkerScript:  "5",
+		StartTimeout:  2 * time.Second,
+		TransportMode: TransportModeSocketPair,
+	}
+
+	worker := NewWorker(cfg, nil)
+	ctx := context.Background()
+
+	if err := worker.Start(ctx); err != nil {
+		t.Fatalf("Failed to start: %v", err)
+	}
+	defer func() { _ = worker.Stop() }()
+
+	if !worker.IsRunning() {
+		t.Error("Worker should be running")
+	}
+	if worker.GetPID() <= 0 {
+		t.Error("Expected a real PID")
+	}
+
+	conn := worker.Conn()
+	if conn == nil {
+		t.Fatal("Expected a non-nil socketpair connection")
+	}
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Errorf("Expected the parent end of the socketpair to be writable: %v", err)
+	}
+
+	if err := worker.Stop(); err != nil {
+		t.Errorf("Stop failed: %v", err)
+	}
+	if worker.IsRunning() {
+		t.Error("Worker should not be running after Stop")
+	}
+	if worker.Conn() != nil {
+		t.Error("Expected Conn() to be nil after Stop")
+	}
+}
+
 func TestWorker_InvalidScript(t *testing.T) {
 	cfg := WorkerConfig{
 		ID:           "invalid-worker",
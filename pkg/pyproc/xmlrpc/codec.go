@@ -0,0 +1,175 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Fault represents a decoded XML-RPC <fault> response. It also implements
+// error, so server handlers can return *Fault directly to control the
+// faultCode/faultString sent back to the caller.
+type Fault struct {
+	Code   int
+	String string
+}
+
+func (f *Fault) Error() string {
+	return fmt.Sprintf("xmlrpc fault %d: %s", f.Code, f.String)
+}
+
+// EncodeCall renders a methodCall request body for method with the given
+// positional params.
+func EncodeCall(method string, params []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?>`)
+	buf.WriteString(`<methodCall><methodName>`)
+	xml.EscapeText(&buf, []byte(method))
+	buf.WriteString(`</methodName><params>`)
+	for _, p := range params {
+		buf.WriteString(`<param>`)
+		if err := EncodeValue(&buf, p); err != nil {
+			return nil, err
+		}
+		buf.WriteString(`</param>`)
+	}
+	buf.WriteString(`</params></methodCall>`)
+	return buf.Bytes(), nil
+}
+
+// DecodeCall parses a methodCall request body into its method name and
+// positional params.
+func DecodeCall(data []byte) (method string, params []interface{}, err error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, tokErr := dec.Token()
+		if tokErr == io.EOF {
+			break
+		}
+		if tokErr != nil {
+			return "", nil, tokErr
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "methodName":
+			method, err = readText(dec, "methodName")
+			if err != nil {
+				return "", nil, err
+			}
+		case "param":
+			v, err := decodeSingleValue(dec, "param")
+			if err != nil {
+				return "", nil, err
+			}
+			params = append(params, v)
+		}
+	}
+
+	if method == "" {
+		return "", nil, fmt.Errorf("xmlrpc: methodCall is missing methodName")
+	}
+	return method, params, nil
+}
+
+// EncodeResponse renders a successful methodResponse carrying a single
+// result value.
+func EncodeResponse(result interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?>`)
+	buf.WriteString(`<methodResponse><params><param>`)
+	if err := EncodeValue(&buf, result); err != nil {
+		return nil, err
+	}
+	buf.WriteString(`</param></params></methodResponse>`)
+	return buf.Bytes(), nil
+}
+
+// EncodeFault renders a methodResponse carrying a <fault>.
+func EncodeFault(f *Fault) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?>`)
+	buf.WriteString(`<methodResponse><fault>`)
+	if err := EncodeValue(&buf, map[string]interface{}{
+		"faultCode":   f.Code,
+		"faultString": f.String,
+	}); err != nil {
+		return nil, err
+	}
+	buf.WriteString(`</fault></methodResponse>`)
+	return buf.Bytes(), nil
+}
+
+// DecodeResponse parses a methodResponse body. If it carries a <fault>, the
+// returned error is a *Fault describing it; otherwise the single result
+// value is returned with a nil error.
+func DecodeResponse(data []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, tokErr := dec.Token()
+		if tokErr == io.EOF {
+			return nil, fmt.Errorf("xmlrpc: methodResponse has no params or fault")
+		}
+		if tokErr != nil {
+			return nil, tokErr
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "fault":
+			v, err := decodeSingleValue(dec, "fault")
+			if err != nil {
+				return nil, err
+			}
+			return nil, faultFromValue(v)
+		case "param":
+			return decodeSingleValue(dec, "param")
+		}
+	}
+}
+
+// decodeSingleValue reads the single <value> child of an element, up to its
+// matching end tag named closing (used for both <param> and <fault>).
+func decodeSingleValue(dec *xml.Decoder, closing string) (interface{}, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "value" {
+				return nil, fmt.Errorf("xmlrpc: expected <value>, got <%s>", t.Name.Local)
+			}
+			return DecodeValue(dec, t)
+		case xml.EndElement:
+			if t.Name.Local == closing {
+				return nil, nil
+			}
+		}
+	}
+}
+
+func faultFromValue(v interface{}) error {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("xmlrpc: fault value is not a struct: %T", v)
+	}
+	f := &Fault{}
+	if code, ok := m["faultCode"].(int); ok {
+		f.Code = code
+	}
+	if str, ok := m["faultString"].(string); ok {
+		f.String = str
+	}
+	return f
+}
@@ -0,0 +1,121 @@
+package xmlrpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{"int", 42},
+		{"bool true", true},
+		{"bool false", false},
+		{"string", "hello <world> & friends"},
+		{"double", 3.14},
+		{"bytes", []byte("binary data")},
+		{"array", []interface{}{1, "two", 3.0}},
+		{"struct", map[string]interface{}{"a": 1, "b": "two"}},
+		{"nil", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeCall("test.method", []interface{}{tt.in})
+			if err != nil {
+				t.Fatalf("EncodeCall failed: %v", err)
+			}
+
+			method, params, err := DecodeCall(data)
+			if err != nil {
+				t.Fatalf("DecodeCall failed: %v", err)
+			}
+			if method != "test.method" {
+				t.Errorf("expected method %q, got %q", "test.method", method)
+			}
+			if len(params) != 1 {
+				t.Fatalf("expected 1 param, got %d", len(params))
+			}
+
+			switch want := tt.in.(type) {
+			case []byte:
+				got, ok := params[0].([]byte)
+				if !ok || string(got) != string(want) {
+					t.Errorf("expected %v, got %v", want, params[0])
+				}
+			case []interface{}:
+				got, ok := params[0].([]interface{})
+				if !ok || len(got) != len(want) {
+					t.Errorf("expected %v, got %v", want, params[0])
+				}
+			case map[string]interface{}:
+				got, ok := params[0].(map[string]interface{})
+				if !ok || len(got) != len(want) {
+					t.Errorf("expected %v, got %v", want, params[0])
+				}
+			default:
+				if params[0] != tt.in {
+					t.Errorf("expected %v (%T), got %v (%T)", tt.in, tt.in, params[0], params[0])
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeDateTime(t *testing.T) {
+	want := time.Date(2026, 7, 26, 12, 30, 0, 0, time.UTC)
+
+	data, err := EncodeCall("test.method", []interface{}{want})
+	if err != nil {
+		t.Fatalf("EncodeCall failed: %v", err)
+	}
+
+	_, params, err := DecodeCall(data)
+	if err != nil {
+		t.Fatalf("DecodeCall failed: %v", err)
+	}
+
+	got, ok := params[0].(time.Time)
+	if !ok || !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, params[0])
+	}
+}
+
+func TestEncodeDecodeResponse(t *testing.T) {
+	data, err := EncodeResponse(map[string]interface{}{"result": 84})
+	if err != nil {
+		t.Fatalf("EncodeResponse failed: %v", err)
+	}
+
+	result, err := DecodeResponse(data)
+	if err != nil {
+		t.Fatalf("DecodeResponse failed: %v", err)
+	}
+
+	m, ok := result.(map[string]interface{})
+	if !ok || m["result"] != 84 {
+		t.Errorf("expected map with result=84, got %v", result)
+	}
+}
+
+func TestEncodeDecodeFault(t *testing.T) {
+	data, err := EncodeFault(&Fault{Code: 7, String: "no such method"})
+	if err != nil {
+		t.Fatalf("EncodeFault failed: %v", err)
+	}
+
+	_, err = DecodeResponse(data)
+	if err == nil {
+		t.Fatal("expected a fault error")
+	}
+
+	fault, ok := err.(*Fault)
+	if !ok {
+		t.Fatalf("expected *Fault, got %T", err)
+	}
+	if fault.Code != 7 || fault.String != "no such method" {
+		t.Errorf("unexpected fault: %+v", fault)
+	}
+}
@@ -0,0 +1,81 @@
+// Package xmlrpc implements the XML-RPC wire format (method calls,
+// responses, faults, and the full <value> type grammar) along with an
+// http.Handler for serving it, so legacy XML-RPC clients (Supervisor,
+// WordPress, Odoo, ...) can talk to pyproc workers directly.
+package xmlrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Dispatcher invokes method with positional params and returns its result,
+// or an error to be surfaced to the caller as a <fault>. Returning a *Fault
+// lets callers control the faultCode/faultString sent over the wire; any
+// other error is reported as a generic fault.
+type Dispatcher interface {
+	Call(ctx context.Context, method string, params []interface{}) (interface{}, error)
+}
+
+// Handler serves XML-RPC methodCall requests, matching the POST /RPC2
+// convention used by legacy clients.
+type Handler struct {
+	dispatcher Dispatcher
+}
+
+// NewHandler returns a Handler that dispatches calls to d.
+func NewHandler(d Dispatcher) *Handler {
+	return &Handler{dispatcher: d}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "xmlrpc: only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "xmlrpc: failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	method, params, err := DecodeCall(body)
+	if err != nil {
+		h.writeFault(w, &Fault{Code: 4, String: fmt.Sprintf("invalid methodCall: %v", err)})
+		return
+	}
+
+	result, err := h.dispatcher.Call(r.Context(), method, params)
+	if err != nil {
+		var fault *Fault
+		if errors.As(err, &fault) {
+			h.writeFault(w, fault)
+			return
+		}
+		h.writeFault(w, &Fault{Code: 1, String: err.Error()})
+		return
+	}
+
+	resp, err := EncodeResponse(result)
+	if err != nil {
+		h.writeFault(w, &Fault{Code: 2, String: fmt.Sprintf("failed to encode response: %v", err)})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write(resp)
+}
+
+func (h *Handler) writeFault(w http.ResponseWriter, f *Fault) {
+	resp, err := EncodeFault(f)
+	if err != nil {
+		http.Error(w, "xmlrpc: failed to encode fault", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write(resp)
+}
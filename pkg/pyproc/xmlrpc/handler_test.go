@@ -0,0 +1,73 @@
+package xmlrpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type stubDispatcher struct {
+	result interface{}
+	err    error
+}
+
+func (d *stubDispatcher) Call(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+	return d.result, d.err
+}
+
+func TestHandlerServeHTTP(t *testing.T) {
+	h := NewHandler(&stubDispatcher{result: map[string]interface{}{"value": 84}})
+
+	body, err := EncodeCall("predict", []interface{}{map[string]interface{}{"value": 42}})
+	if err != nil {
+		t.Fatalf("EncodeCall failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/RPC2", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	result, err := DecodeResponse(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeResponse failed: %v", err)
+	}
+	m, ok := result.(map[string]interface{})
+	if !ok || m["value"] != 84 {
+		t.Errorf("expected map with value=84, got %v", result)
+	}
+}
+
+func TestHandlerServeHTTPFault(t *testing.T) {
+	h := NewHandler(&stubDispatcher{err: &Fault{Code: 3, String: "method not found"}})
+
+	body, err := EncodeCall("missing", nil)
+	if err != nil {
+		t.Fatalf("EncodeCall failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/RPC2", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	_, err = DecodeResponse(rec.Body.Bytes())
+	fault, ok := err.(*Fault)
+	if !ok {
+		t.Fatalf("expected *Fault, got %v", err)
+	}
+	if fault.Code != 3 {
+		t.Errorf("expected fault code 3, got %d", fault.Code)
+	}
+}
+
+func TestHandlerRejectsNonPost(t *testing.T) {
+	h := NewHandler(&stubDispatcher{})
+
+	req := httptest.NewRequest("GET", "/RPC2", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != 405 {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,309 @@
+package xmlrpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateTimeLayout matches the XML-RPC dateTime.iso8601 value format, which
+// (unlike true ISO 8601) has no separators between date fields.
+const dateTimeLayout = "20060102T15:04:05"
+
+// EncodeValue writes v as a <value>...</value> element to buf. Supported Go
+// types are bool, the int/uint family, float32/float64, string, []byte,
+// time.Time, []interface{}, map[string]interface{}, and nil - mapping to
+// boolean, i4, double, string, base64, dateTime.iso8601, array, struct, and
+// the <nil/> extension respectively.
+func EncodeValue(buf *bytes.Buffer, v interface{}) error {
+	buf.WriteString("<value>")
+	if err := encodeInner(buf, v); err != nil {
+		return err
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+func encodeInner(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("<nil/>")
+	case bool:
+		if val {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		fmt.Fprintf(buf, "<i4>%d</i4>", val)
+	case float32, float64:
+		fmt.Fprintf(buf, "<double>%v</double>", val)
+	case string:
+		buf.WriteString("<string>")
+		xml.EscapeText(buf, []byte(val))
+		buf.WriteString("</string>")
+	case []byte:
+		buf.WriteString("<base64>")
+		buf.WriteString(base64.StdEncoding.EncodeToString(val))
+		buf.WriteString("</base64>")
+	case time.Time:
+		buf.WriteString("<dateTime.iso8601>")
+		buf.WriteString(val.UTC().Format(dateTimeLayout))
+		buf.WriteString("</dateTime.iso8601>")
+	case []interface{}:
+		buf.WriteString("<array><data>")
+		for _, item := range val {
+			if err := EncodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	case map[string]interface{}:
+		buf.WriteString("<struct>")
+		for name, item := range val {
+			buf.WriteString("<member><name>")
+			xml.EscapeText(buf, []byte(name))
+			buf.WriteString("</name>")
+			if err := EncodeValue(buf, item); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct>")
+	default:
+		return fmt.Errorf("xmlrpc: unsupported value type %T", v)
+	}
+	return nil
+}
+
+// DecodeValue reads the content of a <value> element, given its already
+// consumed start tag, and returns the Go representation described by
+// EncodeValue. A <value> with no type element (plain character data) decodes
+// to a string, per the XML-RPC spec's untyped-string shorthand.
+func DecodeValue(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			v, err := decodeTyped(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			if err := skipToEnd(dec, start.Name.Local); err != nil {
+				return nil, err
+			}
+			return v, nil
+		case xml.EndElement:
+			if t.Name.Local == start.Name.Local {
+				return strings.TrimSpace(text.String()), nil
+			}
+		}
+	}
+}
+
+func decodeTyped(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "i4", "int":
+		text, err := readText(dec, start.Name.Local)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid %s value %q: %w", start.Name.Local, text, err)
+		}
+		return int(n), nil
+	case "boolean":
+		text, err := readText(dec, "boolean")
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimSpace(text) == "1", nil
+	case "string":
+		return readText(dec, "string")
+	case "double":
+		text, err := readText(dec, "double")
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid double value %q: %w", text, err)
+		}
+		return f, nil
+	case "dateTime.iso8601":
+		text, err := readText(dec, "dateTime.iso8601")
+		if err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(dateTimeLayout, strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid dateTime.iso8601 value %q: %w", text, err)
+		}
+		return t, nil
+	case "base64":
+		text, err := readText(dec, "base64")
+		if err != nil {
+			return nil, err
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("xmlrpc: invalid base64 value: %w", err)
+		}
+		return data, nil
+	case "nil":
+		return nil, skipToEnd(dec, "nil")
+	case "struct":
+		return decodeStruct(dec)
+	case "array":
+		return decodeArray(dec)
+	default:
+		return nil, fmt.Errorf("xmlrpc: unsupported value element <%s>", start.Name.Local)
+	}
+}
+
+func decodeStruct(dec *xml.Decoder) (interface{}, error) {
+	result := make(map[string]interface{})
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "member" {
+				return nil, fmt.Errorf("xmlrpc: expected <member>, got <%s>", t.Name.Local)
+			}
+			name, value, err := decodeMember(dec)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = value
+		case xml.EndElement:
+			if t.Name.Local == "struct" {
+				return result, nil
+			}
+		}
+	}
+}
+
+func decodeMember(dec *xml.Decoder) (string, interface{}, error) {
+	var name string
+	var value interface{}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "name":
+				text, err := readText(dec, "name")
+				if err != nil {
+					return "", nil, err
+				}
+				name = text
+			case "value":
+				v, err := DecodeValue(dec, t)
+				if err != nil {
+					return "", nil, err
+				}
+				value = v
+			default:
+				return "", nil, fmt.Errorf("xmlrpc: unexpected element <%s> in <member>", t.Name.Local)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "member" {
+				return name, value, nil
+			}
+		}
+	}
+}
+
+func decodeArray(dec *xml.Decoder) (interface{}, error) {
+	var result []interface{}
+	inData := false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "data":
+				inData = true
+			case "value":
+				if !inData {
+					return nil, fmt.Errorf("xmlrpc: <value> outside <data> in <array>")
+				}
+				v, err := DecodeValue(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, v)
+			default:
+				return nil, fmt.Errorf("xmlrpc: unexpected element <%s> in <array>", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "data":
+				inData = false
+			case "array":
+				return result, nil
+			}
+		}
+	}
+}
+
+// readText reads character data up to the matching end tag named name,
+// assuming no nested elements (true for every scalar value element).
+func readText(dec *xml.Decoder, name string) (string, error) {
+	var sb strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.EndElement:
+			if t.Name.Local == name {
+				return sb.String(), nil
+			}
+		}
+	}
+}
+
+// skipToEnd discards tokens, tracking nested element depth, until the
+// matching end tag named name is found at depth 0.
+func skipToEnd(dec *xml.Decoder, name string) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 && t.Name.Local == name {
+				return nil
+			}
+			depth--
+		}
+	}
+}
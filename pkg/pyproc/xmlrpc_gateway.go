@@ -0,0 +1,56 @@
+package pyproc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/YuminosukeSato/pyproc/pkg/pyproc/xmlrpc"
+)
+
+// PoolDispatcher adapts a Pool to xmlrpc.Dispatcher, so legacy XML-RPC
+// clients can call registered Python methods without a Go-side shim. A
+// single XML-RPC param is passed through as Pool.Call's input unchanged;
+// zero or multiple params are passed as nil or []interface{} respectively.
+type PoolDispatcher struct {
+	Pool *Pool
+}
+
+// Call implements xmlrpc.Dispatcher.
+func (d *PoolDispatcher) Call(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+	var input interface{}
+	switch len(params) {
+	case 0:
+		input = nil
+	case 1:
+		input = params[0]
+	default:
+		input = params
+	}
+
+	var output interface{}
+	if err := d.Pool.Call(ctx, method, input, &output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// NewXMLRPCServer builds an *http.Server and a net.Listener that serve
+// XML-RPC over a Unix domain socket at socketPath, dispatching calls to
+// pool. Start serving with srv.Serve(ln) on a new goroutine; shutting down
+// srv (e.g. via srv.Shutdown) closes ln.
+func NewXMLRPCServer(socketPath string, pool *Pool) (srv *http.Server, ln net.Listener, err error) {
+	_ = os.Remove(socketPath)
+
+	ln, err = net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pyproc: failed to listen on %s: %w", socketPath, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/RPC2", xmlrpc.NewHandler(&PoolDispatcher{Pool: pool}))
+
+	return &http.Server{Handler: mux}, ln, nil
+}